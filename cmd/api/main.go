@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/cmd/shared"
+)
+
+func main() {
+	if err := shared.SetupLogging(slog.LevelInfo); err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	tracerProvider, err := shared.SetupTracing(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	apiHandler, err := shared.BuildAPIHandler()
+	if err != nil {
+		log.Fatalf("Failed to initialize app: %v", err)
+	}
+	interactionHandler, err := shared.BuildInteractionHandler()
+	if err != nil {
+		log.Fatalf("Failed to initialize app: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/v1/", apiHandler)
+	if interactionHandler != nil {
+		mux.Handle("POST /interactions", interactionHandler)
+	}
+
+	addr := os.Getenv("API_ADDR")
+	if addr == "" {
+		addr = ":8081"
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("API server failed: %v", err)
+		}
+	}()
+	slog.Info("API server started", "addr", addr, "interactions", interactionHandler != nil)
+
+	<-ctx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shut down API server", "error", err)
+	}
+	if interactionHandler != nil {
+		// Deferred slash commands still owe Discord a follow-up.
+		interactionHandler.Wait()
+	}
+	if err := tracerProvider.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shut down tracing", "error", err)
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"log"
+	"log/slog"
+
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/cmd/shared"
+
+	lambdaHandler "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/lambda"
+)
+
+func main() {
+	if err := shared.SetupLogging(slog.LevelInfo); err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+
+	handler, err := shared.BuildAPIHandler()
+	if err != nil {
+		log.Fatalf("Failed to initialize app: %v", err)
+	}
+
+	lambda.Start(lambdaHandler.NewHTTPHandler(handler).HandleRequest)
+}
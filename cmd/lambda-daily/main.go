@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"log/slog"
 
 	"github.com/aws/aws-lambda-go/lambda"
 
@@ -12,7 +13,16 @@ import (
 )
 
 func main() {
+	if err := shared.SetupLogging(slog.LevelInfo); err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+
 	ctx := context.Background()
+	tracerProvider, err := shared.SetupTracing(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
 	eventService, err := shared.BuildEventService(ctx)
 	if err != nil {
 		log.Fatalf("Failed to initialize app: %v", err)
@@ -20,5 +30,5 @@ func main() {
 
 	handler := lambdaHandler.NewDailyHandler(eventService)
 
-	lambda.Start(handler.HandleRequest)
+	lambda.Start(lambdaHandler.FlushAfter(handler.HandleRequest, tracerProvider))
 }
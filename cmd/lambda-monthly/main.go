@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"log"
+	"log/slog"
+
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/cmd/shared"
+
+	lambdaHandler "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/lambda"
+)
+
+func main() {
+	if err := shared.SetupLogging(slog.LevelInfo); err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+
+	ctx := context.Background()
+	tracerProvider, err := shared.SetupTracing(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	eventService, err := shared.BuildEventService(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize app: %v", err)
+	}
+
+	handler := lambdaHandler.NewMonthlyHandler(eventService)
+
+	lambda.Start(lambdaHandler.FlushAfter(handler.HandleRequest, tracerProvider))
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// runCapabilities implements "local capabilities", which lists what each venue's fetcher
+// can provide.
+func runCapabilities(w io.Writer, fetchers []ports.EventFetcher) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "VENUE\tEARLIEST\tHORIZON\tOPEN TIME\tEND TIME\tFIRST PAGE ONLY\tMAX REQUESTS")
+	for _, f := range fetchers {
+		caps := ports.CapabilitiesOf(f)
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%d\n", f.VenueID(), dateOrDash(caps.Earliest), dateOrDash(caps.Horizon),
+			yesNo(caps.OpenTime), yesNo(caps.EndTime), yesNo(caps.FirstPageOnly),
+			max(caps.MaxConcurrentRequests, 1))
+	}
+
+	return tw.Flush()
+}
+
+// skipReason explains why a venue cannot be fetched for date, or returns "" when it can.
+func skipReason(caps ports.Capabilities, date time.Time) string {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+	switch {
+	case !caps.Earliest.IsZero() && day.Before(caps.Earliest):
+		return fmt.Sprintf("no longer listed; the site lists events from %s", caps.Earliest.Format("2006-01-02"))
+	case !caps.Horizon.IsZero() && day.After(caps.Horizon):
+		return fmt.Sprintf("not published yet; the site lists events up to %s", caps.Horizon.Format("2006-01-02"))
+	}
+	return ""
+}
+
+func dateOrDash(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+)
+
+func TestRunCapabilities(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	arena := mock_ports.NewMockEventFetcher(ctrl)
+	arena.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	stadium := mock_ports.NewMockCapableEventFetcher(ctrl)
+	stadium.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+	stadium.EXPECT().Capabilities().Return(ports.Capabilities{
+		Earliest:              time.Date(2026, 10, 1, 0, 0, 0, 0, event.JST),
+		Horizon:               time.Date(2026, 11, 30, 0, 0, 0, 0, event.JST),
+		MaxConcurrentRequests: 5,
+	})
+
+	var buf bytes.Buffer
+	err := runCapabilities(&buf, []ports.EventFetcher{arena, stadium})
+
+	require.NoError(t, err)
+	assert.Equal(t, `VENUE           EARLIEST    HORIZON     OPEN TIME  END TIME  FIRST PAGE ONLY  MAX REQUESTS
+yokohama_arena  -           -           no         no        no               1
+nissan_stadium  2026-10-01  2026-11-30  no         no        no               5
+`, buf.String())
+}
+
+func TestSkipReason(t *testing.T) {
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+	earliest := time.Date(2026, 10, 1, 0, 0, 0, 0, event.JST)
+	horizon := time.Date(2026, 11, 30, 0, 0, 0, 0, event.JST)
+
+	testCases := []struct {
+		date time.Time
+		name string
+		want string
+		caps ports.Capabilities
+	}{
+		{name: "today", caps: ports.Capabilities{Horizon: horizon}, date: today.Add(9 * time.Hour)},
+		{name: "last published day", caps: ports.Capabilities{Horizon: horizon}, date: horizon.Add(9 * time.Hour)},
+		{name: "past horizon", caps: ports.Capabilities{Horizon: horizon}, date: horizon.AddDate(0, 0, 1), want: "not published yet; the site lists events up to 2026-11-30"},
+		{name: "first listed day", caps: ports.Capabilities{Earliest: earliest}, date: earliest.Add(9 * time.Hour)},
+		{name: "before earliest", caps: ports.Capabilities{Earliest: earliest}, date: earliest.AddDate(0, 0, -1), want: "no longer listed; the site lists events from 2026-10-01"},
+		{name: "past date without limit", caps: ports.Capabilities{}, date: today.AddDate(0, 0, -30)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, skipReason(tc.caps, tc.date))
+		})
+	}
+}
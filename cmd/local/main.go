@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
@@ -10,31 +11,59 @@ import (
 	"sort"
 	"time"
 
-	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/cmd/shared"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
-	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/discord"
-	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/fetcher"
 )
 
 func main() {
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
-		Level: slog.LevelDebug,
-	})))
+	if err := shared.SetupLogging(slog.LevelDebug); err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
 
-	sendFlag := flag.Bool("send", false, "Send notification to Discord (requires DISCORD_WEBHOOK_URL)")
+	if len(os.Args) > 1 && os.Args[1] == "stats" {
+		if err := runStats(context.Background(), os.Args[2:], os.Stdout); err != nil && !errors.Is(err, flag.ErrHelp) {
+			log.Fatalf("Failed to compute stats: %v", err)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "capabilities" {
+		fetchers, err := shared.BuildFetchers()
+		if err != nil {
+			log.Fatalf("Failed to initialize fetchers: %v", err)
+		}
+		if err := runCapabilities(os.Stdout, fetchers); err != nil {
+			log.Fatalf("Failed to list capabilities: %v", err)
+		}
+		return
+	}
+
+	sendFlag := flag.Bool("send", false, "Send notification to Discord (requires DISCORD_WEBHOOK_URL, CONFIG_FILE or another configuration source)")
+	dateFlag := flag.String("date", "", "Target date in YYYY-MM-DD (defaults to today in JST)")
 	flag.Parse()
 
 	ctx := context.Background()
 
-	fetchers := []ports.EventFetcher{
-		fetcher.NewYokohamaArenaFetcher(),
-		fetcher.NewNissanStadiumFetcher(),
-		fetcher.NewSkateCenterFetcher(),
+	tracerProvider, err := shared.SetupTracing(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	fetchers, err := shared.BuildFetchers()
+	if err != nil {
+		log.Fatalf("Failed to initialize fetchers: %v", err)
 	}
 
-	jst := time.FixedZone("JST", 9*60*60)
-	today := time.Now().In(jst)
+	now := time.Now().In(event.JST)
+	today := now
+	if *dateFlag != "" {
+		d, err := time.ParseInLocation("2006-01-02", *dateFlag, event.JST)
+		if err != nil {
+			log.Fatalf("Invalid --date: %v", err)
+		}
+		today = d
+	}
 
 	venues := event.NewAllVenues()
 	venueMap := make(map[event.VenueID]*event.Venue)
@@ -47,6 +76,13 @@ func main() {
 	for _, fetcher := range fetchers {
 		venue := venueMap[fetcher.VenueID()]
 
+		caps := ports.CapabilitiesOf(fetcher)
+		if reason := skipReason(caps, today); reason != "" {
+			fmt.Printf("[%s]\n", venue.DisplayName)
+			fmt.Printf("  (%s)\n\n", reason)
+			continue
+		}
+
 		events, err := fetcher.FetchEvents(ctx, today, today)
 
 		if err != nil {
@@ -57,35 +93,40 @@ func main() {
 		}
 
 		venue.Events = events
-		printVenue(venue)
+		printVenue(venue, caps)
 	}
 
 	if *sendFlag {
-		webhookURL := os.Getenv("DISCORD_WEBHOOK_URL")
-		if webhookURL == "" {
-			log.Fatal("DISCORD_WEBHOOK_URL environment variable is required when using --send")
+		eventService, err := shared.BuildEventService(ctx)
+		if err != nil {
+			log.Fatalf("Failed to build event service: %v", err)
 		}
 
-		discordSender := discord.NewWebhookAdapter(webhookURL)
-		eventService := service.NewEventNotificationService(discordSender, fetchers)
-
-		if err := eventService.NotifyTodayEvents(ctx); err != nil {
+		if err := eventService.NotifyEventsForDate(ctx, today); err != nil {
 			log.Fatalf("Failed to send notification: %v", err)
 		}
 
 		fmt.Println("Notification sent to Discord")
 	}
 
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		slog.Warn("failed to shut down tracing", "error", err)
+	}
+
 	if hasError {
 		os.Exit(1)
 	}
 }
 
-func printVenue(venue *event.Venue) {
+func printVenue(venue *event.Venue, caps ports.Capabilities) {
 	fmt.Printf("[%s]\n", venue.DisplayName)
 
 	if len(venue.Events) == 0 {
-		fmt.Println("  (none)")
+		if caps.FirstPageOnly {
+			fmt.Println("  (none; only the first page of upcoming events is read, so later events may be missing)")
+		} else {
+			fmt.Println("  (none)")
+		}
 		fmt.Println()
 		return
 	}
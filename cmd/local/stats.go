@@ -0,0 +1,227 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/cmd/shared"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+)
+
+// Output formats of the stats subcommand.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// runStats implements "local stats", which summarises the event archive selected by
+// ARCHIVE_STORE.
+func runStats(ctx context.Context, args []string, w io.Writer) error {
+	now := time.Now().In(event.JST)
+
+	fs := flag.NewFlagSet("stats", flag.ContinueOnError)
+	fromFlag := fs.String("from", fmt.Sprintf("%d-01-01", now.Year()), "First date in YYYY-MM-DD (defaults to January 1 of this year)")
+	toFlag := fs.String("to", now.Format("2006-01-02"), "Last date in YYYY-MM-DD (defaults to today in JST)")
+	venueFlag := fs.String("venue", "", "Comma-separated venue IDs to include (defaults to every venue)")
+	formatFlag := fs.String("format", formatTable, "Output format: table, json or csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	from, err := time.ParseInLocation("2006-01-02", *fromFlag, event.JST)
+	if err != nil {
+		return fmt.Errorf("invalid --from: %w", err)
+	}
+	to, err := time.ParseInLocation("2006-01-02", *toFlag, event.JST)
+	if err != nil {
+		return fmt.Errorf("invalid --to: %w", err)
+	}
+
+	var venueIDs []event.VenueID
+	if *venueFlag != "" {
+		for _, id := range strings.Split(*venueFlag, ",") {
+			venueIDs = append(venueIDs, event.VenueID(strings.TrimSpace(id)))
+		}
+	}
+
+	var render func(io.Writer, *service.EventStats) error
+	switch *formatFlag {
+	case formatTable:
+		render = renderStatsTable
+	case formatJSON:
+		render = renderStatsJSON
+	case formatCSV:
+		render = renderStatsCSV
+	default:
+		return fmt.Errorf("invalid --format %q: must be %s, %s or %s", *formatFlag, formatTable, formatJSON, formatCSV)
+	}
+
+	eventArchive, err := shared.BuildArchive()
+	if err != nil {
+		return err
+	}
+	if eventArchive == nil {
+		return errors.New("no event archive configured: set ARCHIVE_STORE")
+	}
+
+	stats, err := service.NewStatsService(eventArchive).Stats(ctx, from, to, venueIDs...)
+	if err != nil {
+		return err
+	}
+	return render(w, stats)
+}
+
+func formatClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+func joinVenues(ids []event.VenueID) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = string(id)
+	}
+	return strings.Join(names, ";")
+}
+
+func renderStatsTable(w io.Writer, stats *service.EventStats) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "Period: %s to %s, %d events\n", stats.From.Format("2006-01-02"), stats.To.Format("2006-01-02"), stats.TotalEvents)
+
+	fmt.Fprintln(tw, "\nVENUE\tEVENTS\tWEEKDAY (MON-FRI)")
+	for _, v := range stats.Venues {
+		fmt.Fprintf(tw, "%s\t%d\t%d\n", v.Venue, v.Events, v.WeekdayEvents)
+	}
+
+	fmt.Fprintln(tw, "\nVENUE\tMONTH\tEVENTS")
+	for _, m := range stats.Monthly {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", m.Venue, m.Month, m.Events)
+	}
+
+	fmt.Fprintln(tw, "\nVENUE\tWEEKDAY\tEVENTS")
+	for _, wd := range stats.Weekdays {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", wd.Venue, wd.Weekday, wd.Events)
+	}
+
+	fmt.Fprintln(tw, "\nVENUE\tAVERAGE START\tEVENTS WITH START TIME")
+	for _, st := range stats.StartTimes {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", st.Venue, formatClock(st.Average), st.Events)
+	}
+
+	fmt.Fprintf(tw, "\nDAYS WITH 2+ VENUES (%d)\tVENUES\n", len(stats.MultiVenueDays))
+	for _, d := range stats.MultiVenueDays {
+		fmt.Fprintf(tw, "%s (%s)\t%s\n", d.Date.Format("2006-01-02"), d.Date.Weekday().String()[:3], joinVenues(d.Venues))
+	}
+
+	return tw.Flush()
+}
+
+type statsJSON struct {
+	From           string           `json:"from"`
+	To             string           `json:"to"`
+	Venues         []venueTotalJSON `json:"venues"`
+	Monthly        []monthlyJSON    `json:"monthly"`
+	Weekdays       []weekdayJSON    `json:"weekdays"`
+	StartTimes     []startTimeJSON  `json:"start_times"`
+	MultiVenueDays []multiVenueJSON `json:"multi_venue_days"`
+	TotalEvents    int              `json:"total_events"`
+}
+
+type venueTotalJSON struct {
+	Venue         event.VenueID `json:"venue"`
+	Events        int           `json:"events"`
+	WeekdayEvents int           `json:"weekday_events"`
+}
+
+type monthlyJSON struct {
+	Venue  event.VenueID `json:"venue"`
+	Month  string        `json:"month"`
+	Events int           `json:"events"`
+}
+
+type weekdayJSON struct {
+	Venue   event.VenueID `json:"venue"`
+	Weekday string        `json:"weekday"`
+	Events  int           `json:"events"`
+}
+
+type startTimeJSON struct {
+	Venue   event.VenueID `json:"venue"`
+	Average string        `json:"average"`
+	Events  int           `json:"events"`
+}
+
+type multiVenueJSON struct {
+	Date   string          `json:"date"`
+	Venues []event.VenueID `json:"venues"`
+}
+
+func renderStatsJSON(w io.Writer, stats *service.EventStats) error {
+	doc := statsJSON{
+		From:           stats.From.Format("2006-01-02"),
+		To:             stats.To.Format("2006-01-02"),
+		TotalEvents:    stats.TotalEvents,
+		Venues:         []venueTotalJSON{},
+		Monthly:        []monthlyJSON{},
+		Weekdays:       []weekdayJSON{},
+		StartTimes:     []startTimeJSON{},
+		MultiVenueDays: []multiVenueJSON{},
+	}
+	for _, v := range stats.Venues {
+		doc.Venues = append(doc.Venues, venueTotalJSON(v))
+	}
+	for _, m := range stats.Monthly {
+		doc.Monthly = append(doc.Monthly, monthlyJSON(m))
+	}
+	for _, wd := range stats.Weekdays {
+		doc.Weekdays = append(doc.Weekdays, weekdayJSON{Venue: wd.Venue, Weekday: wd.Weekday.String(), Events: wd.Events})
+	}
+	for _, st := range stats.StartTimes {
+		doc.StartTimes = append(doc.StartTimes, startTimeJSON{Venue: st.Venue, Average: formatClock(st.Average), Events: st.Events})
+	}
+	for _, d := range stats.MultiVenueDays {
+		doc.MultiVenueDays = append(doc.MultiVenueDays, multiVenueJSON{Date: d.Date.Format("2006-01-02"), Venues: d.Venues})
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// renderStatsCSV writes every section as rows of one table, so the output loads into a
+// spreadsheet as is. For days with 2+ venues, venue lists the venues separated by ";"
+// and count is the number of venues.
+func renderStatsCSV(w io.Writer, stats *service.EventStats) error {
+	cw := csv.NewWriter(w)
+	rows := [][]string{{"section", "venue", "key", "count"}}
+	for _, v := range stats.Venues {
+		rows = append(rows,
+			[]string{"total", string(v.Venue), "all", strconv.Itoa(v.Events)},
+			[]string{"total", string(v.Venue), "weekday", strconv.Itoa(v.WeekdayEvents)},
+		)
+	}
+	for _, m := range stats.Monthly {
+		rows = append(rows, []string{"monthly", string(m.Venue), m.Month, strconv.Itoa(m.Events)})
+	}
+	for _, wd := range stats.Weekdays {
+		rows = append(rows, []string{"weekday", string(wd.Venue), wd.Weekday.String(), strconv.Itoa(wd.Events)})
+	}
+	for _, st := range stats.StartTimes {
+		rows = append(rows, []string{"average_start", string(st.Venue), formatClock(st.Average), strconv.Itoa(st.Events)})
+	}
+	for _, d := range stats.MultiVenueDays {
+		rows = append(rows, []string{"multi_venue_day", joinVenues(d.Venues), d.Date.Format("2006-01-02"), strconv.Itoa(len(d.Venues))})
+	}
+	return cw.WriteAll(rows)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+)
+
+func testStats() *service.EventStats {
+	return &service.EventStats{
+		From:        time.Date(2026, 1, 1, 0, 0, 0, 0, event.JST),
+		To:          time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST),
+		TotalEvents: 3,
+		Venues:      []service.VenueTotal{{Venue: event.VenueIDNissanStadium, Events: 3, WeekdayEvents: 1}},
+		Monthly:     []service.MonthlyCount{{Venue: event.VenueIDNissanStadium, Month: "2026-10", Events: 3}},
+		Weekdays:    []service.WeekdayCount{{Venue: event.VenueIDNissanStadium, Weekday: time.Saturday, Events: 2}},
+		StartTimes:  []service.StartTimeStat{{Venue: event.VenueIDNissanStadium, Average: 16*time.Hour + 5*time.Minute, Events: 2}},
+		MultiVenueDays: []service.MultiVenueDay{
+			{Date: time.Date(2026, 10, 17, 0, 0, 0, 0, event.JST), Venues: []event.VenueID{event.VenueIDYokohamaArena, event.VenueIDNissanStadium}},
+		},
+	}
+}
+
+func TestRenderStatsCSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, renderStatsCSV(&buf, testStats()))
+
+	assert.Equal(t, `section,venue,key,count
+total,nissan_stadium,all,3
+total,nissan_stadium,weekday,1
+monthly,nissan_stadium,2026-10,3
+weekday,nissan_stadium,Saturday,2
+average_start,nissan_stadium,16:05,2
+multi_venue_day,yokohama_arena;nissan_stadium,2026-10-17,2
+`, buf.String())
+}
+
+func TestRenderStatsJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, renderStatsJSON(&buf, testStats()))
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, "2026-01-01", doc["from"])
+	assert.Equal(t, float64(3), doc["total_events"])
+	assert.Equal(t, "16:05", doc["start_times"].([]any)[0].(map[string]any)["average"])
+	assert.Equal(t, "Saturday", doc["weekdays"].([]any)[0].(map[string]any)["weekday"])
+}
+
+func TestRenderStatsTable(t *testing.T) {
+	var buf bytes.Buffer
+
+	require.NoError(t, renderStatsTable(&buf, testStats()))
+
+	assert.Contains(t, buf.String(), "Period: 2026-01-01 to 2026-10-18, 3 events")
+	assert.Contains(t, buf.String(), "2026-10-17 (Sat)")
+}
+
+func TestRunStats_Errors(t *testing.T) {
+	t.Setenv("ARCHIVE_STORE", "")
+
+	assert.ErrorContains(t, runStats(context.Background(), []string{"--format", "xml"}, &bytes.Buffer{}), "invalid --format")
+	assert.ErrorContains(t, runStats(context.Background(), []string{"--from", "2026/01/01"}, &bytes.Buffer{}), "invalid --from")
+	assert.ErrorContains(t, runStats(context.Background(), nil, &bytes.Buffer{}), "ARCHIVE_STORE")
+}
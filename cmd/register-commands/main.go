@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/discord"
+)
+
+func main() {
+	guildID := flag.String("guild", os.Getenv("DISCORD_GUILD_ID"), "Register in a single guild (applies immediately) instead of globally")
+	flag.Parse()
+
+	applicationID := os.Getenv("DISCORD_APPLICATION_ID")
+	botToken := os.Getenv("DISCORD_BOT_TOKEN")
+	if applicationID == "" || botToken == "" {
+		log.Fatal("DISCORD_APPLICATION_ID and DISCORD_BOT_TOKEN are required")
+	}
+
+	registrar := discord.NewCommandRegistrar(applicationID, botToken)
+	if err := registrar.Register(context.Background(), *guildID, discord.Commands()); err != nil {
+		log.Fatalf("Failed to register commands: %v", err)
+	}
+
+	if *guildID != "" {
+		log.Printf("Registered /%s in guild %s", discord.CommandName, *guildID)
+	} else {
+		log.Printf("Registered /%s globally (may take up to an hour to appear)", discord.CommandName)
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/cmd/shared"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/scheduler"
+)
+
+const (
+	// Defaults match var.schedule_expression and var.tomorrow_schedule_expression in terraform/.
+	defaultSchedule         = "cron(0 6 * * ? *)"
+	defaultTomorrowSchedule = "cron(0 21 * * ? *)"
+	defaultHealthAddr       = ":8080"
+)
+
+func main() {
+	if err := shared.SetupLogging(slog.LevelInfo); err != nil {
+		log.Fatalf("Failed to initialize logging: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer stop()
+
+	tracerProvider, err := shared.SetupTracing(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
+	eventService, err := shared.BuildEventService(ctx)
+	if err != nil {
+		log.Fatalf("Failed to initialize app: %v", err)
+	}
+
+	jobs, err := buildJobs(eventService)
+	if err != nil {
+		log.Fatalf("Failed to configure schedule: %v", err)
+	}
+
+	sched := scheduler.New(jobs)
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /healthz", sched.HealthHandler())
+	server := &http.Server{
+		Addr:              envOrDefault("HEALTH_ADDR", defaultHealthAddr),
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Health server failed: %v", err)
+		}
+	}()
+
+	slog.Info("scheduler started", "health_addr", server.Addr)
+	for _, st := range sched.Status() {
+		slog.Info("job scheduled", "job", st.Name, "next_run", st.NextRun)
+	}
+
+	sched.Run(ctx)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shut down health server", "error", err)
+	}
+	if err := tracerProvider.Shutdown(shutdownCtx); err != nil {
+		slog.Error("failed to shut down tracing", "error", err)
+	}
+	slog.Info("scheduler stopped")
+}
+
+// buildJobs mirrors the AWS deployment: the morning job runs the monthly outlook on the
+// 1st and the weekly digest on Mondays before the daily notification (as the Step
+// Functions workflow does), and the evening
+// job sends the preview of tomorrow. Setting TOMORROW_SCHEDULE_EXPRESSION to "off"
+// disables the preview.
+func buildJobs(eventService *service.EventNotificationService) ([]scheduler.Job, error) {
+	morning, err := scheduler.ParseCron(envOrDefault("SCHEDULE_EXPRESSION", defaultSchedule), event.JST)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []scheduler.Job{{
+		Name:     "morning",
+		Schedule: morning,
+		Run: func(ctx context.Context) error {
+			return runMorning(ctx, eventService)
+		},
+	}}
+
+	if expr := envOrDefault("TOMORROW_SCHEDULE_EXPRESSION", defaultTomorrowSchedule); expr != "off" {
+		tomorrow, err := scheduler.ParseCron(expr, event.JST)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, scheduler.Job{
+			Name:     "tomorrow",
+			Schedule: tomorrow,
+			Run:      eventService.NotifyTomorrowEvents,
+		})
+	}
+
+	return jobs, nil
+}
+
+// runMorning keeps going with the next notification when the monthly outlook or the
+// weekly digest fails, matching the Catches in the Step Functions definition. The day is
+// taken from the service so the decisions match the dates it renders.
+func runMorning(ctx context.Context, eventService *service.EventNotificationService) error {
+	today := eventService.Today()
+
+	var errs []error
+	if today.Day() == 1 {
+		if err := eventService.NotifyMonthlyEvents(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify monthly events: %w", err))
+		}
+	}
+	if today.Weekday() == time.Monday {
+		if err := eventService.NotifyWeeklyEvents(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to notify weekly events: %w", err))
+		}
+	}
+
+	if err := eventService.NotifyTodayEvents(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to notify today events: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
+func envOrDefault(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
@@ -3,12 +3,25 @@ package shared
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/archive"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/config"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/discord"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/fetcher"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/httpapi"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/httpcache"
+	lambdaHandler "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/lambda"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/metrics"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/snapshot"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/tracing"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
 var loadConfig = config.LoadConfig
@@ -19,14 +32,259 @@ func BuildEventService(ctx context.Context) (*service.EventNotificationService,
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
 
-	fetchers := []ports.EventFetcher{
-		fetcher.NewYokohamaArenaFetcher(),
-		fetcher.NewNissanStadiumFetcher(),
-		fetcher.NewSkateCenterFetcher(),
+	fetchers, err := BuildFetchers()
+	if err != nil {
+		return nil, err
+	}
+
+	recorder, err := BuildMetrics()
+	if err != nil {
+		return nil, err
+	}
+
+	fallbackOptions, err := config.LoadFallbackOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	eventArchive, err := BuildArchive()
+	if err != nil {
+		return nil, err
+	}
+
+	adapterOptions := buildAdapterOptions(cfg.Discord)
+	if recorder != nil {
+		adapterOptions = append(adapterOptions, discord.WithMetrics(recorder))
+	}
+	destinations := make([]service.Destination, 0, len(cfg.Destinations))
+	for _, d := range cfg.Destinations {
+		policy := cfg.Policy
+		policy.RoleMentions = append(append([]config.RoleMention(nil), cfg.Policy.RoleMentions...), d.RoleMentions...)
+		opts := append([]discord.AdapterOption(nil), adapterOptions...)
+		opts = append(opts, discord.WithDestinationName(d.Name))
+		if cfg.Source != nil {
+			opts = append(opts, discord.WithWebhookURLProvider(config.NewDestinationWebhook(cfg.Source, d.Name)))
+		}
+		destinations = append(destinations, service.Destination{
+			Name:          d.Name,
+			Sender:        discord.NewWebhookAdapter(d.WebhookURL, opts...),
+			FailureNotice: service.FailureNotice(d.FailureNotice),
+			Policy:        buildPolicy(policy),
+		})
+	}
+	if len(destinations) == 0 {
+		return nil, fmt.Errorf("no notification destinations configured")
+	}
+
+	serviceOptions := []service.Option{
+		service.WithDestinations(destinations...),
+		service.WithSkipEmptyTomorrow(cfg.SkipEmptyTomorrow),
+	}
+	if recorder != nil {
+		serviceOptions = append(serviceOptions, service.WithMetrics(recorder))
+	}
+	if store := buildFallbackStore(fallbackOptions); store != nil {
+		serviceOptions = append(serviceOptions, service.WithFallback(store, fallbackOptions.MaxAge))
+	}
+	if eventArchive != nil {
+		serviceOptions = append(serviceOptions, service.WithArchive(eventArchive))
+	}
+	if cfg.MaintainerWebhookURL != "" {
+		maintainer := discord.NewWebhookAdapter(cfg.MaintainerWebhookURL)
+		serviceOptions = append(serviceOptions, service.WithMaintainerAlerts(maintainer))
+		if link := lambdaHandler.LogLinkFromEnv(); link != nil {
+			serviceOptions = append(serviceOptions, service.WithLogLink(link))
+		}
+		if cfg.RunReports == config.RunReportsAll || cfg.RunReports == config.RunReportsProblems {
+			serviceOptions = append(serviceOptions, service.WithRunReports(maintainer, cfg.RunReports == config.RunReportsProblems))
+		}
 	}
 
-	discordSender := discord.NewWebhookAdapter(cfg.DiscordWebhookURL)
-	eventService := service.NewEventNotificationService(discordSender, fetchers)
+	eventService := service.NewEventNotificationService(destinations[0].Sender, fetchers, serviceOptions...)
 
 	return eventService, nil
 }
+
+// BuildFetchers returns a fetcher for every supported venue, sharing the response cache
+// configured by FETCH_CACHE.
+func BuildFetchers() ([]ports.EventFetcher, error) {
+	cacheOptions, err := config.LoadFetchCacheOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	store := buildFetchCacheStore(cacheOptions)
+	options := func(venueID event.VenueID) []fetcher.Option {
+		if store == nil {
+			return nil
+		}
+		return []fetcher.Option{
+			fetcher.WithTransport(httpcache.NewTransport(store, cacheOptions.TTLFor(string(venueID)))),
+		}
+	}
+
+	return []ports.EventFetcher{
+		fetcher.NewYokohamaArenaFetcher(options(event.VenueIDYokohamaArena)...),
+		fetcher.NewNissanStadiumFetcher(options(event.VenueIDNissanStadium)...),
+		fetcher.NewSkateCenterFetcher(options(event.VenueIDSkateCenter)...),
+	}, nil
+}
+
+func buildFetchCacheStore(o config.FetchCacheOptions) httpcache.Store {
+	switch o.Backend {
+	case config.FetchCacheMemory:
+		return httpcache.NewMemoryStore()
+	case config.FetchCacheDisk:
+		return httpcache.NewDiskStore(o.Dir)
+	case config.FetchCacheS3:
+		return httpcache.NewS3Store(nil, o.Bucket, o.Prefix)
+	default:
+		return nil
+	}
+}
+
+func buildFallbackStore(o config.FallbackOptions) ports.SnapshotStore {
+	switch o.Backend {
+	case config.FallbackMemory:
+		return snapshot.NewMemoryStore()
+	case config.FallbackDisk:
+		return snapshot.NewDiskStore(o.Dir)
+	case config.FallbackS3:
+		return snapshot.NewS3Store(nil, o.Bucket, o.Prefix)
+	default:
+		return nil
+	}
+}
+
+// BuildArchive returns the event archive selected by ARCHIVE_STORE, or nil when archiving
+// is off.
+func BuildArchive() (ports.EventArchive, error) {
+	o, err := config.LoadArchiveOptions()
+	if err != nil {
+		return nil, err
+	}
+	switch o.Backend {
+	case config.ArchiveFile:
+		return archive.NewFileStore(o.File), nil
+	case config.ArchiveDynamoDB:
+		return archive.NewDynamoDBStore(nil, o.Table), nil
+	default:
+		return nil, nil
+	}
+}
+
+// BuildMetrics returns the metrics recorder selected by METRICS, or nil when metrics are off.
+func BuildMetrics() (ports.Metrics, error) {
+	o, err := config.LoadMetricsOptions()
+	if err != nil {
+		return nil, err
+	}
+	if o.Backend != config.MetricsEMF {
+		return nil, nil
+	}
+	return metrics.NewEMF(o.Namespace), nil
+}
+
+// SetupLogging installs the default logger selected by LOG_FORMAT and LOG_LEVEL, which
+// redacts webhook URLs and tokens. defaultLevel applies when LOG_LEVEL is unset.
+func SetupLogging(defaultLevel slog.Level) error {
+	o, err := config.LoadLoggingOptions(defaultLevel)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(logging.NewHandler(os.Stderr, o.Format, o.Level)))
+	return nil
+}
+
+// SetupTracing installs the tracer provider selected by OTEL_TRACES_EXPORTER. The returned
+// provider is nil when tracing is off; its methods are still safe to call.
+func SetupTracing(ctx context.Context) (*tracing.Provider, error) {
+	o, err := config.LoadTracingOptions()
+	if err != nil {
+		return nil, err
+	}
+	return tracing.Setup(ctx, o.Exporter)
+}
+
+// BuildAPIHandler wires the HTTP JSON API. It needs no configuration source, since the
+// API only reads the public venue sites. API_CACHE_TTL overrides the response cache TTL.
+func BuildAPIHandler() (*httpapi.Handler, error) {
+	var opts []httpapi.Option
+	if v := os.Getenv("API_CACHE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			return nil, fmt.Errorf("invalid API_CACHE_TTL value %q: must be a non-negative duration such as 15m", v)
+		}
+		opts = append(opts, httpapi.WithCacheTTL(ttl))
+	}
+
+	fetchers, err := BuildFetchers()
+	if err != nil {
+		return nil, err
+	}
+
+	return httpapi.NewHandler(service.NewEventQueryService(fetchers), opts...), nil
+}
+
+// BuildInteractionHandler wires the Discord interactions endpoint when DISCORD_PUBLIC_KEY
+// is set, and returns nil otherwise. Slash command replies are only rendered, never
+// delivered to the configured destinations, so no configuration source is needed.
+func BuildInteractionHandler() (*discord.InteractionHandler, error) {
+	v := os.Getenv("DISCORD_PUBLIC_KEY")
+	if v == "" {
+		return nil, nil
+	}
+
+	publicKey, err := discord.ParsePublicKey(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DISCORD_PUBLIC_KEY: %w", err)
+	}
+
+	fetchers, err := BuildFetchers()
+	if err != nil {
+		return nil, err
+	}
+
+	eventService := service.NewEventNotificationService(nil, fetchers, service.WithDestinations())
+	return discord.NewInteractionHandler(publicKey, eventService), nil
+}
+
+func buildPolicy(p config.NotificationPolicy) service.Policy {
+	policy := service.Policy{
+		MinCongestion:             p.MinCongestion,
+		SkipEmpty:                 p.SkipEmpty,
+		AlwaysOnWeekends:          p.AlwaysOnWeekends,
+		MentionHereForLargeVenues: p.MentionHereForLargeVenues,
+	}
+	for _, rm := range p.RoleMentions {
+		policy.VenueMentions = append(policy.VenueMentions, service.VenueMention{
+			Venue:        event.VenueID(rm.Venue),
+			Mention:      notification.RoleMention(rm.RoleID),
+			WeekdaysOnly: rm.WeekdaysOnly,
+		})
+	}
+	return policy
+}
+
+func buildAdapterOptions(o config.DiscordOptions) []discord.AdapterOption {
+	opts := []discord.AdapterOption{discord.WithWait()}
+	if o.Username != "" {
+		opts = append(opts, discord.WithUsername(o.Username))
+	}
+	if o.AvatarURL != "" {
+		opts = append(opts, discord.WithAvatarURL(o.AvatarURL))
+	}
+	if o.ThreadID != "" {
+		opts = append(opts, discord.WithThreadID(o.ThreadID))
+	}
+	switch {
+	case o.MessageStoreBucket != "":
+		opts = append(opts, discord.WithMessageStore(discord.NewS3MessageStore(nil, o.MessageStoreBucket, o.MessageStorePrefix)))
+	case o.MessageStorePath != "":
+		opts = append(opts, discord.WithMessageStore(discord.NewFileMessageStore(o.MessageStorePath)))
+	}
+	if o.ForumThreads {
+		opts = append(opts, discord.WithForumThreads())
+	}
+	return opts
+}
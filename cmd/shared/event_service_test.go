@@ -3,12 +3,17 @@ package shared
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/archive"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/config"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/snapshot"
 )
 
 func TestBuildEventService_Success(t *testing.T) {
@@ -17,7 +22,9 @@ func TestBuildEventService_Success(t *testing.T) {
 
 	loadConfig = func(_ context.Context) (*config.Config, error) {
 		return &config.Config{
-			DiscordWebhookURL: "https://discord.com/api/webhooks/123/abc",
+			Destinations: []config.Destination{
+				{Name: "discord", Type: config.DestinationTypeDiscordWebhook, WebhookURL: "https://discord.com/api/webhooks/123/abc"},
+			},
 		}, nil
 	}
 
@@ -41,3 +48,120 @@ func TestBuildEventService_ConfigError(t *testing.T) {
 	assert.Nil(t, svc)
 	assert.Contains(t, err.Error(), "failed to load config")
 }
+
+func TestBuildEventService_NoDestinations(t *testing.T) {
+	original := loadConfig
+	t.Cleanup(func() { loadConfig = original })
+
+	loadConfig = func(_ context.Context) (*config.Config, error) {
+		return &config.Config{}, nil
+	}
+
+	svc, err := BuildEventService(context.Background())
+
+	require.Error(t, err)
+	assert.Nil(t, svc)
+	assert.Contains(t, err.Error(), "no notification destinations configured")
+}
+
+func TestBuildPolicy(t *testing.T) {
+	policy := buildPolicy(config.NotificationPolicy{
+		MinCongestion: 2,
+		SkipEmpty:     true,
+		RoleMentions: []config.RoleMention{
+			{Venue: "nissan_stadium", RoleID: "111", WeekdaysOnly: true},
+		},
+	})
+
+	assert.Equal(t, 2, policy.MinCongestion)
+	assert.True(t, policy.SkipEmpty)
+	require.Len(t, policy.VenueMentions, 1)
+	assert.Equal(t, event.VenueIDNissanStadium, policy.VenueMentions[0].Venue)
+	assert.Equal(t, notification.RoleMention("111"), policy.VenueMentions[0].Mention)
+	assert.True(t, policy.VenueMentions[0].WeekdaysOnly)
+}
+
+func TestBuildAdapterOptions(t *testing.T) {
+	assert.Len(t, buildAdapterOptions(config.DiscordOptions{}), 1)
+	assert.Len(t, buildAdapterOptions(config.DiscordOptions{
+		Username:         "bot",
+		AvatarURL:        "https://example.com/a.png",
+		ThreadID:         "999",
+		MessageStorePath: "/tmp/messages.json",
+		ForumThreads:     true,
+	}), 6)
+	assert.Len(t, buildAdapterOptions(config.DiscordOptions{MessageStoreBucket: "bucket", MessageStorePrefix: "discord-messages/"}), 2)
+}
+
+func TestBuildInteractionHandler(t *testing.T) {
+	t.Setenv("DISCORD_PUBLIC_KEY", "")
+	h, err := BuildInteractionHandler()
+	require.NoError(t, err)
+	assert.Nil(t, h)
+
+	t.Setenv("DISCORD_PUBLIC_KEY", strings.Repeat("ab", 32))
+	h, err = BuildInteractionHandler()
+	require.NoError(t, err)
+	assert.NotNil(t, h)
+
+	t.Setenv("DISCORD_PUBLIC_KEY", "not-a-key")
+	_, err = BuildInteractionHandler()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid DISCORD_PUBLIC_KEY")
+}
+
+func TestBuildMetrics(t *testing.T) {
+	t.Setenv("METRICS", "")
+	m, err := BuildMetrics()
+	require.NoError(t, err)
+	assert.Nil(t, m)
+
+	t.Setenv("METRICS", "emf")
+	m, err = BuildMetrics()
+	require.NoError(t, err)
+	assert.NotNil(t, m)
+
+	t.Setenv("METRICS", "statsd")
+	_, err = BuildMetrics()
+	require.Error(t, err)
+}
+
+func TestBuildArchive(t *testing.T) {
+	t.Setenv("ARCHIVE_STORE", "")
+	a, err := BuildArchive()
+	require.NoError(t, err)
+	assert.Nil(t, a)
+
+	t.Setenv("ARCHIVE_STORE", "file")
+	a, err = BuildArchive()
+	require.NoError(t, err)
+	assert.IsType(t, &archive.FileStore{}, a)
+
+	t.Setenv("ARCHIVE_STORE", "dynamodb")
+	t.Setenv("ARCHIVE_TABLE", "events")
+	a, err = BuildArchive()
+	require.NoError(t, err)
+	assert.IsType(t, &archive.DynamoDBStore{}, a)
+
+	t.Setenv("ARCHIVE_STORE", "sqlite")
+	_, err = BuildArchive()
+	require.Error(t, err)
+}
+
+func TestBuildFallbackStore(t *testing.T) {
+	assert.Nil(t, buildFallbackStore(config.FallbackOptions{}))
+	assert.IsType(t, &snapshot.MemoryStore{}, buildFallbackStore(config.FallbackOptions{Backend: config.FallbackMemory}))
+	assert.IsType(t, &snapshot.DiskStore{}, buildFallbackStore(config.FallbackOptions{Backend: config.FallbackDisk, Dir: t.TempDir()}))
+	assert.IsType(t, &snapshot.S3Store{}, buildFallbackStore(config.FallbackOptions{Backend: config.FallbackS3, Bucket: "bucket"}))
+}
+
+func TestSetupTracing(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "")
+	p, err := SetupTracing(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+	_, err = SetupTracing(context.Background())
+	require.Error(t, err)
+}
@@ -8,132 +8,330 @@ import (
 	"strings"
 	"time"
 
-	"golang.org/x/sync/errgroup"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
 type EventNotificationService struct {
-	notificationSender ports.NotificationSender
-	eventFetchers      []ports.EventFetcher
+	clock                  ports.Clock
+	maintainer             ports.NotificationSender
+	runReports             ports.NotificationSender
+	metrics                ports.Metrics
+	fallback               *fallback
+	archive                ports.EventArchive
+	logLink                LogLinkFunc
+	destinations           []Destination
+	eventFetchers          []ports.EventFetcher
+	skipEmptyTomorrow      bool
+	runReportsProblemsOnly bool
 }
 
-func NewEventNotificationService(sender ports.NotificationSender, fetchers []ports.EventFetcher) *EventNotificationService {
-	return &EventNotificationService{
-		notificationSender: sender,
-		eventFetchers:      fetchers,
+type Option func(*EventNotificationService)
+
+func WithClock(clock ports.Clock) Option {
+	return func(s *EventNotificationService) {
+		s.clock = clock
+	}
+}
+
+// WithSkipEmptyTomorrow suppresses the evening preview entirely when nothing is scheduled tomorrow.
+func WithSkipEmptyTomorrow(skip bool) Option {
+	return func(s *EventNotificationService) {
+		s.skipEmptyTomorrow = skip
+	}
+}
+
+// WithDestinations replaces the default destination built from the constructor's sender,
+// allowing each destination to carry its own notification policy.
+func WithDestinations(destinations ...Destination) Option {
+	return func(s *EventNotificationService) {
+		s.destinations = destinations
+	}
+}
+
+// WithMaintainerAlerts sends a detailed report of every fetch failure to sender, an ops
+// channel, in addition to the notice each destination's FailureNotice asks for.
+func WithMaintainerAlerts(sender ports.NotificationSender) Option {
+	return func(s *EventNotificationService) {
+		s.maintainer = sender
+	}
+}
+
+// WithLogLink adds a link to the run's logs to maintainer reports.
+func WithLogLink(link LogLinkFunc) Option {
+	return func(s *EventNotificationService) {
+		s.logLink = link
+	}
+}
+
+// WithRunReports posts the fetch diagnostics of every scheduled run to sender, such as an
+// ops channel. With problemsOnly, only runs with errors, failed requests or warnings are posted.
+func WithRunReports(sender ports.NotificationSender, problemsOnly bool) Option {
+	return func(s *EventNotificationService) {
+		s.runReports = sender
+		s.runReportsProblemsOnly = problemsOnly
 	}
 }
 
+// WithFallback saves the events of every successful fetch to store. When a venue's site
+// fails, its events from a previous run are shown instead, marked as such, provided they
+// are no older than maxAge. A maxAge of zero accepts snapshots of any age.
+func WithFallback(store ports.SnapshotStore, maxAge time.Duration) Option {
+	return func(s *EventNotificationService) {
+		s.fallback = &fallback{store: store, maxAge: maxAge}
+	}
+}
+
+// WithArchive keeps every freshly fetched event in archive for statistics.
+func WithArchive(archive ports.EventArchive) Option {
+	return func(s *EventNotificationService) {
+		s.archive = archive
+	}
+}
+
+// WithMetrics records fetch and delivery metrics, dimensioned by venue and run mode.
+func WithMetrics(m ports.Metrics) Option {
+	return func(s *EventNotificationService) {
+		s.metrics = m
+	}
+}
+
+func NewEventNotificationService(sender ports.NotificationSender, fetchers []ports.EventFetcher, opts ...Option) *EventNotificationService {
+	s := &EventNotificationService{
+		destinations:  []Destination{{Name: "default", Sender: sender}},
+		eventFetchers: fetchers,
+		clock:         ports.ClockFunc(time.Now),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.fallback != nil {
+		s.fallback.clock = s.clock
+	}
+	return s
+}
+
 func (s *EventNotificationService) NotifyTodayEvents(ctx context.Context) error {
-	jst := time.FixedZone("JST", 9*60*60)
-	today := time.Now().In(jst)
-	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, jst)
+	return s.NotifyEventsForDate(ctx, s.today())
+}
+
+// NotifyEventsForDate sends the daily notification for the calendar day containing date,
+// interpreted in JST. The day is labelled by its date unless it is today.
+func (s *EventNotificationService) NotifyEventsForDate(ctx context.Context, date time.Time) (err error) {
+	day := s.startOfDay(date)
+
+	ctx = withRun(ctx, runKindDaily)
+	ctx, span := startRunSpan(ctx, "EventNotificationService.NotifyEventsForDate", runKindDaily, day, day)
+	defer func() { endSpan(span, err) }()
 
 	venues := event.NewAllVenues()
 
-	if err := s.fetchAllEvents(ctx, venues, today, today); err != nil {
-		failureNotif := notification.NewNotification(
-			"❌ イベント取得エラー",
-			"イベント情報の取得に失敗しました",
-			notification.ColorRed,
-		)
-		if sendErr := s.notificationSender.Send(ctx, failureNotif); sendErr != nil {
-			return errors.Join(
-				fmt.Errorf("failed to fetch events: %w", err),
-				fmt.Errorf("failed to send failure notification: %w", sendErr),
-			)
-		}
-		return fmt.Errorf("failed to fetch events: %w", err)
+	run, err := s.fetchAllEvents(ctx, runKindDaily, venues, day, day)
+	s.postRunReport(ctx, run)
+	if err != nil {
+		return s.notifyFetchFailure(ctx, err)
 	}
 
 	notif := s.buildDailyNotification(venues)
+	if !day.Equal(s.today()) {
+		notif = s.buildDateNotification(venues, day)
+	}
+	notif.SetEditKey(editKey("daily", day))
+
+	return s.deliver(ctx, runKindDaily, notif, newDigest(venues, day, day))
+}
+
+func (s *EventNotificationService) NotifyTomorrowEvents(ctx context.Context) (err error) {
+	tomorrow := s.today().AddDate(0, 0, 1)
+
+	ctx = withRun(ctx, runKindTomorrow)
+	ctx, span := startRunSpan(ctx, "EventNotificationService.NotifyTomorrowEvents", runKindTomorrow, tomorrow, tomorrow)
+	defer func() { endSpan(span, err) }()
+
+	venues := event.NewAllVenues()
 
-	if err := s.notificationSender.Send(ctx, notif); err != nil {
-		return fmt.Errorf("failed to send notification: %w", err)
+	run, err := s.fetchAllEvents(ctx, runKindTomorrow, venues, tomorrow, tomorrow)
+	s.postRunReport(ctx, run)
+	if err != nil {
+		return s.notifyFetchFailure(ctx, err)
 	}
 
-	return nil
+	if s.skipEmptyTomorrow && countEvents(venues) == 0 {
+		logging.FromContext(ctx).Info("no events tomorrow, skipping notification", "date", tomorrow.Format("2006-01-02"))
+		return nil
+	}
+
+	notif := s.buildTomorrowNotification(venues, tomorrow)
+	notif.SetEditKey(editKey("tomorrow", tomorrow))
+
+	return s.deliver(ctx, runKindTomorrow, notif, newDigest(venues, tomorrow, tomorrow))
 }
 
-func (s *EventNotificationService) NotifyWeeklyEvents(ctx context.Context) error {
-	jst := time.FixedZone("JST", 9*60*60)
-	today := time.Now().In(jst)
-	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, jst)
+func (s *EventNotificationService) NotifyWeeklyEvents(ctx context.Context) (err error) {
+	today := s.today()
 
 	venues := event.NewAllVenues()
 	endDate := today.AddDate(0, 0, 6)
 
-	if err := s.fetchAllEvents(ctx, venues, today, endDate); err != nil {
-		failureNotif := notification.NewNotification(
-			"❌ イベント取得エラー",
-			"イベント情報の取得に失敗しました",
-			notification.ColorRed,
-		)
-		if sendErr := s.notificationSender.Send(ctx, failureNotif); sendErr != nil {
-			return errors.Join(
-				fmt.Errorf("failed to fetch events: %w", err),
-				fmt.Errorf("failed to send failure notification: %w", sendErr),
-			)
-		}
-		return fmt.Errorf("failed to fetch events: %w", err)
+	ctx = withRun(ctx, runKindWeekly)
+	ctx, span := startRunSpan(ctx, "EventNotificationService.NotifyWeeklyEvents", runKindWeekly, today, endDate)
+	defer func() { endSpan(span, err) }()
+
+	run, err := s.fetchAllEvents(ctx, runKindWeekly, venues, today, endDate)
+	s.postRunReport(ctx, run)
+	if err != nil {
+		return s.notifyFetchFailure(ctx, err)
 	}
 
 	notif := s.buildWeeklyNotification(venues, today)
+	notif.SetEditKey(editKey("weekly", today))
 
-	if err := s.notificationSender.Send(ctx, notif); err != nil {
-		return fmt.Errorf("failed to send notification: %w", err)
+	return s.deliver(ctx, runKindWeekly, notif, newDigest(venues, today, endDate))
+}
+
+// TodayNotification builds today's daily notification without sending it, for on-demand
+// requests such as slash commands.
+func (s *EventNotificationService) TodayNotification(ctx context.Context) (*notification.Notification, error) {
+	today := s.today()
+
+	venues, err := s.fetchVenues(ctx, today, today)
+	if err != nil {
+		return nil, err
 	}
+	return s.buildDailyNotification(venues), nil
+}
 
-	return nil
+// DateNotification builds the notification for the calendar day containing date without sending it.
+func (s *EventNotificationService) DateNotification(ctx context.Context, date time.Time) (*notification.Notification, error) {
+	day := s.startOfDay(date)
+
+	venues, err := s.fetchVenues(ctx, day, day)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildDateNotification(venues, day), nil
 }
 
-func (s *EventNotificationService) fetchAllEvents(ctx context.Context, venues []*event.Venue, from, to time.Time) error {
-	venueMap := make(map[event.VenueID]*event.Venue)
-	for _, v := range venues {
-		venueMap[v.ID] = v
+// WeeklyNotification builds the weekly digest starting today without sending it.
+func (s *EventNotificationService) WeeklyNotification(ctx context.Context) (*notification.Notification, error) {
+	today := s.today()
+
+	venues, err := s.fetchVenues(ctx, today, today.AddDate(0, 0, 6))
+	if err != nil {
+		return nil, err
 	}
+	return s.buildWeeklyNotification(venues, today), nil
+}
 
-	type fetchResult struct {
-		venueID event.VenueID
-		events  []event.Event
+func (s *EventNotificationService) fetchVenues(ctx context.Context, from, to time.Time) ([]*event.Venue, error) {
+	ctx = withRun(ctx, runKindOnDemand)
+	venues := event.NewAllVenues()
+	if _, err := s.fetchAllEvents(ctx, runKindOnDemand, venues, from, to); err != nil {
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
 	}
-	results := make([]fetchResult, len(s.eventFetchers))
+	return venues, nil
+}
+
+// Today returns the start of the current day by the service's clock, in JST: the
+// day the daily notification is rendered for.
+func (s *EventNotificationService) Today() time.Time {
+	return s.today()
+}
 
-	eg, ctx := errgroup.WithContext(ctx)
-	for i, fetcher := range s.eventFetchers {
-		eg.Go(func() error {
-			events, err := fetcher.FetchEvents(ctx, from, to)
-			if err != nil {
-				return fmt.Errorf("fetch events for venue %s: %w", fetcher.VenueID(), err)
-			}
-			results[i] = fetchResult{venueID: fetcher.VenueID(), events: events}
-			return nil
-		})
+func (s *EventNotificationService) today() time.Time {
+	return s.startOfDay(s.clock.Now())
+}
+
+func (s *EventNotificationService) startOfDay(t time.Time) time.Time {
+	t = t.In(event.JST)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, event.JST)
+}
+
+// deliver applies each destination's policy to the digest and sends the notification where it passes.
+func (s *EventNotificationService) deliver(ctx context.Context, kind string, notif *notification.Notification, d digest) error {
+	var errs []error
+	for _, dest := range s.destinations {
+		decision := dest.Policy.decide(d)
+		if !decision.send {
+			logging.FromContext(ctx).Info("notification suppressed by policy", "destination", dest.Name)
+			continue
+		}
+
+		n := notif.Clone()
+		for _, m := range decision.mentions {
+			n.AddMention(m)
+		}
+		if key := n.EditKey(); key != "" {
+			n.SetEditKey(dest.Name + "/" + key)
+		}
+
+		start := time.Now()
+		sendCtx, span := tracer().Start(ctx, "NotificationSender.Send", trace.WithAttributes(attribute.String("destination", dest.Name)))
+		err := dest.Sender.Send(sendCtx, n)
+		endSpan(span, err)
+		s.recordSendMetrics(ctx, kind, time.Since(start), err)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to send notification to %s: %w", dest.Name, err))
+		}
 	}
+	return errors.Join(errs...)
+}
 
-	if err := eg.Wait(); err != nil {
-		return fmt.Errorf("fetch all events: %w", err)
+// editKey identifies the notification of the given kind for a date, so reruns replace it.
+func editKey(kind string, date time.Time) string {
+	return kind + "/" + date.Format("2006-01-02")
+}
+
+func (s *EventNotificationService) notifyFetchFailure(ctx context.Context, err error) error {
+	var sendErrs []error
+	for _, dest := range s.destinations {
+		notice := buildFailureNotice(dest.FailureNotice, err)
+		if notice == nil {
+			continue
+		}
+		if sendErr := dest.Sender.Send(ctx, notice); sendErr != nil {
+			sendErrs = append(sendErrs, fmt.Errorf("failed to send failure notification to %s: %w", dest.Name, sendErr))
+		}
 	}
 
-	for _, r := range results {
-		if venue, ok := venueMap[r.venueID]; ok {
-			venue.Events = append(venue.Events, r.events...)
+	if s.maintainer != nil {
+		if sendErr := s.maintainer.Send(ctx, buildMaintainerFailureReport(ctx, err, s.logLink)); sendErr != nil {
+			sendErrs = append(sendErrs, fmt.Errorf("failed to send maintainer alert: %w", sendErr))
 		}
 	}
+	if len(sendErrs) > 0 {
+		return errors.Join(append([]error{fmt.Errorf("failed to fetch events: %w", err)}, sendErrs...)...)
+	}
+	return fmt.Errorf("failed to fetch events: %w", err)
+}
 
-	return nil
+// alertLayoutChanges sends the maintainer a report for each layout change among errs, the
+// fetch errors the fallback recovered from. Subscribers see old events in that case, so
+// without the report a broken fetcher would go unnoticed. A failure to send is only logged.
+func (s *EventNotificationService) alertLayoutChanges(ctx context.Context, errs []error) {
+	if s.maintainer == nil {
+		return
+	}
+	for _, err := range errs {
+		if !apperrors.IsLayoutChanged(err) {
+			continue
+		}
+		report := buildMaintainerFailureReport(ctx, err, s.logLink)
+		report.AddField("通知", "前回取得データで代替しました", false)
+		if sendErr := s.maintainer.Send(ctx, report); sendErr != nil {
+			logging.FromContext(ctx).Warn("failed to send maintainer alert", logging.KeyError, sendErr)
+		}
+	}
 }
 
 func (s *EventNotificationService) buildDailyNotification(venues []*event.Venue) *notification.Notification {
-	color := s.determineColor(venues)
-
-	totalEvents := 0
-	for _, venue := range venues {
-		totalEvents += len(venue.Events)
-	}
+	totalEvents := countEvents(venues)
 
 	var description string
 	if totalEvents == 0 {
@@ -142,21 +340,59 @@ func (s *EventNotificationService) buildDailyNotification(venues []*event.Venue)
 		description = fmt.Sprintf("本日のイベント数: %d件", totalEvents)
 	}
 
-	notif := notification.NewNotification(
-		"📅 新横浜 イベント情報",
-		description,
-		color,
-	)
+	return s.buildDayNotification(venues, "📅 新横浜 イベント情報", description, "本日の予定はありません")
+}
+
+func (s *EventNotificationService) buildTomorrowNotification(venues []*event.Venue, date time.Time) *notification.Notification {
+	totalEvents := countEvents(venues)
+
+	var description string
+	if totalEvents == 0 {
+		description = fmt.Sprintf("%s の開催イベントはありません", formatDateLabel(date))
+	} else {
+		description = fmt.Sprintf("%s のイベント数: %d件", formatDateLabel(date), totalEvents)
+	}
+
+	return s.buildDayNotification(venues, "📅 明日の新横浜 イベント情報", description, "明日の予定はありません")
+}
+
+func (s *EventNotificationService) buildDateNotification(venues []*event.Venue, date time.Time) *notification.Notification {
+	totalEvents := countEvents(venues)
+
+	var description string
+	if totalEvents == 0 {
+		description = fmt.Sprintf("%s の開催イベントはありません", formatDateLabel(date))
+	} else {
+		description = fmt.Sprintf("%s のイベント数: %d件", formatDateLabel(date), totalEvents)
+	}
+
+	return s.buildDayNotification(venues, "📅 新横浜 イベント情報", description, "予定はありません")
+}
+
+func (s *EventNotificationService) buildDayNotification(venues []*event.Venue, title, description, emptyText string) *notification.Notification {
+	notif := notification.NewNotification(title, description, s.determineColor(venues))
 
 	for _, venue := range venues {
 		fieldName := fmt.Sprintf("%s %s", venue.Emoji, venue.DisplayName)
-		fieldValue := s.formatVenueEvents(venue.Events)
-		notif.AddField(fieldName, fieldValue, false)
+		fieldValue := s.formatVenueEvents(venue.Events, emptyText)
+		if !venue.UnlistedBefore.IsZero() || !venue.UnpublishedFrom.IsZero() {
+			// The venue does not list the day, so "no events" would be misleading.
+			fieldValue = ""
+		}
+		notif.AddField(fieldName, s.annotateVenue(venue, fieldValue), false)
 	}
 
 	return notif
 }
 
+func countEvents(venues []*event.Venue) int {
+	total := 0
+	for _, venue := range venues {
+		total += len(venue.Events)
+	}
+	return total
+}
+
 func (s *EventNotificationService) buildWeeklyNotification(venues []*event.Venue, startDate time.Time) *notification.Notification {
 	color := s.determineColor(venues)
 	notif := notification.NewNotification(
@@ -164,37 +400,62 @@ func (s *EventNotificationService) buildWeeklyNotification(venues []*event.Venue
 		"",
 		color,
 	)
+	notif.SetTopic(fmt.Sprintf("%d/%d週", startDate.Month(), startDate.Day()))
 
 	for _, venue := range venues {
 		fieldName := fmt.Sprintf("%s %s", venue.Emoji, venue.DisplayName)
-		fieldValue := s.formatVenueWeeklyEvents(venue.Events)
+		fieldValue := s.annotateVenue(venue, s.formatVenueWeeklyEvents(venue.Events))
 		notif.AddField(fieldName, fieldValue, false)
 	}
 
 	return notif
 }
 
-func (s *EventNotificationService) determineColor(venues []*event.Venue) notification.Color {
-	venuesWithEvents := 0
-	for _, venue := range venues {
-		if len(venue.Events) > 0 {
-			venuesWithEvents++
+// annotateVenue explains the gaps in a venue's events: days the venue no longer lists or
+// has not published yet, and events that come from an earlier run, since they may be out
+// of date.
+func (s *EventNotificationService) annotateVenue(venue *event.Venue, fieldValue string) string {
+	var lines []string
+	if fieldValue != "" {
+		lines = append(lines, fieldValue)
+	}
+	if !venue.UnlistedBefore.IsZero() {
+		lines = append(lines, fmt.Sprintf("※%sより前は未掲載", formatDateLabel(venue.UnlistedBefore.In(event.JST))))
+	}
+	if !venue.UnpublishedFrom.IsZero() {
+		lines = append(lines, fmt.Sprintf("※%s以降は未公開", formatDateLabel(venue.UnpublishedFrom.In(event.JST))))
+	}
+	if !venue.StaleAsOf.IsZero() {
+		lines = append(lines, fmt.Sprintf("※前回取得データ（%s時点）", venue.StaleAsOf.In(event.JST).Format("1/2 15:04")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// capabilitiesOf returns the capabilities of the fetcher for venueID, or none when the
+// venue has no fetcher.
+func (s *EventNotificationService) capabilitiesOf(venueID event.VenueID) ports.Capabilities {
+	for _, f := range s.eventFetchers {
+		if f.VenueID() == venueID {
+			return ports.CapabilitiesOf(f)
 		}
 	}
+	return ports.Capabilities{}
+}
 
-	switch venuesWithEvents {
-	case 0:
+func (s *EventNotificationService) determineColor(venues []*event.Venue) notification.Color {
+	switch event.CongestionLevelFor(venues) {
+	case event.CongestionLow:
 		return notification.ColorGreen
-	case 1:
+	case event.CongestionModerate:
 		return notification.ColorYellow
 	default:
 		return notification.ColorRed
 	}
 }
 
-func (s *EventNotificationService) formatVenueEvents(events []event.Event) string {
+func (s *EventNotificationService) formatVenueEvents(events []event.Event, emptyText string) string {
 	if len(events) == 0 {
-		return "本日の予定はありません"
+		return emptyText
 	}
 
 	sort.Slice(events, func(i, j int) bool {
@@ -3,9 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +17,7 @@ import (
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
 )
 
 func timePtr(t time.Time) *time.Time {
@@ -49,7 +52,8 @@ func TestNewEventNotificationService(t *testing.T) {
 	_, _, service, _ := setupSingleFetcherService(t)
 
 	require.NotNil(t, service)
-	assert.NotNil(t, service.notificationSender)
+	require.Len(t, service.destinations, 1)
+	assert.NotNil(t, service.destinations[0].Sender)
 	assert.Len(t, service.eventFetchers, 1)
 }
 
@@ -455,6 +459,69 @@ func TestNotifyTodayEvents_FetchError_SendFailureNotificationFails(t *testing.T)
 	assert.ErrorIs(t, err, sendErr)
 }
 
+func TestNotifyTodayEvents_LayoutChanged_AlertsMaintainer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockMaintainer := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher}, WithMaintainerAlerts(mockMaintainer))
+
+	layoutErr := apperrors.NewLayoutChangedError("nissan_stadium", "calendar page has no dated rows")
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("failed to fetch event candidates: %w", layoutErr))
+
+	var subscriberNotif, alert *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		subscriberNotif = notif
+		return nil
+	})
+	mockMaintainer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		alert = notif
+		return nil
+	})
+
+	err := service.NotifyTodayEvents(context.Background())
+
+	require.Error(t, err)
+	assert.True(t, apperrors.IsLayoutChanged(err))
+	require.NotNil(t, subscriberNotif)
+	assert.Equal(t, "❌ イベント取得エラー", subscriberNotif.Title())
+	require.NotNil(t, alert)
+	assert.Equal(t, "⚠️ 会場サイトの構造変更を検知", alert.Title())
+	fields := fieldsByName(alert)
+	assert.Equal(t, "日産スタジアム", fields["会場"])
+	assert.Equal(t, string(apperrors.CodeLayoutChanged), fields["種別"])
+	assert.Contains(t, fields["詳細"], "nissan_stadium: calendar page has no dated rows")
+}
+
+func TestNotifyTodayEvents_FetchError_ReportsTransientErrorsToMaintainer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockMaintainer := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher}, WithMaintainerAlerts(mockMaintainer))
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+	mockMaintainer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		assert.Equal(t, "❌ イベント取得エラー", notif.Title())
+		assert.Contains(t, fieldsByName(notif)["詳細"], "fetch events for venue nissan_stadium\ntimeout")
+		return nil
+	})
+
+	err := service.NotifyTodayEvents(context.Background())
+
+	require.Error(t, err)
+}
+
+func TestBuildMaintainerFailureReport_TruncatesDetail(t *testing.T) {
+	alert := buildMaintainerFailureReport(context.Background(), errors.New(strings.Repeat("あ", 2000)), nil)
+
+	require.Len(t, alert.Fields(), 1)
+	assert.Equal(t, maxFieldValueLength, utf8.RuneCountInString(alert.Fields()[0].Value))
+}
+
 func TestNotifyTodayEvents_SendError(t *testing.T) {
 	mockSender, mockFetcher, service, ctx := setupSingleFetcherService(t)
 	expectedErr := errors.New("send error")
@@ -505,6 +572,280 @@ func TestNotifyTodayEvents_BothNilStartTime_SortsByTitle(t *testing.T) {
 	assert.Contains(t, arenaField.Value, "・イベントA\n・イベントB\n・イベントC")
 }
 
+func TestNotifyTodayEvents_UsesInjectedClock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+
+	jst := time.FixedZone("JST", 9*60*60)
+	// 2026-01-27 23:30 UTC is already 2026-01-28 in JST.
+	now := time.Date(2026, 1, 27, 23, 30, 0, 0, time.UTC)
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher},
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+	)
+
+	expected := time.Date(2026, 1, 28, 0, 0, 0, 0, jst)
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, from, to time.Time) ([]event.Event, error) {
+		assert.True(t, expected.Equal(from))
+		assert.True(t, expected.Equal(to))
+		return []event.Event{}, nil
+	})
+	var sentNotification *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		sentNotification = notif
+		return nil
+	})
+
+	err := service.NotifyTodayEvents(context.Background())
+
+	require.NoError(t, err)
+	require.NotNil(t, sentNotification)
+	assert.Equal(t, "default/daily/2026-01-28", sentNotification.EditKey())
+}
+
+func TestToday_UsesClockInJST(t *testing.T) {
+	// 2026-01-31 23:30 UTC is already Sunday, February 1 in JST.
+	now := time.Date(2026, 1, 31, 23, 30, 0, 0, time.UTC)
+	service := NewEventNotificationService(nil, nil,
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+	)
+
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, event.JST), service.Today())
+}
+
+func TestNotifyEventsForDate_NormalizesToStartOfDay(t *testing.T) {
+	mockSender, mockFetcher, service, ctx := setupSingleFetcherService(t)
+
+	jst := time.FixedZone("JST", 9*60*60)
+	date := time.Date(2026, 11, 3, 15, 45, 0, 0, jst)
+	expected := time.Date(2026, 11, 3, 0, 0, 0, 0, jst)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, from, to time.Time) ([]event.Event, error) {
+		assert.True(t, expected.Equal(from))
+		assert.True(t, expected.Equal(to))
+		return []event.Event{}, nil
+	})
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := service.NotifyEventsForDate(ctx, date)
+
+	require.NoError(t, err)
+}
+
+func TestNotifyEventsForDate_LabelsOtherDays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sender := mock_ports.NewMockNotificationSender(ctrl)
+	fetcher := mock_ports.NewMockEventFetcher(ctrl)
+	fetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, event.JST)
+	service := NewEventNotificationService(sender, []ports.EventFetcher{fetcher},
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+	)
+
+	tests := []struct {
+		date time.Time
+		name string
+		want string
+	}{
+		{name: "today", date: now, want: "本日のイベント数: 1件"},
+		{name: "other day", date: time.Date(2026, 11, 3, 0, 0, 0, 0, event.JST), want: "11/3(火) のイベント数: 1件"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{{Date: tt.date, Title: "Live"}}, nil)
+			sender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+				assert.Equal(t, tt.want, notif.Description())
+				return nil
+			})
+
+			require.NoError(t, service.NotifyEventsForDate(context.Background(), tt.date))
+		})
+	}
+}
+
+// Policy and destination tests
+
+func TestNotifyEventsForDate_PerDestinationPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	everything := mock_ports.NewMockNotificationSender(ctrl)
+	quietDays := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+
+	service := NewEventNotificationService(nil, []ports.EventFetcher{mockFetcher},
+		WithDestinations(
+			Destination{Name: "everything", Sender: everything},
+			Destination{Name: "quiet", Sender: quietDays, Policy: Policy{SkipEmpty: true}},
+		),
+	)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{}, nil)
+	everything.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	jst := time.FixedZone("JST", 9*60*60)
+	err := service.NotifyEventsForDate(context.Background(), time.Date(2026, 4, 7, 0, 0, 0, 0, jst))
+
+	require.NoError(t, err)
+}
+
+func TestNotifyEventsForDate_MentionHereForLargeVenue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	loud := mock_ports.NewMockNotificationSender(ctrl)
+	plain := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+
+	service := NewEventNotificationService(nil, []ports.EventFetcher{mockFetcher},
+		WithDestinations(
+			Destination{Name: "loud", Sender: loud, Policy: Policy{MentionHereForLargeVenues: true}},
+			Destination{Name: "plain", Sender: plain},
+		),
+	)
+
+	jst := time.FixedZone("JST", 9*60*60)
+	day := time.Date(2026, 4, 7, 0, 0, 0, 0, jst)
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{{Title: "試合", Date: day}}, nil)
+
+	var loudNotif, plainNotif *notification.Notification
+	loud.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		loudNotif = notif
+		return nil
+	})
+	plain.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		plainNotif = notif
+		return nil
+	})
+
+	err := service.NotifyEventsForDate(context.Background(), day)
+
+	require.NoError(t, err)
+	require.NotNil(t, loudNotif)
+	require.NotNil(t, plainNotif)
+	assert.Equal(t, []notification.Mention{notification.HereMention()}, loudNotif.Mentions())
+	assert.Empty(t, plainNotif.Mentions())
+}
+
+func TestNotifyEventsForDate_OneDestinationFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ok := mock_ports.NewMockNotificationSender(ctrl)
+	broken := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+
+	service := NewEventNotificationService(nil, []ports.EventFetcher{mockFetcher},
+		WithDestinations(
+			Destination{Name: "broken", Sender: broken},
+			Destination{Name: "ok", Sender: ok},
+		),
+	)
+	sendErr := errors.New("send error")
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{}, nil)
+	broken.EXPECT().Send(gomock.Any(), gomock.Any()).Return(sendErr)
+	ok.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := service.NotifyEventsForDate(context.Background(), time.Now())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, sendErr)
+	assert.Contains(t, err.Error(), "broken")
+}
+
+// Tomorrow preview tests
+
+func setupTomorrowService(t *testing.T, opts ...Option) (*mock_ports.MockNotificationSender, *mock_ports.MockEventFetcher, *EventNotificationService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 10, 18, 21, 0, 0, 0, jst)
+	opts = append([]Option{WithClock(ports.ClockFunc(func() time.Time { return now }))}, opts...)
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher}, opts...)
+	return mockSender, mockFetcher, service
+}
+
+func TestNotifyTomorrowEvents_WithEvents(t *testing.T) {
+	mockSender, mockFetcher, service := setupTomorrowService(t)
+
+	jst := time.FixedZone("JST", 9*60*60)
+	tomorrow := time.Date(2026, 10, 19, 0, 0, 0, 0, jst)
+	events := []event.Event{
+		{
+			Title: "サッカー公式戦",
+			Date:  tomorrow,
+			Schedules: []event.Schedule{
+				{StartTime: timePtr(time.Date(2026, 10, 19, 19, 0, 0, 0, jst))},
+			},
+		},
+	}
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, from, to time.Time) ([]event.Event, error) {
+		assert.True(t, tomorrow.Equal(from))
+		assert.True(t, tomorrow.Equal(to))
+		return events, nil
+	})
+
+	var sentNotification *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		sentNotification = notif
+		return nil
+	})
+
+	err := service.NotifyTomorrowEvents(context.Background())
+
+	require.NoError(t, err)
+	require.NotNil(t, sentNotification)
+	assert.Equal(t, "📅 明日の新横浜 イベント情報", sentNotification.Title())
+	assert.Equal(t, "10/19(月) のイベント数: 1件", sentNotification.Description())
+	assert.Contains(t, sentNotification.Fields()[1].Value, "・**19:00開始** サッカー公式戦")
+	assert.Equal(t, "明日の予定はありません", sentNotification.Fields()[0].Value)
+}
+
+func TestNotifyTomorrowEvents_NoEvents_SendsByDefault(t *testing.T) {
+	mockSender, mockFetcher, service := setupTomorrowService(t)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{}, nil)
+
+	var sentNotification *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		sentNotification = notif
+		return nil
+	})
+
+	err := service.NotifyTomorrowEvents(context.Background())
+
+	require.NoError(t, err)
+	require.NotNil(t, sentNotification)
+	assert.Equal(t, "10/19(月) の開催イベントはありません", sentNotification.Description())
+}
+
+func TestNotifyTomorrowEvents_NoEvents_Suppressed(t *testing.T) {
+	_, mockFetcher, service := setupTomorrowService(t, WithSkipEmptyTomorrow(true))
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{}, nil)
+
+	err := service.NotifyTomorrowEvents(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestNotifyTomorrowEvents_FetchError(t *testing.T) {
+	mockSender, mockFetcher, service := setupTomorrowService(t, WithSkipEmptyTomorrow(true))
+	expectedErr := errors.New("fetch error")
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := service.NotifyTomorrowEvents(context.Background())
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, expectedErr)
+}
+
 // Weekly notification tests
 
 func TestNotifyWeeklyEvents_NoEvents(t *testing.T) {
@@ -554,6 +895,37 @@ func TestNotifyWeeklyEvents_FetchError(t *testing.T) {
 	assert.Equal(t, "❌ イベント取得エラー", sentNotification.Title())
 }
 
+func TestNotifyWeeklyEvents_FetchesSevenDaysFromTodayWithWeekTopic(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+
+	jst := time.FixedZone("JST", 9*60*60)
+	now := time.Date(2026, 4, 6, 6, 0, 0, 0, jst)
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher},
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+	)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, from, to time.Time) ([]event.Event, error) {
+		assert.True(t, time.Date(2026, 4, 6, 0, 0, 0, 0, jst).Equal(from))
+		assert.True(t, time.Date(2026, 4, 12, 0, 0, 0, 0, jst).Equal(to))
+		return []event.Event{}, nil
+	})
+
+	var sentNotification *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		sentNotification = notif
+		return nil
+	})
+
+	err := service.NotifyWeeklyEvents(context.Background())
+
+	require.NoError(t, err)
+	require.NotNil(t, sentNotification)
+	assert.Equal(t, "4/6週", sentNotification.Topic())
+}
+
 // Tests for weekly formatting logic (direct unit tests of formatVenueWeeklyEvents)
 
 func TestFormatVenueWeeklyEvents_NoEvents(t *testing.T) {
@@ -661,3 +1033,102 @@ func TestFormatVenueWeeklyEvents_WeekdayLabels(t *testing.T) {
 	assert.Contains(t, result, "4/11(土)")
 	assert.Contains(t, result, "4/12(日)")
 }
+
+// On-demand notification tests
+
+func TestTodayNotification_DoesNotSend(t *testing.T) {
+	// The sender mock has no expectations, so any Send call fails the test.
+	_, mockFetcher, service := setupTomorrowService(t)
+
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), today, today).Return([]event.Event{
+		{Date: today, Title: "Match"},
+	}, nil)
+
+	notif, err := service.TodayNotification(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "本日のイベント数: 1件", notif.Description())
+	assert.Empty(t, notif.EditKey())
+}
+
+func TestDateNotification_UsesDateLabel(t *testing.T) {
+	_, mockFetcher, service := setupTomorrowService(t)
+
+	date := time.Date(2026, 11, 3, 0, 0, 0, 0, event.JST)
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{}, nil)
+
+	notif, err := service.DateNotification(context.Background(), date.Add(15*time.Hour))
+
+	require.NoError(t, err)
+	assert.Equal(t, "📅 新横浜 イベント情報", notif.Title())
+	assert.Equal(t, "11/3(火) の開催イベントはありません", notif.Description())
+	assert.Equal(t, "予定はありません", notif.Fields()[1].Value)
+}
+
+func TestDateNotification_PastHorizon(t *testing.T) {
+	date := time.Date(2027, 1, 10, 0, 0, 0, 0, event.JST)
+	stadium := newHorizonFetcher(t, time.Date(2026, 11, 30, 0, 0, 0, 0, event.JST))
+	service := NewEventNotificationService(nil, []ports.EventFetcher{stadium})
+
+	notif, err := service.DateNotification(context.Background(), date)
+
+	require.NoError(t, err)
+	assert.Equal(t, "⚽ 日産スタジアム", notif.Fields()[1].Name)
+	assert.Equal(t, "※12/1(火)以降は未公開", notif.Fields()[1].Value)
+	assert.Equal(t, "予定はありません", notif.Fields()[0].Value)
+}
+
+func TestDateNotification_BeforeEarliest(t *testing.T) {
+	date := time.Date(2026, 9, 20, 0, 0, 0, 0, event.JST)
+	stadium := newCapableFetcher(t, event.VenueIDNissanStadium, ports.Capabilities{Earliest: time.Date(2026, 10, 1, 0, 0, 0, 0, event.JST)})
+	service := NewEventNotificationService(nil, []ports.EventFetcher{stadium})
+
+	notif, err := service.DateNotification(context.Background(), date)
+
+	require.NoError(t, err)
+	assert.Equal(t, "⚽ 日産スタジアム", notif.Fields()[1].Name)
+	assert.Equal(t, "※10/1(木)より前は未掲載", notif.Fields()[1].Value)
+}
+
+func TestNotifyEventsForDate_BeforeEarliest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	sender := mock_ports.NewMockNotificationSender(ctrl)
+	arena := mock_ports.NewMockEventFetcher(ctrl)
+	arena.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	stadium := newCapableFetcher(t, event.VenueIDNissanStadium, ports.Capabilities{Earliest: time.Date(2026, 10, 1, 0, 0, 0, 0, event.JST)})
+	service := NewEventNotificationService(sender, []ports.EventFetcher{arena, stadium})
+	date := time.Date(2026, 9, 20, 0, 0, 0, 0, event.JST)
+
+	arena.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{}, nil)
+	sender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, notif *notification.Notification) error {
+		assert.Equal(t, "※10/1(木)より前は未掲載", notif.Fields()[1].Value)
+		return nil
+	})
+
+	err := service.NotifyEventsForDate(context.Background(), date)
+
+	require.NoError(t, err)
+}
+
+func TestBuildWeeklyNotification_NotesUnpublishedDays(t *testing.T) {
+	service := NewEventNotificationService(nil, nil)
+	venues := event.NewAllVenues()
+	venues[1].UnpublishedFrom = time.Date(2026, 12, 1, 0, 0, 0, 0, event.JST)
+
+	notif := service.buildWeeklyNotification(venues, time.Date(2026, 11, 28, 0, 0, 0, 0, event.JST))
+
+	assert.Equal(t, "今週の予定はありません\n※12/1(火)以降は未公開", notif.Fields()[1].Value)
+}
+
+func TestWeeklyNotification_FetchError(t *testing.T) {
+	_, mockFetcher, service := setupTomorrowService(t)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+
+	notif, err := service.WeeklyNotification(context.Background())
+
+	require.Error(t, err)
+	assert.Nil(t, notif)
+	assert.Contains(t, err.Error(), "failed to fetch events")
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// EventQueryService answers ad-hoc questions about events for API clients, using the
+// same fetchers as the notifications.
+type EventQueryService struct {
+	eventFetchers []ports.EventFetcher
+}
+
+// Congestion is the expected busyness around the station on one day.
+type Congestion struct {
+	Date             time.Time
+	Level            event.CongestionLevel
+	Venues           []*event.Venue
+	TotalEvents      int
+	VenuesWithEvents int
+	LargeVenueActive bool
+}
+
+func NewEventQueryService(fetchers []ports.EventFetcher) *EventQueryService {
+	return &EventQueryService{eventFetchers: fetchers}
+}
+
+// Venues lists every supported venue, without events.
+func (s *EventQueryService) Venues() []*event.Venue {
+	return event.NewAllVenues()
+}
+
+// Capabilities returns the capabilities of each venue's fetcher, by venue.
+func (s *EventQueryService) Capabilities() map[event.VenueID]ports.Capabilities {
+	caps := make(map[event.VenueID]ports.Capabilities, len(s.eventFetchers))
+	for _, f := range s.eventFetchers {
+		caps[f.VenueID()] = ports.CapabilitiesOf(f)
+	}
+	return caps
+}
+
+// Events returns the venues with their events between from and to (inclusive).
+// When venueIDs is empty, every venue is included.
+func (s *EventQueryService) Events(ctx context.Context, from, to time.Time, venueIDs ...event.VenueID) ([]*event.Venue, error) {
+	var venues []*event.Venue
+	for _, v := range event.NewAllVenues() {
+		if len(venueIDs) == 0 || slices.Contains(venueIDs, v.ID) {
+			venues = append(venues, v)
+		}
+	}
+
+	if _, _, err := fetchVenueEvents(ctx, s.fetchersFor(venueIDs), venues, from, to, nil); err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+func (s *EventQueryService) fetchersFor(venueIDs []event.VenueID) []ports.EventFetcher {
+	var fetchers []ports.EventFetcher
+	for _, f := range s.eventFetchers {
+		if len(venueIDs) == 0 || slices.Contains(venueIDs, f.VenueID()) {
+			fetchers = append(fetchers, f)
+		}
+	}
+	return fetchers
+}
+
+func (s *EventQueryService) Congestion(ctx context.Context, date time.Time) (*Congestion, error) {
+	venues, err := s.Events(ctx, date, date)
+	if err != nil {
+		return nil, err
+	}
+
+	d := newDigest(venues, date, date)
+	return &Congestion{
+		Date:             date,
+		Level:            event.CongestionLevelFor(venues),
+		Venues:           venues,
+		TotalEvents:      d.totalEvents,
+		VenuesWithEvents: d.venuesWithEvents,
+		LargeVenueActive: d.largeVenueActive,
+	}, nil
+}
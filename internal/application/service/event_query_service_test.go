@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+)
+
+func setupQueryService(t *testing.T) (*mock_ports.MockEventFetcher, *mock_ports.MockEventFetcher, *EventQueryService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	arena := mock_ports.NewMockEventFetcher(ctrl)
+	stadium := mock_ports.NewMockEventFetcher(ctrl)
+	arena.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	stadium.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+	return arena, stadium, NewEventQueryService([]ports.EventFetcher{arena, stadium})
+}
+
+func TestEventQueryService_Venues(t *testing.T) {
+	_, _, svc := setupQueryService(t)
+
+	venues := svc.Venues()
+
+	require.Len(t, venues, 3)
+	assert.Equal(t, event.VenueIDYokohamaArena, venues[0].ID)
+}
+
+func TestEventQueryService_Events_FiltersVenues(t *testing.T) {
+	_, stadium, svc := setupQueryService(t)
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+
+	stadium.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{{Date: date, Title: "Match"}}, nil)
+
+	venues, err := svc.Events(context.Background(), date, date, event.VenueIDNissanStadium)
+
+	require.NoError(t, err)
+	require.Len(t, venues, 1)
+	assert.Equal(t, event.VenueIDNissanStadium, venues[0].ID)
+	assert.Len(t, venues[0].Events, 1)
+}
+
+func TestEventQueryService_Events_FetchError(t *testing.T) {
+	arena, stadium, svc := setupQueryService(t)
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+
+	arena.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+	stadium.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	venues, err := svc.Events(context.Background(), date, date)
+
+	require.Error(t, err)
+	assert.Nil(t, venues)
+}
+
+func TestEventQueryService_Congestion(t *testing.T) {
+	arena, stadium, svc := setupQueryService(t)
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+
+	arena.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{{Date: date, Title: "Live"}, {Date: date, Title: "Live 2"}}, nil)
+	stadium.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{{Date: date, Title: "Match"}}, nil)
+
+	c, err := svc.Congestion(context.Background(), date)
+
+	require.NoError(t, err)
+	assert.Equal(t, event.CongestionHigh, c.Level)
+	assert.Equal(t, 3, c.TotalEvents)
+	assert.Equal(t, 2, c.VenuesWithEvents)
+	assert.True(t, c.LargeVenueActive)
+	assert.Len(t, c.Venues, 3)
+}
+
+func newCapableFetcher(t *testing.T, venueID event.VenueID, caps ports.Capabilities) *mock_ports.MockCapableEventFetcher {
+	t.Helper()
+	f := mock_ports.NewMockCapableEventFetcher(gomock.NewController(t))
+	f.EXPECT().VenueID().Return(venueID).AnyTimes()
+	f.EXPECT().Capabilities().Return(caps).AnyTimes()
+	return f
+}
+
+func newHorizonFetcher(t *testing.T, horizon time.Time) *mock_ports.MockCapableEventFetcher {
+	t.Helper()
+	return newCapableFetcher(t, event.VenueIDNissanStadium, ports.Capabilities{Horizon: horizon})
+}
+
+func TestEventQueryService_Events_ClampsToHorizon(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, event.JST) }
+	stadium := newHorizonFetcher(t, day(11, 30))
+	svc := NewEventQueryService([]ports.EventFetcher{stadium})
+
+	stadium.EXPECT().FetchEvents(gomock.Any(), day(11, 25), day(11, 30)).Return([]event.Event{{Date: day(11, 28), Title: "Match"}}, nil)
+
+	venues, err := svc.Events(context.Background(), day(11, 25), day(12, 5), event.VenueIDNissanStadium)
+
+	require.NoError(t, err)
+	require.Len(t, venues, 1)
+	assert.Len(t, venues[0].Events, 1)
+	assert.True(t, day(12, 1).Equal(venues[0].UnpublishedFrom))
+}
+
+func TestEventQueryService_Events_WithinHorizon(t *testing.T) {
+	date := time.Date(2026, 11, 30, 0, 0, 0, 0, event.JST)
+	stadium := newHorizonFetcher(t, date)
+	svc := NewEventQueryService([]ports.EventFetcher{stadium})
+
+	stadium.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{}, nil)
+
+	venues, err := svc.Events(context.Background(), date, date)
+
+	require.NoError(t, err)
+	assert.True(t, venues[0].UnpublishedFrom.IsZero())
+}
+
+func TestEventQueryService_Events_PastHorizonSkipsFetch(t *testing.T) {
+	date := time.Date(2027, 1, 10, 0, 0, 0, 0, event.JST)
+	stadium := newHorizonFetcher(t, time.Date(2026, 11, 30, 0, 0, 0, 0, event.JST))
+	svc := NewEventQueryService([]ports.EventFetcher{stadium})
+
+	venues, err := svc.Events(context.Background(), date, date, event.VenueIDNissanStadium)
+
+	require.NoError(t, err)
+	assert.Empty(t, venues[0].Events)
+	assert.True(t, time.Date(2026, 12, 1, 0, 0, 0, 0, event.JST).Equal(venues[0].UnpublishedFrom))
+}
+
+func TestEventQueryService_Capabilities(t *testing.T) {
+	arena, _, _ := setupQueryService(t)
+	skate := newCapableFetcher(t, event.VenueIDSkateCenter, ports.Capabilities{FirstPageOnly: true})
+	svc := NewEventQueryService([]ports.EventFetcher{arena, skate})
+
+	caps := svc.Capabilities()
+
+	assert.Equal(t, map[event.VenueID]ports.Capabilities{
+		event.VenueIDYokohamaArena: {},
+		event.VenueIDSkateCenter:   {FirstPageOnly: true},
+	}, caps)
+}
+
+func TestEventQueryService_Events_ClampsToEarliest(t *testing.T) {
+	day := func(m time.Month, d int) time.Time { return time.Date(2026, m, d, 0, 0, 0, 0, event.JST) }
+	arena, _, _ := setupQueryService(t)
+	stadium := newCapableFetcher(t, event.VenueIDNissanStadium, ports.Capabilities{Earliest: day(10, 1)})
+	svc := NewEventQueryService([]ports.EventFetcher{arena, stadium})
+
+	arena.EXPECT().FetchEvents(gomock.Any(), day(9, 28), day(10, 2)).Return([]event.Event{}, nil)
+	stadium.EXPECT().FetchEvents(gomock.Any(), day(10, 1), day(10, 2)).Return([]event.Event{{Date: day(10, 2), Title: "Match"}}, nil)
+
+	venues, err := svc.Events(context.Background(), day(9, 28), day(10, 2), event.VenueIDYokohamaArena, event.VenueIDNissanStadium)
+
+	require.NoError(t, err)
+	require.Len(t, venues, 2)
+	assert.True(t, venues[0].UnlistedBefore.IsZero())
+	assert.Len(t, venues[1].Events, 1)
+	assert.True(t, day(10, 1).Equal(venues[1].UnlistedBefore))
+}
+
+func TestEventQueryService_Congestion_SkipsUnlistedVenue(t *testing.T) {
+	date := time.Date(2026, 9, 15, 0, 0, 0, 0, event.JST)
+	earliest := time.Date(2026, 10, 1, 0, 0, 0, 0, event.JST)
+	arena, _, _ := setupQueryService(t)
+	stadium := newCapableFetcher(t, event.VenueIDNissanStadium, ports.Capabilities{Earliest: earliest})
+	svc := NewEventQueryService([]ports.EventFetcher{arena, stadium})
+
+	arena.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{{Date: date, Title: "Concert"}}, nil)
+
+	c, err := svc.Congestion(context.Background(), date)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.TotalEvents)
+	assert.True(t, earliest.Equal(c.Venues[1].UnlistedBefore))
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+const defaultFetchFailureDescription = "イベント情報の取得に失敗しました"
+
+// FailureNotice selects what a destination's subscribers see when events cannot be fetched.
+// The details always go to the maintainer when WithMaintainerAlerts is set.
+type FailureNotice string
+
+const (
+	// FailureNoticeFull explains which venue failed and why. It is the zero value's behaviour.
+	FailureNoticeFull FailureNotice = "full"
+	// FailureNoticeSoft only says that this run's notification is skipped.
+	FailureNoticeSoft FailureNotice = "soft"
+	FailureNoticeNone FailureNotice = "none"
+)
+
+// LogLinkFunc returns a link to the logs of the run with runID, or "" when none can be built.
+type LogLinkFunc func(runID string) string
+
+func buildFailureNotice(notice FailureNotice, err error) *notification.Notification {
+	switch notice {
+	case FailureNoticeNone:
+		return nil
+	case FailureNoticeSoft:
+		return buildSoftFailureNotification(err)
+	default:
+		return buildFetchFailureNotification(err)
+	}
+}
+
+// buildFetchFailureNotification tells subscribers which venue failed and why, in terms
+// they can act on, without exposing the underlying error.
+func buildFetchFailureNotification(err error) *notification.Notification {
+	n := notification.NewNotification("❌ イベント取得エラー", describeFetchFailure(err), notification.ColorRed)
+	if apperrors.IsRetryable(err) {
+		n.AddField("今後の対応", "一時的な問題の可能性があります。次回の通知で再取得します", false)
+	}
+	return n
+}
+
+// buildSoftFailureNotification tells subscribers that this run's digest is skipped without
+// suggesting anything is broken.
+func buildSoftFailureNotification(err error) *notification.Notification {
+	description := "会場サイトから情報を取得できなかったため、今回のお知らせはお休みします"
+	if domainErr, ok := apperrors.AsDomainError(err); ok && domainErr.Venue != "" {
+		description = fmt.Sprintf("%sの情報を取得できなかったため、今回のお知らせはお休みします", venueDisplayName(event.VenueID(domainErr.Venue)))
+	}
+	if apperrors.IsRetryable(err) {
+		description += "。次回のお知らせで再取得します"
+	}
+	return notification.NewNotification("ℹ️ イベント情報をお届けできませんでした", description, notification.ColorYellow)
+}
+
+// buildMaintainerFailureReport gives the maintainer everything needed to investigate a
+// fetch failure: the venue, the error classification and chain, and where to find the logs.
+func buildMaintainerFailureReport(ctx context.Context, err error, logLink LogLinkFunc) *notification.Notification {
+	var report *notification.Notification
+	if apperrors.IsLayoutChanged(err) {
+		report = notification.NewNotification(
+			"⚠️ 会場サイトの構造変更を検知",
+			"ページを解析できませんでした。「予定なし」ではなく取得処理の修正が必要です",
+			notification.ColorRed,
+		)
+	} else {
+		report = notification.NewNotification("❌ イベント取得エラー", describeFetchFailure(err), notification.ColorRed)
+	}
+
+	if domainErr, ok := apperrors.AsDomainError(err); ok {
+		if domainErr.Venue != "" {
+			report.AddField("会場", venueDisplayName(event.VenueID(domainErr.Venue)), true)
+		}
+		report.AddField("種別", string(domainErr.Code), true)
+		report.AddField("再試行", fmt.Sprintf("%t", domainErr.Retryable), true)
+	}
+	report.AddField("詳細", truncateFieldValue(errorChain(err)), false)
+
+	if runID := logging.RunIDFromContext(ctx); runID != "" {
+		report.AddField("run_id", runID, true)
+		if logLink != nil {
+			if link := logLink(runID); link != "" {
+				report.AddField("ログ", link, false)
+			}
+		}
+	}
+	return report
+}
+
+// errorChain puts each wrapped error on its own line, outermost first, so the context
+// added at every layer is easy to tell apart.
+func errorChain(err error) string {
+	var lines []string
+	for err != nil {
+		msg := err.Error()
+		next := errors.Unwrap(err)
+		if next != nil {
+			msg = strings.TrimSuffix(msg, ": "+next.Error())
+		}
+		lines = append(lines, msg)
+		err = next
+	}
+	return strings.Join(lines, "\n")
+}
+
+func describeFetchFailure(err error) string {
+	domainErr, ok := apperrors.AsDomainError(err)
+	if !ok || domainErr.Venue == "" {
+		return defaultFetchFailureDescription
+	}
+
+	venue := venueDisplayName(event.VenueID(domainErr.Venue))
+	switch domainErr.Code {
+	case apperrors.CodeSourceUnavailable:
+		if domainErr.Retryable {
+			return fmt.Sprintf("%sのサイトに接続できず、イベント情報を取得できませんでした", venue)
+		}
+		return fmt.Sprintf("%sのサイトがエラーを返したため、イベント情報を取得できませんでした", venue)
+	case apperrors.CodeRateLimited:
+		return fmt.Sprintf("%sのサイトへのアクセスが一時的に制限されているため、イベント情報を取得できませんでした", venue)
+	case apperrors.CodeLayoutChanged:
+		return fmt.Sprintf("%sのサイトの構成が変わったため、イベント情報を読み取れませんでした。修正されるまでお待ちください", venue)
+	case apperrors.CodeParseFailure:
+		return fmt.Sprintf("%sのイベント情報に解析できない項目がありました", venue)
+	default:
+		return defaultFetchFailureDescription
+	}
+}
+
+func venueDisplayName(id event.VenueID) string {
+	for _, v := range event.NewAllVenues() {
+		if v.ID == id {
+			return v.DisplayName
+		}
+	}
+	return string(id)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+func TestDescribeFetchFailure(t *testing.T) {
+	notFound := apperrors.NewSourceUnavailableError("skate_center", errors.New("unexpected status code: 404"))
+	notFound.Retryable = false
+
+	testCases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "plain error", err: errors.New("boom"), want: "イベント情報の取得に失敗しました"},
+		{name: "source unavailable", err: apperrors.NewSourceUnavailableError("yokohama_arena", errors.New("timeout")), want: "横浜アリーナのサイトに接続できず、イベント情報を取得できませんでした"},
+		{name: "source error status", err: notFound, want: "KOSÉ新横浜スケートセンターのサイトがエラーを返したため、イベント情報を取得できませんでした"},
+		{name: "rate limited", err: apperrors.NewSourceRateLimitedError("nissan_stadium", nil), want: "日産スタジアムのサイトへのアクセスが一時的に制限されているため、イベント情報を取得できませんでした"},
+		{name: "layout changed", err: apperrors.NewLayoutChangedError("nissan_stadium", "calendar page has no dated rows"), want: "日産スタジアムのサイトの構成が変わったため、イベント情報を読み取れませんでした。修正されるまでお待ちください"},
+		{name: "parse failure", err: apperrors.NewParseError("nissan_stadium", "unparseable date", nil), want: "日産スタジアムのイベント情報に解析できない項目がありました"},
+		{name: "no venue", err: apperrors.NewConfigInvalidError("invalid RUN_REPORTS", nil), want: "イベント情報の取得に失敗しました"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, describeFetchFailure(fmt.Errorf("fetch all events: %w", tc.err)))
+		})
+	}
+}
+
+func TestNotifyTodayEvents_FetchError_ExplainsFailure(t *testing.T) {
+	mockSender, mockFetcher, service, ctx := setupSingleFetcherService(t)
+	fetchErr := apperrors.NewSourceUnavailableError("yokohama_arena", errors.New("connection refused"))
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fetchErr)
+
+	var sent *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		sent = n
+		return nil
+	})
+
+	err := service.NotifyTodayEvents(ctx)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, apperrors.ErrSourceUnavailable)
+	require.NotNil(t, sent)
+	assert.Equal(t, "❌ イベント取得エラー", sent.Title())
+	assert.Equal(t, "横浜アリーナのサイトに接続できず、イベント情報を取得できませんでした", sent.Description())
+	require.Len(t, sent.Fields(), 1)
+	assert.Contains(t, sent.Fields()[0].Value, "次回の通知で再取得します")
+	assert.NotContains(t, sent.Description(), "connection refused")
+}
+
+func fieldsByName(n *notification.Notification) map[string]string {
+	fields := make(map[string]string)
+	for _, f := range n.Fields() {
+		fields[f.Name] = f.Value
+	}
+	return fields
+}
+
+func TestNotifyTodayEvents_FetchError_FailureNoticePerDestination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	full := mock_ports.NewMockNotificationSender(ctrl)
+	soft := mock_ports.NewMockNotificationSender(ctrl)
+	silent := mock_ports.NewMockNotificationSender(ctrl)
+	maintainer := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+
+	service := NewEventNotificationService(full, []ports.EventFetcher{mockFetcher},
+		WithDestinations(
+			Destination{Name: "full", Sender: full},
+			Destination{Name: "soft", Sender: soft, FailureNotice: FailureNoticeSoft},
+			Destination{Name: "silent", Sender: silent, FailureNotice: FailureNoticeNone},
+		),
+		WithMaintainerAlerts(maintainer),
+		WithLogLink(func(runID string) string { return "https://logs.example/" + runID }),
+	)
+
+	fetchErr := apperrors.NewSourceUnavailableError("yokohama_arena", errors.New("connection refused"))
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("failed to fetch events for yokohama_arena: %w", fetchErr))
+
+	full.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		assert.Equal(t, "❌ イベント取得エラー", n.Title())
+		return nil
+	})
+	soft.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		assert.Equal(t, "ℹ️ イベント情報をお届けできませんでした", n.Title())
+		assert.Equal(t, notification.ColorYellow, n.Color())
+		assert.Equal(t, "横浜アリーナの情報を取得できなかったため、今回のお知らせはお休みします。次回のお知らせで再取得します", n.Description())
+		assert.Empty(t, n.Fields())
+		return nil
+	})
+	var report *notification.Notification
+	maintainer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		report = n
+		return nil
+	})
+
+	err := service.NotifyTodayEvents(context.Background())
+
+	require.Error(t, err)
+	require.NotNil(t, report)
+	fields := fieldsByName(report)
+	assert.Equal(t, "横浜アリーナ", fields["会場"])
+	assert.Equal(t, string(apperrors.CodeSourceUnavailable), fields["種別"])
+	assert.Equal(t, "true", fields["再試行"])
+	assert.Equal(t, "fetch all events\nfetch events for venue yokohama_arena\nfailed to fetch events for yokohama_arena\nSOURCE_UNAVAILABLE: yokohama_arena: site is unavailable - connection refused\nconnection refused", fields["詳細"])
+	require.NotEmpty(t, fields["run_id"])
+	assert.Equal(t, "https://logs.example/"+fields["run_id"], fields["ログ"])
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+const snapshotDateLayout = "2006-01-02"
+
+// fallback keeps the events of every successful fetch per venue and date, and serves
+// them when a later fetch of the same dates fails. The methods do nothing on a nil
+// fallback. Store errors are only logged: the fallback must never fail a fetch that
+// would otherwise succeed.
+type fallback struct {
+	store  ports.SnapshotStore
+	clock  ports.Clock
+	maxAge time.Duration
+}
+
+func (f *fallback) save(ctx context.Context, venue event.VenueID, from, to time.Time, events []event.Event) {
+	if f == nil {
+		return
+	}
+
+	byDate := make(map[string][]event.Event)
+	for _, e := range events {
+		date := e.Date.In(from.Location()).Format(snapshotDateLayout)
+		byDate[date] = append(byDate[date], e)
+	}
+
+	now := f.clock.Now()
+	for _, date := range snapshotDates(from, to) {
+		snapshot := &ports.Snapshot{FetchedAt: now, Events: byDate[date]}
+		if err := f.store.Save(ctx, venue, date, snapshot); err != nil {
+			logging.FromContext(ctx).Warn("failed to save snapshot", "date", date, logging.KeyError, err)
+		}
+	}
+}
+
+// load returns the stored events for every date from from to to, and when the oldest of
+// them was fetched. It reports false unless every date has a snapshot within maxAge, since
+// a partial schedule would read as "no events" on the missing dates.
+func (f *fallback) load(ctx context.Context, venue event.VenueID, from, to time.Time) ([]event.Event, time.Time, bool) {
+	if f == nil {
+		return nil, time.Time{}, false
+	}
+
+	now := f.clock.Now()
+	var events []event.Event
+	var oldest time.Time
+	for _, date := range snapshotDates(from, to) {
+		snapshot, ok, err := f.store.Load(ctx, venue, date)
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to load snapshot", "date", date, logging.KeyError, err)
+			return nil, time.Time{}, false
+		}
+		if !ok || (f.maxAge > 0 && now.Sub(snapshot.FetchedAt) > f.maxAge) {
+			return nil, time.Time{}, false
+		}
+		events = append(events, snapshot.Events...)
+		if oldest.IsZero() || snapshot.FetchedAt.Before(oldest) {
+			oldest = snapshot.FetchedAt
+		}
+	}
+	return events, oldest, true
+}
+
+func snapshotDates(from, to time.Time) []string {
+	var dates []string
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d.Format(snapshotDateLayout))
+	}
+	return dates
+}
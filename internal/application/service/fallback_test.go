@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+type fakeSnapshotStore map[string]ports.Snapshot
+
+func (f fakeSnapshotStore) Load(_ context.Context, venue event.VenueID, date string) (*ports.Snapshot, bool, error) {
+	s, ok := f[string(venue)+"/"+date]
+	return &s, ok, nil
+}
+
+func (f fakeSnapshotStore) Save(_ context.Context, venue event.VenueID, date string, snapshot *ports.Snapshot) error {
+	f[string(venue)+"/"+date] = *snapshot
+	return nil
+}
+
+func setupFallbackService(t *testing.T, store ports.SnapshotStore, now time.Time) (*mock_ports.MockNotificationSender, *mock_ports.MockEventFetcher, *mock_ports.MockNotificationSender, *EventNotificationService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	mockReports := mock_ports.NewMockNotificationSender(ctrl)
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher},
+		WithFallback(store, 48*time.Hour),
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+		WithRunReports(mockReports, false),
+	)
+	return mockSender, mockFetcher, mockReports, service
+}
+
+func TestNotifyWeeklyEvents_SavesSnapshotPerDate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 7, 0, 0, 0, event.JST)
+	store := fakeSnapshotStore{}
+	mockSender, mockFetcher, mockReports, service := setupFallbackService(t, store, now)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{
+		{Date: time.Date(2026, 10, 20, 0, 0, 0, 0, event.JST), Title: "Live"},
+	}, nil)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+	mockReports.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, service.NotifyWeeklyEvents(context.Background()))
+
+	assert.Len(t, store, 7, "days without events are saved too")
+	assert.Equal(t, "Live", store["yokohama_arena/2026-10-20"].Events[0].Title)
+	assert.Empty(t, store["yokohama_arena/2026-10-19"].Events)
+	assert.True(t, now.Equal(store["yokohama_arena/2026-10-19"].FetchedAt))
+}
+
+func TestNotifyTodayEvents_FallsBackToSnapshot(t *testing.T) {
+	now := time.Date(2026, 10, 18, 7, 0, 0, 0, event.JST)
+	fetchedAt := time.Date(2026, 10, 17, 19, 0, 0, 0, event.JST)
+	store := fakeSnapshotStore{
+		"yokohama_arena/2026-10-18": {FetchedAt: fetchedAt, Events: []event.Event{{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST), Title: "Live"}}},
+	}
+	mockSender, mockFetcher, mockReports, service := setupFallbackService(t, store, now)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+	var sent, report *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		sent = n
+		return nil
+	})
+	mockReports.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		report = n
+		return nil
+	})
+
+	require.NoError(t, service.NotifyTodayEvents(context.Background()))
+
+	require.NotNil(t, sent)
+	assert.Equal(t, "📅 新横浜 イベント情報", sent.Title())
+	assert.Contains(t, sent.Fields()[0].Value, "Live")
+	assert.Contains(t, sent.Fields()[0].Value, "※前回取得データ（10/17 19:00時点）")
+	require.NotNil(t, report)
+	assert.Contains(t, report.Fields()[0].Value, "エラー: timeout")
+	assert.Contains(t, report.Fields()[0].Value, "前回取得データで代替: 2026-10-17 19:00")
+}
+
+func TestNotifyTodayEvents_SnapshotTooOld(t *testing.T) {
+	now := time.Date(2026, 10, 18, 7, 0, 0, 0, event.JST)
+	store := fakeSnapshotStore{
+		"yokohama_arena/2026-10-18": {FetchedAt: now.Add(-72 * time.Hour)},
+	}
+	mockSender, mockFetcher, mockReports, service := setupFallbackService(t, store, now)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		assert.Equal(t, "❌ イベント取得エラー", n.Title())
+		return nil
+	})
+	mockReports.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.Error(t, service.NotifyTodayEvents(context.Background()))
+}
+
+func TestNotifyTodayEvents_FallbackStillAlertsLayoutChange(t *testing.T) {
+	now := time.Date(2026, 10, 18, 7, 0, 0, 0, event.JST)
+	store := fakeSnapshotStore{
+		"yokohama_arena/2026-10-18": {FetchedAt: now.Add(-12 * time.Hour), Events: []event.Event{{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST), Title: "Live"}}},
+	}
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockMaintainer := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher},
+		WithFallback(store, 48*time.Hour),
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+		WithMaintainerAlerts(mockMaintainer),
+	)
+
+	layoutErr := apperrors.NewLayoutChangedError("yokohama_arena", "event list is missing")
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, layoutErr)
+	var sent, alert *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		sent = n
+		return nil
+	})
+	mockMaintainer.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		alert = n
+		return nil
+	})
+
+	require.NoError(t, service.NotifyTodayEvents(context.Background()))
+
+	require.NotNil(t, sent)
+	assert.Contains(t, sent.Fields()[0].Value, "Live")
+	require.NotNil(t, alert)
+	assert.Equal(t, "⚠️ 会場サイトの構造変更を検知", alert.Title())
+	fields := fieldsByName(alert)
+	assert.Equal(t, "横浜アリーナ", fields["会場"])
+	assert.Equal(t, "前回取得データで代替しました", fields["通知"])
+}
+
+func TestNotifyTodayEvents_FallbackDoesNotAlertTransientErrors(t *testing.T) {
+	now := time.Date(2026, 10, 18, 7, 0, 0, 0, event.JST)
+	store := fakeSnapshotStore{"yokohama_arena/2026-10-18": {FetchedAt: now.Add(-12 * time.Hour)}}
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockMaintainer := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher},
+		WithFallback(store, 48*time.Hour),
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+		WithMaintainerAlerts(mockMaintainer),
+	)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, apperrors.NewSourceUnavailableError("yokohama_arena", errors.New("timeout")))
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, service.NotifyTodayEvents(context.Background()))
+}
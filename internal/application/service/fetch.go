@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// fetchVenueEvents runs the fetchers concurrently and appends their events to the matching venues.
+// A venue whose fetch fails gets the events kept by fb instead, if it has them. Fetchers
+// are only asked for the days their source lists, and their venue records where the
+// unlisted days end and the unpublished days start.
+// It returns a diagnostics report per fetcher, including when fetching failed, and the
+// errors of the fetches that fb recovered from, which callers may still need to act on.
+func fetchVenueEvents(ctx context.Context, fetchers []ports.EventFetcher, venues []*event.Venue, from, to time.Time, fb *fallback) ([]*diagnostics.Report, []error, error) {
+	venueMap := make(map[event.VenueID]*event.Venue)
+	for _, v := range venues {
+		venueMap[v.ID] = v
+	}
+
+	type fetchResult struct {
+		staleAsOf       time.Time
+		unlistedBefore  time.Time
+		unpublishedFrom time.Time
+		recovered       error
+		venueID         event.VenueID
+		events          []event.Event
+	}
+	results := make([]fetchResult, len(fetchers))
+	reports := make([]*diagnostics.Report, len(fetchers))
+
+	eg, ctx := errgroup.WithContext(ctx)
+	for i, fetcher := range fetchers {
+		report := diagnostics.NewReport(fetcher.VenueID())
+		reports[i] = report
+		eg.Go(func() error {
+			ctx := logging.With(ctx, logging.KeyVenue, string(fetcher.VenueID()))
+
+			listedFrom, unlistedBefore := clampToEarliest(fetcher, from)
+			listedTo, unpublishedFrom := clampToHorizon(fetcher, listedFrom, to)
+			if listedTo.Before(listedFrom) {
+				logging.FromContext(ctx).Info("range is outside the days the venue lists")
+				report.Skip("range outside the days the venue lists", fmt.Sprintf("%s to %s", from.Format("2006-01-02"), to.Format("2006-01-02")))
+				report.Finish(0, 0, nil)
+				results[i] = fetchResult{venueID: fetcher.VenueID(), unlistedBefore: unlistedBefore, unpublishedFrom: unpublishedFrom}
+				return nil
+			}
+			from, to := listedFrom, listedTo
+
+			ctx, span := tracer().Start(ctx, "EventFetcher.FetchEvents", trace.WithAttributes(attribute.String("venue", string(fetcher.VenueID()))))
+			start := time.Now()
+			events, err := fetcher.FetchEvents(diagnostics.NewContext(ctx, report), from, to)
+			report.Finish(len(events), time.Since(start), err)
+			span.SetAttributes(attribute.Int("events", len(events)))
+			endSpan(span, err)
+			if err != nil {
+				err = fmt.Errorf("fetch events for venue %s: %w", fetcher.VenueID(), err)
+				stale, fetchedAt, ok := fb.load(ctx, fetcher.VenueID(), from, to)
+				if !ok {
+					return err
+				}
+				logging.FromContext(ctx).Warn("using previously fetched events", "fetched_at", fetchedAt, logging.KeyError, err)
+				report.UseFallback(len(stale), fetchedAt)
+				results[i] = fetchResult{
+					venueID:         fetcher.VenueID(),
+					events:          stale,
+					staleAsOf:       fetchedAt,
+					unlistedBefore:  unlistedBefore,
+					unpublishedFrom: unpublishedFrom,
+					recovered:       err,
+				}
+				return nil
+			}
+			fb.save(ctx, fetcher.VenueID(), from, to, events)
+			results[i] = fetchResult{venueID: fetcher.VenueID(), events: events, unlistedBefore: unlistedBefore, unpublishedFrom: unpublishedFrom}
+			return nil
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		return reports, nil, fmt.Errorf("fetch all events: %w", err)
+	}
+
+	var recovered []error
+	for _, r := range results {
+		if r.recovered != nil {
+			recovered = append(recovered, r.recovered)
+		}
+		if venue, ok := venueMap[r.venueID]; ok {
+			venue.Events = append(venue.Events, r.events...)
+			venue.StaleAsOf = r.staleAsOf
+			venue.UnlistedBefore = r.unlistedBefore
+			venue.UnpublishedFrom = r.unpublishedFrom
+		}
+	}
+
+	return reports, recovered, nil
+}
+
+// clampToEarliest starts the range at the first day the fetcher's source still lists, if
+// that is after from, and returns that day as the end of the unlisted days. The range is
+// left empty when the whole of it is unlisted.
+func clampToEarliest(fetcher ports.EventFetcher, from time.Time) (time.Time, time.Time) {
+	caps := ports.CapabilitiesOf(fetcher)
+	if caps.Earliest.IsZero() {
+		return from, time.Time{}
+	}
+	earliest := caps.Earliest.In(from.Location())
+	if !earliest.After(from) {
+		return from, time.Time{}
+	}
+	return earliest, earliest
+}
+
+// clampToHorizon ends the range at the fetcher's publication horizon, if it has one before
+// to, and returns the day after the horizon as the first unpublished day.
+func clampToHorizon(fetcher ports.EventFetcher, from, to time.Time) (time.Time, time.Time) {
+	caps := ports.CapabilitiesOf(fetcher)
+	if caps.Horizon.IsZero() {
+		return to, time.Time{}
+	}
+	horizon := caps.Horizon.In(from.Location())
+	unpublishedFrom := horizon.AddDate(0, 0, 1)
+	if unpublishedFrom.After(to) {
+		return to, time.Time{}
+	}
+	return horizon, unpublishedFrom
+}
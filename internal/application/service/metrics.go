@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// Metric names, published per venue and run mode for fetches and per run mode for sends.
+const (
+	metricFetchLatency  = "FetchLatency"
+	metricFetchSuccess  = "FetchSuccess"
+	metricFetchFailure  = "FetchFailure"
+	metricEventCount    = "EventCount"
+	metricParseWarnings = "ParseWarnings"
+	metricSendLatency   = "NotificationSendLatency"
+	metricSendFailure   = "NotificationSendFailure"
+	dimensionVenue      = "venue"
+	dimensionMode       = "mode"
+)
+
+func (s *EventNotificationService) recordFetchMetrics(ctx context.Context, run *diagnostics.RunReport) {
+	if s.metrics == nil {
+		return
+	}
+
+	var metrics []ports.Metric
+	for _, f := range run.Fetchers {
+		dims := map[string]string{dimensionVenue: string(f.Venue), dimensionMode: run.Kind}
+		failed := 0.0
+		if f.Error != "" {
+			failed = 1
+		}
+		metrics = append(metrics,
+			ports.Metric{Name: metricFetchLatency, Unit: ports.UnitMilliseconds, Value: float64(f.DurationMS), Dimensions: dims},
+			ports.Metric{Name: metricFetchSuccess, Unit: ports.UnitCount, Value: 1 - failed, Dimensions: dims},
+			ports.Metric{Name: metricFetchFailure, Unit: ports.UnitCount, Value: failed, Dimensions: dims},
+			ports.Metric{Name: metricEventCount, Unit: ports.UnitCount, Value: float64(f.Events), Dimensions: dims},
+			ports.Metric{Name: metricParseWarnings, Unit: ports.UnitCount, Value: float64(len(f.Warnings)), Dimensions: dims},
+		)
+	}
+	s.metrics.Record(ctx, metrics...)
+}
+
+func (s *EventNotificationService) recordSendMetrics(ctx context.Context, kind string, d time.Duration, err error) {
+	if s.metrics == nil {
+		return
+	}
+
+	dims := map[string]string{dimensionMode: kind}
+	failed := 0.0
+	if err != nil {
+		failed = 1
+	}
+	s.metrics.Record(ctx,
+		ports.Metric{Name: metricSendLatency, Unit: ports.UnitMilliseconds, Value: float64(d.Milliseconds()), Dimensions: dims},
+		ports.Metric{Name: metricSendFailure, Unit: ports.UnitCount, Value: failed, Dimensions: dims},
+	)
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/metrics"
+)
+
+func TestNotifyWeeklyEvents_RecordsMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	arena := mock_ports.NewMockEventFetcher(ctrl)
+	skate := mock_ports.NewMockEventFetcher(ctrl)
+	arena.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	skate.EXPECT().VenueID().Return(event.VenueIDSkateCenter).AnyTimes()
+
+	recorder := metrics.NewMemory()
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{arena, skate}, WithMetrics(recorder))
+
+	arena.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _, _ time.Time) ([]event.Event, error) {
+		diagnostics.FromContext(ctx).Warnf("bad time")
+		return []event.Event{{Title: "A"}, {Title: "B"}}, nil
+	})
+	skate.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{}, nil)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, service.NotifyWeeklyEvents(context.Background()))
+
+	arenaDims := map[string]string{"venue": "yokohama_arena", "mode": "weekly"}
+	assert.Equal(t, []float64{2}, recorder.Find("EventCount", arenaDims))
+	assert.Equal(t, []float64{1}, recorder.Find("ParseWarnings", arenaDims))
+	assert.Equal(t, []float64{1}, recorder.Find("FetchSuccess", arenaDims))
+	assert.Equal(t, []float64{0}, recorder.Find("FetchFailure", arenaDims))
+	assert.Len(t, recorder.Find("FetchLatency", arenaDims), 1)
+	assert.Equal(t, []float64{0}, recorder.Find("EventCount", map[string]string{"venue": "skate_center", "mode": "weekly"}))
+	assert.Len(t, recorder.Find("NotificationSendLatency", map[string]string{"mode": "weekly"}), 1)
+	assert.Equal(t, []float64{0}, recorder.Find("NotificationSendFailure", map[string]string{"mode": "weekly"}))
+}
+
+func TestNotifyTodayEvents_RecordsFailureMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+
+	recorder := metrics.NewMemory()
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher}, WithMetrics(recorder))
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.Error(t, service.NotifyTodayEvents(context.Background()))
+
+	dims := map[string]string{"venue": "nissan_stadium", "mode": "daily"}
+	assert.Equal(t, []float64{1}, recorder.Find("FetchFailure", dims))
+	assert.Equal(t, []float64{0}, recorder.Find("FetchSuccess", dims))
+}
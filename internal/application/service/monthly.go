@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+)
+
+// maxMonthlyTitleLength keeps a notable day on one line. With three venues and a line per
+// day, a month stays well within Discord's 6000 character limit for an embed.
+const maxMonthlyTitleLength = 20
+
+// NotifyMonthlyEvents sends an overview of the month containing today, listing only the
+// notable days: those with an event at a large venue or with events at two or more venues.
+func (s *EventNotificationService) NotifyMonthlyEvents(ctx context.Context) (err error) {
+	first, last := monthRange(s.today())
+
+	ctx = withRun(ctx, runKindMonthly)
+	ctx, span := startRunSpan(ctx, "EventNotificationService.NotifyMonthlyEvents", runKindMonthly, first, last)
+	defer func() { endSpan(span, err) }()
+
+	venues := event.NewAllVenues()
+
+	run, err := s.fetchAllEvents(ctx, runKindMonthly, venues, first, last)
+	s.postRunReport(ctx, run)
+	if err != nil {
+		return s.notifyFetchFailure(ctx, err)
+	}
+
+	notif := s.buildMonthlyNotification(venues, first)
+	notif.SetEditKey(editKey("monthly", first))
+
+	return s.deliver(ctx, runKindMonthly, notif, newDigest(venues, first, last))
+}
+
+// monthRange returns the first and last day of the month containing day.
+func monthRange(day time.Time) (time.Time, time.Time) {
+	first := time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	return first, first.AddDate(0, 1, -1)
+}
+
+// notableDay is a day of the month worth planning around, with its events by venue.
+type notableDay struct {
+	date   time.Time
+	venues []venueDay
+}
+
+type venueDay struct {
+	venue  *event.Venue
+	events []event.Event
+}
+
+func (s *EventNotificationService) buildMonthlyNotification(venues []*event.Venue, month time.Time) *notification.Notification {
+	label := fmt.Sprintf("%d年%d月", month.Year(), month.Month())
+	days := s.notableDays(venues)
+
+	var description string
+	if total := countEvents(venues); total == 0 {
+		description = fmt.Sprintf("%sの開催イベントはありません", label)
+	} else {
+		description = fmt.Sprintf("%sのイベント数: %d件 / 注目日: %d日", label, total, len(days))
+	}
+
+	notif := notification.NewNotification("📅 新横浜 月間イベント情報", description, s.determineColor(venues))
+	notif.SetTopic(label)
+
+	for _, venue := range venues {
+		fieldName := fmt.Sprintf("%s %s", venue.Emoji, venue.DisplayName)
+		count := fmt.Sprintf("%d件", len(venue.Events))
+		// A site read only up to its first page may not list the whole month yet.
+		if s.capabilitiesOf(venue.ID).FirstPageOnly {
+			count += "\n※直近の掲載分のみ"
+		}
+		notif.AddField(fieldName, s.annotateVenue(venue, count), true)
+	}
+
+	if len(days) == 0 {
+		notif.AddField("注目日", "大規模会場でのイベントや複数会場が重なる日はありません", false)
+		return notif
+	}
+
+	lines := make([]string, 0, len(days))
+	for _, d := range days {
+		lines = append(lines, formatNotableDay(d))
+	}
+	for i, chunk := range chunkLines(lines, maxFieldValueLength) {
+		name := "注目日"
+		if i > 0 {
+			name = "注目日（続き）"
+		}
+		notif.AddField(name, chunk, false)
+	}
+
+	return notif
+}
+
+// notableDays groups the events by day, in date order, and keeps the days with an event at
+// a large venue or with events at two or more venues.
+func (s *EventNotificationService) notableDays(venues []*event.Venue) []notableDay {
+	byDate := make(map[time.Time]*notableDay)
+	for _, venue := range venues {
+		for _, e := range venue.Events {
+			date := s.startOfDay(e.Date)
+			d, ok := byDate[date]
+			if !ok {
+				d = &notableDay{date: date}
+				byDate[date] = d
+			}
+			if n := len(d.venues); n == 0 || d.venues[n-1].venue != venue {
+				d.venues = append(d.venues, venueDay{venue: venue})
+			}
+			v := &d.venues[len(d.venues)-1]
+			v.events = append(v.events, e)
+		}
+	}
+
+	var days []notableDay
+	for _, d := range byDate {
+		if len(d.venues) >= 2 || d.venues[0].venue.Large {
+			days = append(days, *d)
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].date.Before(days[j].date) })
+	return days
+}
+
+// formatNotableDay renders a day on one line, showing the earliest event of each venue.
+func formatNotableDay(d notableDay) string {
+	parts := make([]string, 0, len(d.venues))
+	for _, v := range d.venues {
+		events := v.events
+		sort.SliceStable(events, func(i, j int) bool {
+			si, sj := firstStartTime(events[i]), firstStartTime(events[j])
+			return si != nil && (sj == nil || si.Before(*sj))
+		})
+
+		part := v.venue.Emoji + " "
+		if start := firstStartTime(events[0]); start != nil {
+			part += start.Format("15:04") + " "
+		}
+		part += truncateTitle(events[0].Title, maxMonthlyTitleLength)
+		if len(events) > 1 {
+			part += fmt.Sprintf(" 他%d件", len(events)-1)
+		}
+		parts = append(parts, part)
+	}
+	return fmt.Sprintf("**%s** %s", formatDateLabel(d.date), strings.Join(parts, " / "))
+}
+
+func truncateTitle(title string, n int) string {
+	if utf8.RuneCountInString(title) <= n {
+		return title
+	}
+	return string([]rune(title)[:n-1]) + "…"
+}
+
+// chunkLines joins lines into values of at most limit characters, without splitting a line.
+// Lines are expected to be much shorter than limit.
+func chunkLines(lines []string, limit int) []string {
+	var chunks []string
+	var cur string
+	for _, line := range lines {
+		if cur != "" && utf8.RuneCountInString(cur)+1+utf8.RuneCountInString(line) > limit {
+			chunks = append(chunks, cur)
+			cur = ""
+		}
+		if cur != "" {
+			cur += "\n"
+		}
+		cur += line
+	}
+	if cur != "" {
+		chunks = append(chunks, cur)
+	}
+	return chunks
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+)
+
+func TestNotifyMonthlyEvents_FetchesWholeMonth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+
+	now := time.Date(2026, 2, 1, 6, 0, 0, 0, event.JST)
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher},
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+	)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, from, to time.Time) ([]event.Event, error) {
+		assert.True(t, time.Date(2026, 2, 1, 0, 0, 0, 0, event.JST).Equal(from))
+		assert.True(t, time.Date(2026, 2, 28, 0, 0, 0, 0, event.JST).Equal(to))
+		return []event.Event{
+			{Title: "J1リーグ 第1節", Date: time.Date(2026, 2, 14, 0, 0, 0, 0, event.JST)},
+		}, nil
+	})
+
+	var sent *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		sent = n
+		return nil
+	})
+
+	require.NoError(t, service.NotifyMonthlyEvents(context.Background()))
+	require.NotNil(t, sent)
+	assert.Equal(t, "📅 新横浜 月間イベント情報", sent.Title())
+	assert.Equal(t, "2026年2月のイベント数: 1件 / 注目日: 1日", sent.Description())
+	assert.Equal(t, "2026年2月", sent.Topic())
+	assert.Equal(t, "default/monthly/2026-02-01", sent.EditKey())
+}
+
+func TestNotifyMonthlyEvents_FetchError(t *testing.T) {
+	mockSender, mockFetcher, service, ctx := setupSingleFetcherService(t)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("boom"))
+
+	var sent *notification.Notification
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		sent = n
+		return nil
+	})
+
+	err := service.NotifyMonthlyEvents(ctx)
+
+	require.Error(t, err)
+	require.NotNil(t, sent)
+	assert.Equal(t, notification.ColorRed, sent.Color())
+}
+
+func TestBuildMonthlyNotification_ListsOnlyNotableDays(t *testing.T) {
+	service := NewEventNotificationService(nil, nil)
+	day := func(d int) time.Time { return time.Date(2026, 3, d, 0, 0, 0, 0, event.JST) }
+	at := func(d, h int) *time.Time { return timePtr(time.Date(2026, 3, d, h, 0, 0, 0, event.JST)) }
+
+	venues := event.NewAllVenues()
+	venues[0].Events = []event.Event{
+		{Title: "夜公演", Date: day(7), Schedules: []event.Schedule{{StartTime: at(7, 18)}}},
+		{Title: "昼公演", Date: day(7), Schedules: []event.Schedule{{StartTime: at(7, 13)}}},
+	}
+	venues[1].Events = []event.Event{
+		{Title: "J1リーグ", Date: day(21), Schedules: []event.Schedule{{StartTime: at(21, 14)}}},
+	}
+	venues[2].Events = []event.Event{
+		{Title: "アイスホッケー", Date: day(3)},
+		{Title: "フィギュアスケート大会", Date: day(21)},
+	}
+
+	n := service.buildMonthlyNotification(venues, day(1))
+
+	fields := n.Fields()
+	require.Len(t, fields, 4)
+	assert.Equal(t, notification.Field{Name: "🏟️ 横浜アリーナ", Value: "2件", Inline: true}, fields[0])
+	assert.Equal(t, notification.Field{Name: "⚽ 日産スタジアム", Value: "1件", Inline: true}, fields[1])
+	assert.Equal(t, notification.Field{Name: "⛸️ KOSÉ新横浜スケートセンター", Value: "2件", Inline: true}, fields[2])
+	assert.Equal(t, "注目日", fields[3].Name)
+	assert.Equal(t,
+		"**3/7(土)** 🏟️ 13:00 昼公演 他1件\n"+
+			"**3/21(土)** ⚽ 14:00 J1リーグ / ⛸️ フィギュアスケート大会",
+		fields[3].Value)
+}
+
+func TestBuildMonthlyNotification_NotesFirstPageOnlyVenues(t *testing.T) {
+	skate := newCapableFetcher(t, event.VenueIDSkateCenter, ports.Capabilities{FirstPageOnly: true})
+	service := NewEventNotificationService(nil, []ports.EventFetcher{skate})
+
+	n := service.buildMonthlyNotification(event.NewAllVenues(), time.Date(2026, 3, 1, 0, 0, 0, 0, event.JST))
+
+	fields := n.Fields()
+	assert.Equal(t, "0件", fields[0].Value)
+	assert.Equal(t, "0件\n※直近の掲載分のみ", fields[2].Value)
+}
+
+func TestBuildMonthlyNotification_NoNotableDays(t *testing.T) {
+	service := NewEventNotificationService(nil, nil)
+	venues := event.NewAllVenues()
+	venues[2].Events = []event.Event{{Title: "アイスホッケー", Date: time.Date(2026, 3, 3, 0, 0, 0, 0, event.JST)}}
+
+	n := service.buildMonthlyNotification(venues, time.Date(2026, 3, 1, 0, 0, 0, 0, event.JST))
+
+	assert.Equal(t, "2026年3月のイベント数: 1件 / 注目日: 0日", n.Description())
+	fields := n.Fields()
+	require.Len(t, fields, 4)
+	assert.Equal(t, "大規模会場でのイベントや複数会場が重なる日はありません", fields[3].Value)
+}
+
+func TestBuildMonthlyNotification_FitsDiscordLimits(t *testing.T) {
+	service := NewEventNotificationService(nil, nil)
+	longTitle := strings.Repeat("長いイベント名", 20)
+
+	venues := event.NewAllVenues()
+	for d := 1; d <= 31; d++ {
+		date := time.Date(2026, 3, d, 0, 0, 0, 0, event.JST)
+		for _, v := range venues {
+			v.Events = append(v.Events, event.Event{Title: longTitle, Date: date}, event.Event{Title: longTitle, Date: date})
+		}
+	}
+
+	n := service.buildMonthlyNotification(venues, time.Date(2026, 3, 1, 0, 0, 0, 0, event.JST))
+
+	total := utf8.RuneCountInString(n.Title()) + utf8.RuneCountInString(n.Description())
+	var notable int
+	for _, f := range n.Fields() {
+		assert.LessOrEqual(t, utf8.RuneCountInString(f.Value), maxFieldValueLength)
+		total += utf8.RuneCountInString(f.Name) + utf8.RuneCountInString(f.Value)
+		if strings.HasPrefix(f.Name, "注目日") {
+			notable += strings.Count(f.Value, "\n") + 1
+		}
+	}
+	assert.LessOrEqual(t, total, 6000)
+	assert.Equal(t, 31, notable)
+	assert.Equal(t, "注目日（続き）", n.Fields()[4].Name)
+}
+
+func TestChunkLines(t *testing.T) {
+	assert.Equal(t, []string{"aaa\nbbb", "ccc"}, chunkLines([]string{"aaa", "bbb", "ccc"}, 7))
+	assert.Empty(t, chunkLines(nil, 7))
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// Policy decides whether a digest is worth posting to a destination and how loudly.
+// The zero value always sends and never mentions anyone, matching the original behaviour.
+type Policy struct {
+	VenueMentions []VenueMention
+	// MinCongestion is the minimum number of venues with events required to notify. Zero disables the check.
+	MinCongestion             int
+	SkipEmpty                 bool
+	AlwaysOnWeekends          bool
+	MentionHereForLargeVenues bool
+}
+
+// VenueMention alerts Mention whenever Venue has an event in the digest,
+// optionally only for events on weekdays when they affect commuting.
+type VenueMention struct {
+	Venue        event.VenueID
+	Mention      notification.Mention
+	WeekdaysOnly bool
+}
+
+type decision struct {
+	mentions []notification.Mention
+	send     bool
+}
+
+type Destination struct {
+	Sender ports.NotificationSender
+	Name   string
+	// FailureNotice selects what subscribers see when events cannot be fetched.
+	FailureNotice FailureNotice
+	Policy        Policy
+}
+
+type digest struct {
+	from             time.Time
+	to               time.Time
+	venues           []*event.Venue
+	totalEvents      int
+	venuesWithEvents int
+	largeVenueActive bool
+}
+
+func newDigest(venues []*event.Venue, from, to time.Time) digest {
+	d := digest{from: from, to: to, venues: venues}
+	for _, venue := range venues {
+		if len(venue.Events) == 0 {
+			continue
+		}
+		d.totalEvents += len(venue.Events)
+		d.venuesWithEvents++
+		if venue.Large {
+			d.largeVenueActive = true
+		}
+	}
+	return d
+}
+
+func (d digest) includesWeekend() bool {
+	for day := d.from; !day.After(d.to); day = day.AddDate(0, 0, 1) {
+		if !isWeekday(day) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d digest) venueActive(id event.VenueID, weekdaysOnly bool) bool {
+	for _, venue := range d.venues {
+		if venue.ID != id {
+			continue
+		}
+		for _, e := range venue.Events {
+			if !weekdaysOnly || isWeekday(e.Date) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isWeekday does not account for public holidays.
+func isWeekday(t time.Time) bool {
+	wd := t.Weekday()
+	return wd != time.Saturday && wd != time.Sunday
+}
+
+func (p Policy) decide(d digest) decision {
+	if !p.shouldSend(d) {
+		return decision{}
+	}
+
+	dec := decision{send: true}
+	if p.MentionHereForLargeVenues && d.largeVenueActive {
+		dec.mentions = append(dec.mentions, notification.HereMention())
+	}
+	for _, vm := range p.VenueMentions {
+		if d.venueActive(vm.Venue, vm.WeekdaysOnly) {
+			dec.mentions = append(dec.mentions, vm.Mention)
+		}
+	}
+	return dec
+}
+
+func (p Policy) shouldSend(d digest) bool {
+	if p.AlwaysOnWeekends && d.includesWeekend() {
+		return true
+	}
+	if p.SkipEmpty && d.totalEvents == 0 {
+		return false
+	}
+	if p.MinCongestion > 0 && d.venuesWithEvents < p.MinCongestion {
+		return false
+	}
+	return true
+}
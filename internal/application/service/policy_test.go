@@ -0,0 +1,160 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+)
+
+func TestPolicy_Decide(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	monday := time.Date(2026, 4, 6, 0, 0, 0, 0, jst)
+	saturday := time.Date(2026, 4, 11, 0, 0, 0, 0, jst)
+
+	empty := digest{from: monday, to: monday}
+	quiet := digest{from: monday, to: monday, totalEvents: 1, venuesWithEvents: 1}
+	busy := digest{from: monday, to: monday, totalEvents: 3, venuesWithEvents: 2, largeVenueActive: true}
+	emptyWeekend := digest{from: saturday, to: saturday}
+
+	tests := []struct {
+		name     string
+		expected decision
+		policy   Policy
+		digest   digest
+	}{
+		{
+			name:     "zero policy always sends",
+			policy:   Policy{},
+			digest:   empty,
+			expected: decision{send: true},
+		},
+		{
+			name:     "skip empty suppresses empty day",
+			policy:   Policy{SkipEmpty: true},
+			digest:   empty,
+			expected: decision{},
+		},
+		{
+			name:     "skip empty sends when events exist",
+			policy:   Policy{SkipEmpty: true},
+			digest:   quiet,
+			expected: decision{send: true},
+		},
+		{
+			name:     "below congestion threshold",
+			policy:   Policy{MinCongestion: 2},
+			digest:   quiet,
+			expected: decision{},
+		},
+		{
+			name:     "meets congestion threshold",
+			policy:   Policy{MinCongestion: 2},
+			digest:   busy,
+			expected: decision{send: true},
+		},
+		{
+			name:     "weekend overrides skip empty",
+			policy:   Policy{SkipEmpty: true, AlwaysOnWeekends: true},
+			digest:   emptyWeekend,
+			expected: decision{send: true},
+		},
+		{
+			name:     "weekend override does not apply on weekdays",
+			policy:   Policy{SkipEmpty: true, AlwaysOnWeekends: true},
+			digest:   empty,
+			expected: decision{},
+		},
+		{
+			name:     "mention here for large venue",
+			policy:   Policy{MentionHereForLargeVenues: true},
+			digest:   busy,
+			expected: decision{send: true, mentions: []notification.Mention{notification.HereMention()}},
+		},
+		{
+			name:     "no mention without large venue",
+			policy:   Policy{MentionHereForLargeVenues: true},
+			digest:   quiet,
+			expected: decision{send: true},
+		},
+		{
+			name:     "no mention when suppressed",
+			policy:   Policy{MinCongestion: 3, MentionHereForLargeVenues: true},
+			digest:   busy,
+			expected: decision{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.policy.decide(tt.digest))
+		})
+	}
+}
+
+func TestPolicy_Decide_VenueMentions(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	wednesday := time.Date(2026, 4, 8, 0, 0, 0, 0, jst)
+	saturday := time.Date(2026, 4, 11, 0, 0, 0, 0, jst)
+	role := notification.RoleMention("111")
+
+	policy := Policy{
+		VenueMentions: []VenueMention{
+			{Venue: event.VenueIDNissanStadium, Mention: role, WeekdaysOnly: true},
+		},
+	}
+
+	t.Run("weekday stadium event pings role", func(t *testing.T) {
+		venues := event.NewAllVenues()
+		venues[1].Events = []event.Event{{Title: "試合", Date: wednesday}}
+
+		dec := policy.decide(newDigest(venues, wednesday, wednesday))
+
+		assert.Equal(t, []notification.Mention{role}, dec.mentions)
+	})
+
+	t.Run("weekend stadium event does not ping role", func(t *testing.T) {
+		venues := event.NewAllVenues()
+		venues[1].Events = []event.Event{{Title: "試合", Date: saturday}}
+
+		dec := policy.decide(newDigest(venues, saturday, saturday))
+
+		assert.True(t, dec.send)
+		assert.Empty(t, dec.mentions)
+	})
+
+	t.Run("other venue does not ping role", func(t *testing.T) {
+		venues := event.NewAllVenues()
+		venues[0].Events = []event.Event{{Title: "ライブ", Date: wednesday}}
+
+		dec := policy.decide(newDigest(venues, wednesday, wednesday))
+
+		assert.Empty(t, dec.mentions)
+	})
+}
+
+func TestNewDigest(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	day := time.Date(2026, 4, 6, 0, 0, 0, 0, jst)
+
+	venues := event.NewAllVenues()
+	venues[1].Events = []event.Event{{Title: "A", Date: day}, {Title: "B", Date: day}}
+	venues[2].Events = []event.Event{{Title: "C", Date: day}}
+
+	d := newDigest(venues, day, day)
+
+	assert.Equal(t, 3, d.totalEvents)
+	assert.Equal(t, 2, d.venuesWithEvents)
+	assert.True(t, d.largeVenueActive)
+}
+
+func TestDigest_IncludesWeekend(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	monday := time.Date(2026, 4, 6, 0, 0, 0, 0, jst)
+
+	assert.False(t, digest{from: monday, to: monday.AddDate(0, 0, 4)}.includesWeekend())
+	assert.True(t, digest{from: monday, to: monday.AddDate(0, 0, 6)}.includesWeekend())
+}
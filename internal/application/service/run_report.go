@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// Run kinds recorded in run reports.
+const (
+	runKindDaily    = "daily"
+	runKindTomorrow = "tomorrow"
+	runKindWeekly   = "weekly"
+	runKindMonthly  = "monthly"
+	runKindOnDemand = "on_demand"
+)
+
+// maxFieldValueLength is Discord's limit for an embed field value.
+const maxFieldValueLength = 1024
+
+// withRun tags the logger of ctx with a new run id and the run kind, so every line logged
+// during the run, including by the fetchers, can be found together.
+func withRun(ctx context.Context, kind string) context.Context {
+	ctx = logging.WithRunID(ctx, logging.NewRunID())
+	return logging.WithMode(ctx, kind)
+}
+
+// fetchAllEvents fetches the events into venues and logs the diagnostics of every fetcher
+// as a single run report, which is returned even when fetching failed. Layout changes that
+// the fallback covered for are still reported to the maintainer on scheduled runs.
+func (s *EventNotificationService) fetchAllEvents(ctx context.Context, kind string, venues []*event.Venue, from, to time.Time) (*diagnostics.RunReport, error) {
+	ctx, span := startRunSpan(ctx, "EventNotificationService.fetchAllEvents", kind, from, to)
+	run := &diagnostics.RunReport{
+		RunID:     logging.RunIDFromContext(ctx),
+		StartedAt: s.clock.Now(),
+		Kind:      kind,
+		From:      from.Format("2006-01-02"),
+		To:        to.Format("2006-01-02"),
+	}
+
+	start := time.Now()
+	reports, recovered, err := fetchVenueEvents(ctx, s.eventFetchers, venues, from, to, s.fallback)
+	run.Fetchers = reports
+	run.DurationMS = time.Since(start).Milliseconds()
+	endSpan(span, err)
+
+	logRunReport(ctx, run)
+	s.recordFetchMetrics(ctx, run)
+	if kind != runKindOnDemand {
+		s.alertLayoutChanges(ctx, recovered)
+	}
+	if err == nil {
+		s.archiveEvents(ctx, venues)
+	}
+	return run, err
+}
+
+// archiveEvents adds the fetched events to the archive. Events shown from a fallback
+// snapshot were archived when they were fetched. The archive is secondary to the
+// notification, so a failure is only logged.
+func (s *EventNotificationService) archiveEvents(ctx context.Context, venues []*event.Venue) {
+	if s.archive == nil {
+		return
+	}
+	now := s.clock.Now()
+	for _, venue := range venues {
+		if !venue.StaleAsOf.IsZero() || len(venue.Events) == 0 {
+			continue
+		}
+		if err := s.archive.Put(ctx, venue.ID, venue.Events, now); err != nil {
+			logging.FromContext(ctx).Warn("failed to archive events", logging.KeyVenue, string(venue.ID), logging.KeyError, err)
+		}
+	}
+}
+
+func logRunReport(ctx context.Context, run *diagnostics.RunReport) {
+	body, err := json.Marshal(run)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to encode run report", logging.KeyError, err)
+		return
+	}
+
+	level := slog.LevelInfo
+	if run.HasProblems() {
+		level = slog.LevelWarn
+	}
+	logging.FromContext(ctx).Log(ctx, level, "fetch run report", "report", json.RawMessage(body))
+}
+
+// postRunReport sends the run report to the ops channel, if any. The report is secondary
+// to the notification itself, so a failure to send it is only logged.
+func (s *EventNotificationService) postRunReport(ctx context.Context, run *diagnostics.RunReport) {
+	if s.runReports == nil || (s.runReportsProblemsOnly && !run.HasProblems()) {
+		return
+	}
+	if err := s.runReports.Send(ctx, buildRunReportNotification(run)); err != nil {
+		logging.FromContext(ctx).Warn("failed to send run report", logging.KeyError, err)
+	}
+}
+
+func buildRunReportNotification(run *diagnostics.RunReport) *notification.Notification {
+	color := notification.ColorGreen
+	if run.HasProblems() {
+		color = notification.ColorYellow
+	}
+	for _, f := range run.Fetchers {
+		if f.Error != "" {
+			color = notification.ColorRed
+		}
+	}
+
+	period := run.From
+	if run.To != run.From {
+		period += "〜" + run.To
+	}
+	description := fmt.Sprintf("%s (%s) / %s", run.Kind, period, formatDurationMS(run.DurationMS))
+	if run.RunID != "" {
+		// Lets the maintainer find the log lines of the run.
+		description += " / run_id " + run.RunID
+	}
+	n := notification.NewNotification("📋 取得レポート", description, color)
+
+	for _, f := range run.Fetchers {
+		n.AddField(venueDisplayName(f.Venue), truncateFieldValue(formatFetcherReport(f)), false)
+	}
+	return n
+}
+
+func formatFetcherReport(r *diagnostics.Report) string {
+	var bytes int64
+	var failed []diagnostics.Request
+	for _, req := range r.Requests {
+		bytes += req.Bytes
+		if req.Failed() {
+			failed = append(failed, req)
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "イベント %d件 / リクエスト %d件 / %.1fKB / %s",
+		r.Events, len(r.Requests), float64(bytes)/1024, formatDurationMS(r.DurationMS))
+	if r.Error != "" {
+		sb.WriteString("\nエラー: " + r.Error)
+	}
+	if !r.FallbackFetchedAt.IsZero() {
+		sb.WriteString("\n前回取得データで代替: " + r.FallbackFetchedAt.In(event.JST).Format("2006-01-02 15:04"))
+	}
+	for _, req := range failed {
+		if req.Error != "" {
+			fmt.Fprintf(&sb, "\n失敗: %s (%s)", req.URL, req.Error)
+		} else {
+			fmt.Fprintf(&sb, "\n失敗: %s (HTTP %d)", req.URL, req.Status)
+		}
+	}
+	for _, w := range r.Warnings {
+		sb.WriteString("\n警告: " + w)
+	}
+	if len(r.Skipped) > 0 {
+		fmt.Fprintf(&sb, "\nスキップ: %d件", len(r.Skipped))
+	}
+	return sb.String()
+}
+
+func formatDurationMS(ms int64) string {
+	return fmt.Sprintf("%.1f秒", float64(ms)/1000)
+}
+
+func truncateFieldValue(s string) string {
+	if utf8.RuneCountInString(s) <= maxFieldValueLength {
+		return s
+	}
+	return string([]rune(s)[:maxFieldValueLength-1]) + "…"
+}
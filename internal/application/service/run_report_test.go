@@ -0,0 +1,193 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+func setupRunReportService(t *testing.T, problemsOnly bool) (*mock_ports.MockNotificationSender, *mock_ports.MockNotificationSender, *mock_ports.MockEventFetcher, *EventNotificationService) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockOps := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher},
+		WithRunReports(mockOps, problemsOnly),
+	)
+	return mockSender, mockOps, mockFetcher, service
+}
+
+func TestNotifyTodayEvents_PostsRunReport(t *testing.T) {
+	mockSender, mockOps, mockFetcher, service := setupRunReportService(t, false)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _, _ time.Time) ([]event.Event, error) {
+		report := diagnostics.FromContext(ctx)
+		report.RecordRequest(diagnostics.Request{URL: "https://example.com/event", Status: 200, Bytes: 2048})
+		report.Skip("no detail page", "")
+		return []event.Event{{Title: "Live"}}, nil
+	})
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	var sent *notification.Notification
+	mockOps.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		sent = n
+		return nil
+	})
+
+	require.NoError(t, service.NotifyTodayEvents(context.Background()))
+
+	require.NotNil(t, sent)
+	assert.Equal(t, "📋 取得レポート", sent.Title())
+	assert.True(t, strings.HasPrefix(sent.Description(), "daily ("), sent.Description())
+	assert.Contains(t, sent.Description(), "/ run_id ")
+	assert.Equal(t, notification.ColorGreen, sent.Color())
+	require.Len(t, sent.Fields(), 1)
+	assert.Equal(t, "横浜アリーナ", sent.Fields()[0].Name)
+	assert.Contains(t, sent.Fields()[0].Value, "イベント 1件 / リクエスト 1件 / 2.0KB")
+	assert.Contains(t, sent.Fields()[0].Value, "スキップ: 1件")
+}
+
+func TestNotifyTodayEvents_RunReportProblemsOnly(t *testing.T) {
+	mockSender, mockOps, mockFetcher, service := setupRunReportService(t, true)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{}, nil)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+	mockOps.EXPECT().Send(gomock.Any(), gomock.Any()).Times(0)
+
+	require.NoError(t, service.NotifyTodayEvents(context.Background()))
+}
+
+func TestNotifyTodayEvents_RunReportOnFetchError(t *testing.T) {
+	mockSender, mockOps, mockFetcher, service := setupRunReportService(t, true)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _, _ time.Time) ([]event.Event, error) {
+		diagnostics.FromContext(ctx).RecordRequest(diagnostics.Request{URL: "https://example.com/event", Status: 503})
+		return nil, errors.New("unexpected status code: 503")
+	})
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	var sent *notification.Notification
+	mockOps.EXPECT().Send(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, n *notification.Notification) error {
+		sent = n
+		return errors.New("ops channel down")
+	})
+
+	err := service.NotifyTodayEvents(context.Background())
+
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "ops channel down")
+	require.NotNil(t, sent)
+	assert.Equal(t, notification.ColorRed, sent.Color())
+	assert.Contains(t, sent.Fields()[0].Value, "エラー: unexpected status code: 503")
+	assert.Contains(t, sent.Fields()[0].Value, "失敗: https://example.com/event (HTTP 503)")
+}
+
+func TestBuildRunReportNotification_Warnings(t *testing.T) {
+	report := diagnostics.NewReport(event.VenueIDNissanStadium)
+	report.Warnf("ライブ: unparseable start time %q", "未定")
+	report.Finish(1, 1500*time.Millisecond, nil)
+
+	n := buildRunReportNotification(&diagnostics.RunReport{
+		Kind:       "weekly",
+		From:       "2026-10-19",
+		To:         "2026-10-25",
+		Fetchers:   []*diagnostics.Report{report},
+		DurationMS: 1500,
+	})
+
+	assert.Equal(t, "weekly (2026-10-19〜2026-10-25) / 1.5秒", n.Description())
+	assert.Equal(t, notification.ColorYellow, n.Color())
+	require.Len(t, n.Fields(), 1)
+	assert.Equal(t, "日産スタジアム", n.Fields()[0].Name)
+	assert.Contains(t, n.Fields()[0].Value, `警告: ライブ: unparseable start time "未定"`)
+}
+
+func TestNotifyTodayEvents_LogsAreCorrelated(t *testing.T) {
+	mockSender, mockFetcher, service, ctx := setupSingleFetcherService(t)
+	var buf bytes.Buffer
+	ctx = logging.NewContext(ctx, slog.New(logging.NewHandler(&buf, logging.FormatJSON, slog.LevelInfo)))
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _, _ time.Time) ([]event.Event, error) {
+		logging.FromContext(ctx).Info("fetched")
+		return nil, nil
+	})
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, service.NotifyTodayEvents(ctx))
+
+	var lines []map[string]any
+	for _, raw := range bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n")) {
+		var line map[string]any
+		require.NoError(t, json.Unmarshal(raw, &line))
+		lines = append(lines, line)
+	}
+	require.Len(t, lines, 2)
+	fetched, report := lines[0], lines[1]
+	assert.Equal(t, "fetched", fetched["msg"])
+	assert.NotEmpty(t, fetched[logging.KeyRunID])
+	assert.Equal(t, runKindDaily, fetched[logging.KeyMode])
+	assert.Equal(t, "yokohama_arena", fetched[logging.KeyVenue])
+	assert.Equal(t, "fetch run report", report["msg"])
+	assert.Equal(t, fetched[logging.KeyRunID], report[logging.KeyRunID])
+	assert.NotContains(t, report, logging.KeyVenue)
+}
+
+func TestFetchVenueEvents_TagsEachVenueSeparately(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	var fetchers []ports.EventFetcher
+	for _, id := range []event.VenueID{event.VenueIDYokohamaArena, event.VenueIDNissanStadium, event.VenueIDSkateCenter} {
+		f := mock_ports.NewMockEventFetcher(ctrl)
+		f.EXPECT().VenueID().Return(id).AnyTimes()
+		f.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _, _ time.Time) ([]event.Event, error) {
+			logging.FromContext(ctx).Info(string(id))
+			return nil, nil
+		})
+		fetchers = append(fetchers, f)
+	}
+	var buf bytes.Buffer
+	ctx := logging.NewContext(context.Background(), slog.New(logging.NewHandler(&buf, logging.FormatJSON, slog.LevelInfo)))
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+
+	_, _, err := fetchVenueEvents(ctx, fetchers, event.NewAllVenues(), date, date, nil)
+
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	for _, line := range lines {
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		assert.Equal(t, entry["msg"], entry[logging.KeyVenue])
+		assert.Equal(t, 1, strings.Count(line, `"`+logging.KeyVenue+`":`), line)
+	}
+}
+
+func TestFetchVenueEvents_FinishesReportOfSkippedVenue(t *testing.T) {
+	date := time.Date(2027, 1, 10, 0, 0, 0, 0, event.JST)
+	stadium := newHorizonFetcher(t, time.Date(2026, 11, 30, 0, 0, 0, 0, event.JST))
+
+	reports, _, err := fetchVenueEvents(context.Background(), []ports.EventFetcher{stadium}, event.NewAllVenues(), date, date, nil)
+
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, []diagnostics.Skip{{Reason: "range outside the days the venue lists", Detail: "2027-01-10 to 2027-01-10"}}, reports[0].Skipped)
+	assert.Empty(t, reports[0].Error)
+	assert.False(t, reports[0].HasProblems())
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// StatsService summarises the event archive, e.g. how many weekday events a venue had
+// this year.
+type StatsService struct {
+	archive ports.EventArchive
+}
+
+// EventStats summarises the archived events dated from From to To. Per-venue rows follow
+// the order of event.NewAllVenues.
+type EventStats struct {
+	From       time.Time
+	To         time.Time
+	Venues     []VenueTotal
+	Monthly    []MonthlyCount
+	Weekdays   []WeekdayCount
+	StartTimes []StartTimeStat
+	// MultiVenueDays are the days on which two or more venues had events.
+	MultiVenueDays []MultiVenueDay
+	TotalEvents    int
+}
+
+// VenueTotal is the number of events of a venue, and how many of them fell on a weekday
+// (Monday to Friday), when they affect commuting.
+type VenueTotal struct {
+	Venue         event.VenueID
+	Events        int
+	WeekdayEvents int
+}
+
+type MonthlyCount struct {
+	Venue event.VenueID
+	// Month is formatted as 2006-01.
+	Month  string
+	Events int
+}
+
+// WeekdayCount is the number of events of a venue on a weekday. Each venue's weekdays are
+// ordered busiest first.
+type WeekdayCount struct {
+	Venue   event.VenueID
+	Weekday time.Weekday
+	Events  int
+}
+
+// StartTimeStat is the average start time of a venue's events, as time since midnight in
+// JST. Events without a published start time are not counted.
+type StartTimeStat struct {
+	Venue   event.VenueID
+	Average time.Duration
+	Events  int
+}
+
+type MultiVenueDay struct {
+	Date   time.Time
+	Venues []event.VenueID
+}
+
+func NewStatsService(archive ports.EventArchive) *StatsService {
+	return &StatsService{archive: archive}
+}
+
+// Stats summarises the archived events from from to to (inclusive). When venueIDs is
+// empty, every venue is included.
+func (s *StatsService) Stats(ctx context.Context, from, to time.Time, venueIDs ...event.VenueID) (*EventStats, error) {
+	archived, err := s.archive.List(ctx, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived events: %w", err)
+	}
+
+	byVenue := make(map[event.VenueID][]event.Event)
+	for _, a := range archived {
+		if len(venueIDs) == 0 || slices.Contains(venueIDs, a.Venue) {
+			byVenue[a.Venue] = append(byVenue[a.Venue], a.Event)
+		}
+	}
+
+	stats := &EventStats{From: from, To: to}
+	venuesByDate := make(map[time.Time][]event.VenueID)
+	for _, venue := range event.NewAllVenues() {
+		events := byVenue[venue.ID]
+		if len(events) == 0 {
+			continue
+		}
+		stats.TotalEvents += len(events)
+		stats.Venues = append(stats.Venues, venueTotal(venue.ID, events))
+		stats.Monthly = append(stats.Monthly, monthlyCounts(venue.ID, events)...)
+		stats.Weekdays = append(stats.Weekdays, weekdayCounts(venue.ID, events)...)
+		if st, ok := averageStartTime(venue.ID, events); ok {
+			stats.StartTimes = append(stats.StartTimes, st)
+		}
+
+		seen := make(map[time.Time]bool)
+		for _, e := range events {
+			date := startOfDayIn(e.Date, event.JST)
+			if !seen[date] {
+				seen[date] = true
+				venuesByDate[date] = append(venuesByDate[date], venue.ID)
+			}
+		}
+	}
+
+	for date, venues := range venuesByDate {
+		if len(venues) >= 2 {
+			stats.MultiVenueDays = append(stats.MultiVenueDays, MultiVenueDay{Date: date, Venues: venues})
+		}
+	}
+	slices.SortFunc(stats.MultiVenueDays, func(a, b MultiVenueDay) int { return a.Date.Compare(b.Date) })
+
+	return stats, nil
+}
+
+func venueTotal(venue event.VenueID, events []event.Event) VenueTotal {
+	total := VenueTotal{Venue: venue, Events: len(events)}
+	for _, e := range events {
+		if wd := e.Date.In(event.JST).Weekday(); wd != time.Saturday && wd != time.Sunday {
+			total.WeekdayEvents++
+		}
+	}
+	return total
+}
+
+func monthlyCounts(venue event.VenueID, events []event.Event) []MonthlyCount {
+	counts := make(map[string]int)
+	for _, e := range events {
+		counts[e.Date.In(event.JST).Format("2006-01")]++
+	}
+
+	var months []MonthlyCount
+	for month, n := range counts {
+		months = append(months, MonthlyCount{Venue: venue, Month: month, Events: n})
+	}
+	slices.SortFunc(months, func(a, b MonthlyCount) int { return cmp.Compare(a.Month, b.Month) })
+	return months
+}
+
+func weekdayCounts(venue event.VenueID, events []event.Event) []WeekdayCount {
+	var counts [7]int
+	for _, e := range events {
+		counts[e.Date.In(event.JST).Weekday()]++
+	}
+
+	var weekdays []WeekdayCount
+	for wd, n := range counts {
+		if n > 0 {
+			weekdays = append(weekdays, WeekdayCount{Venue: venue, Weekday: time.Weekday(wd), Events: n})
+		}
+	}
+	slices.SortStableFunc(weekdays, func(a, b WeekdayCount) int { return cmp.Compare(b.Events, a.Events) })
+	return weekdays
+}
+
+func averageStartTime(venue event.VenueID, events []event.Event) (StartTimeStat, bool) {
+	var total time.Duration
+	var n int
+	for _, e := range events {
+		start := firstStartTime(e)
+		if start == nil {
+			continue
+		}
+		t := start.In(event.JST)
+		total += t.Sub(startOfDayIn(t, event.JST))
+		n++
+	}
+	if n == 0 {
+		return StartTimeStat{}, false
+	}
+	return StartTimeStat{Venue: venue, Average: (total / time.Duration(n)).Truncate(time.Minute), Events: n}, true
+}
+
+func startOfDayIn(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+)
+
+func archived(venue event.VenueID, day int, title string, startHour, startMinute int) ports.ArchivedEvent {
+	e := event.Event{Date: time.Date(2026, 10, day, 0, 0, 0, 0, event.JST), Title: title}
+	if startHour >= 0 {
+		start := time.Date(2026, 10, day, startHour, startMinute, 0, 0, event.JST)
+		e.Schedules = []event.Schedule{{StartTime: &start}}
+	}
+	return ports.ArchivedEvent{Venue: venue, Event: e}
+}
+
+func TestStatsService_Stats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	archive := mock_ports.NewMockEventArchive(ctrl)
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, event.JST)
+	to := time.Date(2026, 10, 31, 0, 0, 0, 0, event.JST)
+
+	// 2026-10-17 and 24 are Saturdays and 2026-10-19 is a Monday.
+	archive.EXPECT().List(gomock.Any(), from, to).Return([]ports.ArchivedEvent{
+		archived(event.VenueIDNissanStadium, 17, "Match", 14, 0),
+		archived(event.VenueIDNissanStadium, 19, "Concert", 18, 30),
+		archived(event.VenueIDNissanStadium, 24, "Match", -1, 0),
+		archived(event.VenueIDYokohamaArena, 17, "Live", 18, 0),
+		archived(event.VenueIDYokohamaArena, 17, "Live (matinee)", 13, 0),
+	}, nil)
+
+	stats, err := NewStatsService(archive).Stats(context.Background(), from, to)
+
+	require.NoError(t, err)
+	assert.Equal(t, 5, stats.TotalEvents)
+	assert.Equal(t, []VenueTotal{
+		{Venue: event.VenueIDYokohamaArena, Events: 2, WeekdayEvents: 0},
+		{Venue: event.VenueIDNissanStadium, Events: 3, WeekdayEvents: 1},
+	}, stats.Venues)
+	assert.Equal(t, []MonthlyCount{
+		{Venue: event.VenueIDYokohamaArena, Month: "2026-10", Events: 2},
+		{Venue: event.VenueIDNissanStadium, Month: "2026-10", Events: 3},
+	}, stats.Monthly)
+	assert.Equal(t, []WeekdayCount{
+		{Venue: event.VenueIDYokohamaArena, Weekday: time.Saturday, Events: 2},
+		{Venue: event.VenueIDNissanStadium, Weekday: time.Saturday, Events: 2},
+		{Venue: event.VenueIDNissanStadium, Weekday: time.Monday, Events: 1},
+	}, stats.Weekdays)
+	assert.Equal(t, []StartTimeStat{
+		{Venue: event.VenueIDYokohamaArena, Average: 15*time.Hour + 30*time.Minute, Events: 2},
+		{Venue: event.VenueIDNissanStadium, Average: 16*time.Hour + 15*time.Minute, Events: 2},
+	}, stats.StartTimes)
+	require.Len(t, stats.MultiVenueDays, 1)
+	assert.True(t, time.Date(2026, 10, 17, 0, 0, 0, 0, event.JST).Equal(stats.MultiVenueDays[0].Date))
+	assert.Equal(t, []event.VenueID{event.VenueIDYokohamaArena, event.VenueIDNissanStadium}, stats.MultiVenueDays[0].Venues)
+}
+
+func TestStatsService_Stats_VenueFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	archive := mock_ports.NewMockEventArchive(ctrl)
+	archive.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return([]ports.ArchivedEvent{
+		archived(event.VenueIDNissanStadium, 17, "Match", 14, 0),
+		archived(event.VenueIDYokohamaArena, 17, "Live", 18, 0),
+	}, nil)
+
+	stats, err := NewStatsService(archive).Stats(context.Background(), time.Time{}, time.Time{}, event.VenueIDNissanStadium)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.TotalEvents)
+	assert.Empty(t, stats.MultiVenueDays)
+}
+
+func TestStatsService_Stats_ArchiveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	archive := mock_ports.NewMockEventArchive(ctrl)
+	archive.EXPECT().List(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("table not found"))
+
+	_, err := NewStatsService(archive).Stats(context.Background(), time.Time{}, time.Time{})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "table not found")
+}
+
+func TestNotifyTodayEvents_ArchivesFetchedEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	archive := mock_ports.NewMockEventArchive(ctrl)
+	now := time.Date(2026, 10, 18, 7, 0, 0, 0, event.JST)
+	service := NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher},
+		WithArchive(archive),
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+	)
+
+	events := []event.Event{{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST), Title: "Live"}}
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(events, nil)
+	archive.EXPECT().Put(gomock.Any(), event.VenueIDYokohamaArena, events, now).Return(errors.New("throttled"))
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, service.NotifyTodayEvents(context.Background()), "archive errors do not fail the notification")
+}
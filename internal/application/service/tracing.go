@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// tracer returns the package tracer from the current global provider.
+func tracer() trace.Tracer {
+	return otel.Tracer("github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service")
+}
+
+func startRunSpan(ctx context.Context, name, kind string, from, to time.Time) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(
+		attribute.String("run_id", logging.RunIDFromContext(ctx)),
+		attribute.String("mode", kind),
+		attribute.String("from", from.Format("2006-01-02")),
+		attribute.String("to", to.Format("2006-01-02")),
+	))
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
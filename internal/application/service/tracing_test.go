@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+)
+
+func setupSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	original := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(original) })
+	return recorder
+}
+
+func spansByName(recorder *tracetest.SpanRecorder) map[string][]sdktrace.ReadOnlySpan {
+	spans := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, s := range recorder.Ended() {
+		spans[s.Name()] = append(spans[s.Name()], s)
+	}
+	return spans
+}
+
+func TestNotifyWeeklyEvents_Spans(t *testing.T) {
+	recorder := setupSpanRecorder(t)
+	mockSender, mockFetcher1, mockFetcher2, mockFetcher3, service, ctx := setupThreeFetcherService(t)
+
+	for _, f := range []interface {
+		FetchEvents(ctx, from, to any) *gomock.Call
+	}{mockFetcher1.EXPECT(), mockFetcher2.EXPECT(), mockFetcher3.EXPECT()} {
+		f.FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, _, _ time.Time) ([]event.Event, error) {
+			assert.True(t, trace.SpanContextFromContext(ctx).IsValid(), "fetchers receive the span context")
+			return []event.Event{{Title: "Live"}}, nil
+		})
+	}
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, service.NotifyWeeklyEvents(ctx))
+
+	spans := spansByName(recorder)
+	require.Len(t, spans["EventNotificationService.NotifyWeeklyEvents"], 1)
+	require.Len(t, spans["EventNotificationService.fetchAllEvents"], 1)
+	require.Len(t, spans["EventFetcher.FetchEvents"], 3)
+	require.Len(t, spans["NotificationSender.Send"], 1)
+
+	root := spans["EventNotificationService.NotifyWeeklyEvents"][0]
+	fetchAll := spans["EventNotificationService.fetchAllEvents"][0]
+	assert.Equal(t, root.SpanContext().SpanID(), fetchAll.Parent().SpanID())
+	for _, s := range spans["EventFetcher.FetchEvents"] {
+		assert.Equal(t, fetchAll.SpanContext().SpanID(), s.Parent().SpanID())
+	}
+	assert.Equal(t, root.SpanContext().SpanID(), spans["NotificationSender.Send"][0].Parent().SpanID())
+}
+
+func TestNotifyTodayEvents_SpanRecordsFetchError(t *testing.T) {
+	recorder := setupSpanRecorder(t)
+	mockSender, mockFetcher, service, ctx := setupSingleFetcherService(t)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.Error(t, service.NotifyTodayEvents(ctx))
+
+	spans := spansByName(recorder)
+	require.Len(t, spans["EventFetcher.FetchEvents"], 1)
+	assert.Equal(t, codes.Error, spans["EventFetcher.FetchEvents"][0].Status().Code)
+	require.Len(t, spans["EventNotificationService.NotifyEventsForDate"], 1)
+	assert.Equal(t, codes.Error, spans["EventNotificationService.NotifyEventsForDate"][0].Status().Code)
+}
@@ -0,0 +1,153 @@
+package diagnostics
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+)
+
+// Request is one HTTP request made by a fetcher.
+type Request struct {
+	URL        string `json:"url"`
+	Error      string `json:"error,omitempty"`
+	Status     int    `json:"status,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+	Bytes      int64  `json:"bytes"`
+}
+
+// Failed reports whether the request got no response or an error status.
+func (r Request) Failed() bool {
+	return r.Error != "" || r.Status >= 400
+}
+
+// Skip is an entry a fetcher left out of its result, with the reason why.
+type Skip struct {
+	Reason string `json:"reason"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Report collects what a single fetcher did during one run. Its methods are safe for
+// concurrent use and do nothing on a nil Report, so fetchers can record unconditionally.
+type Report struct {
+	// FallbackFetchedAt is when the events used instead of a failed fetch were fetched.
+	FallbackFetchedAt time.Time     `json:"fallback_fetched_at,omitzero"`
+	Venue             event.VenueID `json:"venue"`
+	Error             string        `json:"error,omitempty"`
+	Requests          []Request     `json:"requests"`
+	Warnings          []string      `json:"warnings,omitempty"`
+	Skipped           []Skip        `json:"skipped,omitempty"`
+	Events            int           `json:"events"`
+	DurationMS        int64         `json:"duration_ms"`
+	mu                sync.Mutex
+}
+
+func NewReport(venue event.VenueID) *Report {
+	return &Report{Venue: venue, Requests: []Request{}}
+}
+
+func (r *Report) RecordRequest(req Request) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Requests = append(r.Requests, req)
+}
+
+// Warnf records data the fetcher could not fully interpret but worked around, such as
+// an unparseable start time.
+func (r *Report) Warnf(format string, args ...any) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (r *Report) Skip(reason, detail string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Skipped = append(r.Skipped, Skip{Reason: reason, Detail: detail})
+}
+
+// Finish records the outcome of the fetch.
+func (r *Report) Finish(events int, d time.Duration, err error) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Events = events
+	r.DurationMS = d.Milliseconds()
+	if err != nil {
+		r.Error = err.Error()
+	}
+}
+
+// UseFallback records that the failed fetch was replaced by events fetched at fetchedAt.
+func (r *Report) UseFallback(events int, fetchedAt time.Time) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.Events = events
+	r.FallbackFetchedAt = fetchedAt
+}
+
+// HasProblems reports whether the fetch failed, a request failed or data had to be
+// worked around. Skipped entries alone are expected and do not count.
+func (r *Report) HasProblems() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.Error != "" || len(r.Warnings) > 0 {
+		return true
+	}
+	for _, req := range r.Requests {
+		if req.Failed() {
+			return true
+		}
+	}
+	return false
+}
+
+// RunReport aggregates the reports of every fetcher used by one notification run.
+type RunReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	RunID      string    `json:"run_id"`
+	Kind       string    `json:"kind"`
+	From       string    `json:"from"`
+	To         string    `json:"to"`
+	Fetchers   []*Report `json:"fetchers"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+func (r *RunReport) HasProblems() bool {
+	for _, f := range r.Fetchers {
+		if f.HasProblems() {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// NewContext returns a context whose fetches are recorded in r.
+func NewContext(ctx context.Context, r *Report) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the report attached by NewContext, or nil.
+func FromContext(ctx context.Context) *Report {
+	r, _ := ctx.Value(contextKey{}).(*Report)
+	return r
+}
@@ -0,0 +1,91 @@
+package diagnostics
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+)
+
+func TestReport_NilIsNoop(t *testing.T) {
+	var r *Report
+
+	assert.NotPanics(t, func() {
+		r.RecordRequest(Request{URL: "https://example.com"})
+		r.Warnf("bad time %q", "25:00")
+		r.Skip("no detail page", "")
+		r.Finish(1, time.Second, nil)
+		r.UseFallback(1, time.Now())
+	})
+	assert.Nil(t, FromContext(context.Background()))
+}
+
+func TestReport_HasProblems(t *testing.T) {
+	testCases := []struct {
+		record func(r *Report)
+		name   string
+		want   bool
+	}{
+		{name: "clean", record: func(r *Report) { r.RecordRequest(Request{Status: 200}) }, want: false},
+		{name: "skips only", record: func(r *Report) { r.Skip("not for this venue", "") }, want: false},
+		{name: "warning", record: func(r *Report) { r.Warnf("bad time") }, want: true},
+		{name: "error status", record: func(r *Report) { r.RecordRequest(Request{Status: 503}) }, want: true},
+		{name: "transport error", record: func(r *Report) { r.RecordRequest(Request{Error: "timeout"}) }, want: true},
+		{name: "fetch error", record: func(r *Report) { r.Finish(0, 0, errors.New("boom")) }, want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := NewReport(event.VenueIDYokohamaArena)
+			tc.record(r)
+
+			assert.Equal(t, tc.want, r.HasProblems())
+			assert.Equal(t, tc.want, (&RunReport{Fetchers: []*Report{NewReport(event.VenueIDSkateCenter), r}}).HasProblems())
+		})
+	}
+}
+
+func TestReport_JSON(t *testing.T) {
+	r := NewReport(event.VenueIDNissanStadium)
+	ctx := NewContext(context.Background(), r)
+
+	FromContext(ctx).RecordRequest(Request{URL: "https://www.nissan-stadium.jp/calendar/", Status: 200, DurationMS: 120, Bytes: 2048})
+	FromContext(ctx).Warnf("failed to parse start time %q", "未定")
+	FromContext(ctx).Skip("not for Nissan Stadium", "https://www.nissan-stadium.jp/calendar/detail.php?id=1")
+	r.Finish(2, 1500*time.Millisecond, nil)
+
+	body, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"venue": "nissan_stadium",
+		"requests": [{"url": "https://www.nissan-stadium.jp/calendar/", "status": 200, "duration_ms": 120, "bytes": 2048}],
+		"warnings": ["failed to parse start time \"未定\""],
+		"skipped": [{"reason": "not for Nissan Stadium", "detail": "https://www.nissan-stadium.jp/calendar/detail.php?id=1"}],
+		"events": 2,
+		"duration_ms": 1500
+	}`, string(body))
+}
+
+func TestReport_UseFallback(t *testing.T) {
+	r := NewReport(event.VenueIDYokohamaArena)
+	r.Finish(0, time.Second, errors.New("timeout"))
+	r.UseFallback(3, time.Date(2026, 10, 17, 10, 0, 0, 0, time.UTC))
+
+	body, err := json.Marshal(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"fallback_fetched_at": "2026-10-17T10:00:00Z",
+		"venue": "yokohama_arena",
+		"error": "timeout",
+		"requests": [],
+		"events": 3,
+		"duration_ms": 1000
+	}`, string(body))
+	assert.True(t, r.HasProblems())
+}
@@ -0,0 +1,33 @@
+package event
+
+// CongestionLevel summarises how busy the area around the station is expected to be.
+type CongestionLevel string
+
+const (
+	CongestionLow      CongestionLevel = "low"
+	CongestionModerate CongestionLevel = "moderate"
+	CongestionHigh     CongestionLevel = "high"
+)
+
+// CongestionLevelFor grades congestion by the number of venues holding events.
+func CongestionLevelFor(venues []*Venue) CongestionLevel {
+	switch CountActiveVenues(venues) {
+	case 0:
+		return CongestionLow
+	case 1:
+		return CongestionModerate
+	default:
+		return CongestionHigh
+	}
+}
+
+// CountActiveVenues returns the number of venues with at least one event.
+func CountActiveVenues(venues []*Venue) int {
+	n := 0
+	for _, v := range venues {
+		if len(v.Events) > 0 {
+			n++
+		}
+	}
+	return n
+}
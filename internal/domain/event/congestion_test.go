@@ -0,0 +1,27 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCongestionLevelFor(t *testing.T) {
+	busy := func() *Venue { return &Venue{Events: []Event{{Title: "Live"}}} }
+	idle := func() *Venue { return &Venue{} }
+
+	assert.Equal(t, CongestionLow, CongestionLevelFor(nil))
+	assert.Equal(t, CongestionLow, CongestionLevelFor([]*Venue{idle(), idle()}))
+	assert.Equal(t, CongestionModerate, CongestionLevelFor([]*Venue{busy(), idle()}))
+	assert.Equal(t, CongestionHigh, CongestionLevelFor([]*Venue{busy(), busy(), idle()}))
+}
+
+func TestCountActiveVenues(t *testing.T) {
+	venues := []*Venue{
+		{Events: []Event{{Title: "A"}, {Title: "B"}}},
+		{},
+		{Events: []Event{{Title: "C"}}},
+	}
+
+	assert.Equal(t, 2, CountActiveVenues(venues))
+}
@@ -1,6 +1,12 @@
 package event
 
-import "time"
+import (
+	"strings"
+	"time"
+)
+
+// JST is the default location used to decide which calendar day an event belongs to.
+var JST = time.FixedZone("JST", 9*60*60)
 
 type Schedule struct {
 	StartTime *time.Time
@@ -12,3 +18,9 @@ type Event struct {
 	Title     string
 	Schedules []Schedule
 }
+
+// Key identifies the event across fetches by its date in JST and its title. Start times
+// are left out because venues often publish or change them later.
+func (e Event) Key() string {
+	return e.Date.In(JST).Format("2006-01-02") + "#" + strings.Join(strings.Fields(e.Title), " ")
+}
@@ -0,0 +1,17 @@
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvent_Key(t *testing.T) {
+	start := time.Date(2026, 10, 18, 18, 0, 0, 0, JST)
+	a := Event{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, JST), Title: "LIVE  TOUR\n2026"}
+	b := Event{Date: time.Date(2026, 10, 17, 15, 0, 0, 0, time.UTC), Title: "LIVE TOUR 2026", Schedules: []Schedule{{StartTime: &start}}}
+
+	assert.Equal(t, "2026-10-18#LIVE TOUR 2026", a.Key())
+	assert.Equal(t, a.Key(), b.Key(), "same date in JST and title regardless of whitespace or schedules")
+}
@@ -1,5 +1,7 @@
 package event
 
+import "time"
+
 type VenueID string
 
 const (
@@ -9,10 +11,21 @@ const (
 )
 
 type Venue struct {
-	ID          VenueID
-	DisplayName string
-	Emoji       string
-	Events      []Event
+	// StaleAsOf is when Events were fetched if they come from an earlier run because the
+	// venue's site could not be fetched. It is zero for current data.
+	StaleAsOf time.Time
+	// UnlistedBefore is the first day the venue still lists events for. It is set only when
+	// the requested range starts before that day.
+	UnlistedBefore time.Time
+	// UnpublishedFrom is the day from which the venue has not published events yet. It is
+	// set only when the requested range reaches that day.
+	UnpublishedFrom time.Time
+	ID              VenueID
+	DisplayName     string
+	Emoji           string
+	Events          []Event
+	// Large marks venues whose crowds noticeably affect commuting around the station.
+	Large bool
 }
 
 func NewAllVenues() []*Venue {
@@ -22,12 +35,14 @@ func NewAllVenues() []*Venue {
 			DisplayName: "横浜アリーナ",
 			Emoji:       "🏟️",
 			Events:      []Event{},
+			Large:       true,
 		},
 		{
 			ID:          VenueIDNissanStadium,
 			DisplayName: "日産スタジアム",
 			Emoji:       "⚽",
 			Events:      []Event{},
+			Large:       true,
 		},
 		{
 			ID:          VenueIDSkateCenter,
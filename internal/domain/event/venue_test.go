@@ -24,6 +24,7 @@ func TestNewAllVenues(t *testing.T) {
 		assert.Equal(t, "横浜アリーナ", venue.DisplayName)
 		assert.Equal(t, "🏟️", venue.Emoji)
 		assert.Empty(t, venue.Events)
+		assert.True(t, venue.Large)
 	})
 
 	t.Run("NissanStadium", func(t *testing.T) {
@@ -32,6 +33,7 @@ func TestNewAllVenues(t *testing.T) {
 		assert.Equal(t, "日産スタジアム", venue.DisplayName)
 		assert.Equal(t, "⚽", venue.Emoji)
 		assert.Empty(t, venue.Events)
+		assert.True(t, venue.Large)
 	})
 
 	t.Run("SkateCenter", func(t *testing.T) {
@@ -40,5 +42,6 @@ func TestNewAllVenues(t *testing.T) {
 		assert.Equal(t, "KOSÉ新横浜スケートセンター", venue.DisplayName)
 		assert.Equal(t, "⛸️", venue.Emoji)
 		assert.Empty(t, venue.Events)
+		assert.False(t, venue.Large)
 	})
 }
@@ -0,0 +1,29 @@
+package notification
+
+type MentionKind int
+
+const (
+	// MentionHere alerts everyone currently online in the channel.
+	MentionHere MentionKind = iota + 1
+	MentionRole
+	MentionUser
+)
+
+// Mention is a sender-agnostic request to alert people. ID identifies the role or user
+// in the destination's own namespace and is empty for MentionHere.
+type Mention struct {
+	ID   string
+	Kind MentionKind
+}
+
+func HereMention() Mention {
+	return Mention{Kind: MentionHere}
+}
+
+func RoleMention(id string) Mention {
+	return Mention{Kind: MentionRole, ID: id}
+}
+
+func UserMention(id string) Mention {
+	return Mention{Kind: MentionUser, ID: id}
+}
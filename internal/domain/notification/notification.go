@@ -8,7 +8,10 @@ type Notification struct {
 	timestamp   time.Time
 	title       string
 	description string
+	topic       string
+	editKey     string
 	fields      []Field
+	mentions    []Mention
 	color       Color
 }
 
@@ -45,8 +48,42 @@ func (n *Notification) AddField(name, value string, inline bool) {
 	})
 }
 
+// AddMention asks the sender to alert the given audience. Duplicate mentions are ignored.
+func (n *Notification) AddMention(m Mention) {
+	for _, existing := range n.mentions {
+		if existing == m {
+			return
+		}
+	}
+	n.mentions = append(n.mentions, m)
+}
+
+// SetTopic groups related notifications, e.g. one weekly digest per week.
+// Senders that support threads may use it as the thread name.
+func (n *Notification) SetTopic(topic string) {
+	n.topic = topic
+}
+
+// SetEditKey marks notifications that supersede each other, e.g. reruns of the daily
+// notification for the same date. Senders that remember posted messages may edit the
+// earlier message in place instead of posting a new one.
+func (n *Notification) SetEditKey(key string) {
+	n.editKey = key
+}
+
+// Clone returns a copy that can be adjusted per destination without affecting the original.
+func (n *Notification) Clone() *Notification {
+	c := *n
+	c.fields = append([]Field(nil), n.fields...)
+	c.mentions = append([]Mention(nil), n.mentions...)
+	return &c
+}
+
 func (n *Notification) Title() string        { return n.title }
 func (n *Notification) Description() string  { return n.description }
+func (n *Notification) Topic() string        { return n.topic }
+func (n *Notification) EditKey() string      { return n.editKey }
 func (n *Notification) Fields() []Field      { return n.fields }
 func (n *Notification) Color() Color         { return n.color }
 func (n *Notification) Timestamp() time.Time { return n.timestamp }
+func (n *Notification) Mentions() []Mention  { return n.mentions }
@@ -112,3 +112,56 @@ func TestField(t *testing.T) {
 	assert.Equal(t, "Test Value", field.Value)
 	assert.True(t, field.Inline)
 }
+
+func TestNotification_AddMention(t *testing.T) {
+	notif := NewNotification("Title", "Description", ColorGreen)
+
+	assert.Empty(t, notif.Mentions())
+
+	notif.AddMention(HereMention())
+	notif.AddMention(RoleMention("123"))
+	notif.AddMention(RoleMention("123"))
+	notif.AddMention(UserMention("456"))
+
+	assert.Equal(t, []Mention{
+		{Kind: MentionHere},
+		{Kind: MentionRole, ID: "123"},
+		{Kind: MentionUser, ID: "456"},
+	}, notif.Mentions())
+}
+
+func TestNotification_Clone(t *testing.T) {
+	notif := NewNotification("Title", "Description", ColorYellow)
+	notif.AddField("Field1", "Value1", false)
+
+	clone := notif.Clone()
+	clone.AddMention(HereMention())
+	clone.AddField("Field2", "Value2", false)
+
+	assert.Equal(t, notif.Title(), clone.Title())
+	assert.Equal(t, notif.Timestamp(), clone.Timestamp())
+	assert.Empty(t, notif.Mentions())
+	assert.Len(t, notif.Fields(), 1)
+	assert.Len(t, clone.Fields(), 2)
+}
+
+func TestNotification_SetTopic(t *testing.T) {
+	notif := NewNotification("Title", "Description", ColorGreen)
+
+	assert.Empty(t, notif.Topic())
+
+	notif.SetTopic("10/19週")
+
+	assert.Equal(t, "10/19週", notif.Topic())
+}
+
+func TestNotification_SetEditKey(t *testing.T) {
+	notif := NewNotification("Title", "Description", ColorGreen)
+	notif.SetEditKey("daily/2026-10-18")
+
+	clone := notif.Clone()
+	clone.SetEditKey("discord/daily/2026-10-18")
+
+	assert.Equal(t, "daily/2026-10-18", notif.EditKey())
+	assert.Equal(t, "discord/daily/2026-10-18", clone.EditKey())
+}
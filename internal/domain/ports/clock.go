@@ -0,0 +1,14 @@
+package ports
+
+import "time"
+
+type Clock interface {
+	Now() time.Time
+}
+
+// ClockFunc adapts a plain function such as time.Now to the Clock interface.
+type ClockFunc func() time.Time
+
+func (f ClockFunc) Now() time.Time {
+	return f()
+}
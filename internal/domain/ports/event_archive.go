@@ -0,0 +1,26 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+)
+
+// ArchivedEvent is an event as last fetched, with when it was first and last seen.
+type ArchivedEvent struct {
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+	Venue       event.VenueID
+	Event       event.Event
+}
+
+// EventArchive keeps every fetched event, keyed by venue and event.Event.Key, so that
+// fetching the same event again updates it instead of adding a duplicate.
+//
+//go:generate mockgen -source=event_archive.go -destination=mock_ports/mock_event_archive.go -package=mock_ports
+type EventArchive interface {
+	Put(ctx context.Context, venue event.VenueID, events []event.Event, seenAt time.Time) error
+	// List returns the archived events dated from from to to, inclusive.
+	List(ctx context.Context, from, to time.Time) ([]ArchivedEvent, error)
+}
@@ -12,3 +12,43 @@ type EventFetcher interface {
 	FetchEvents(ctx context.Context, from, to time.Time) ([]event.Event, error)
 	VenueID() event.VenueID
 }
+
+// Capabilities describes what a fetcher's source publishes, so requests can be clamped to
+// it up front and gaps in the output explained.
+type Capabilities struct {
+	// Earliest is the first day, at midnight, for which the source still lists events.
+	// Ranges starting before it are clamped to it instead of failing. Zero means no limit.
+	Earliest time.Time
+	// Horizon is the last day, at midnight, for which the source publishes events. Ranges
+	// reaching past it are clamped to it instead of failing. Zero means no known horizon.
+	Horizon time.Time
+	// MaxConcurrentRequests is how many requests the fetcher sends to the site at once,
+	// the rate limit it keeps to. Zero means one at a time.
+	MaxConcurrentRequests int
+	// OpenTime and EndTime report whether events carry door-opening and end times.
+	OpenTime bool
+	EndTime  bool
+	// FirstPageOnly means only the first page of upcoming events is read, so events
+	// further ahead may be missing.
+	FirstPageOnly bool
+}
+
+// CapabilityReporter is implemented by fetchers that describe their Capabilities.
+type CapabilityReporter interface {
+	Capabilities() Capabilities
+}
+
+// CapableEventFetcher is an EventFetcher that describes its Capabilities.
+type CapableEventFetcher interface {
+	EventFetcher
+	CapabilityReporter
+}
+
+// CapabilitiesOf returns the capabilities of f. A fetcher that does not describe them is
+// assumed to fetch any range and to provide start times only.
+func CapabilitiesOf(f EventFetcher) Capabilities {
+	if r, ok := f.(CapabilityReporter); ok {
+		return r.Capabilities()
+	}
+	return Capabilities{}
+}
@@ -0,0 +1,24 @@
+package ports
+
+import "context"
+
+// MetricUnit is a CloudWatch metric unit.
+type MetricUnit string
+
+const (
+	UnitCount        MetricUnit = "Count"
+	UnitMilliseconds MetricUnit = "Milliseconds"
+)
+
+// Metric is a single measurement. Dimensions such as the venue or run mode break it down.
+type Metric struct {
+	Dimensions map[string]string
+	Name       string
+	Unit       MetricUnit
+	Value      float64
+}
+
+//go:generate mockgen -source=metrics.go -destination=mock_ports/mock_metrics.go -package=mock_ports
+type Metrics interface {
+	Record(ctx context.Context, metrics ...Metric)
+}
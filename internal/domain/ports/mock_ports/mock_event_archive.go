@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event_archive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=event_archive.go -destination=mock_ports/mock_event_archive.go -package=mock_ports
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	event "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	ports "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventArchive is a mock of EventArchive interface.
+type MockEventArchive struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventArchiveMockRecorder
+	isgomock struct{}
+}
+
+// MockEventArchiveMockRecorder is the mock recorder for MockEventArchive.
+type MockEventArchiveMockRecorder struct {
+	mock *MockEventArchive
+}
+
+// NewMockEventArchive creates a new mock instance.
+func NewMockEventArchive(ctrl *gomock.Controller) *MockEventArchive {
+	mock := &MockEventArchive{ctrl: ctrl}
+	mock.recorder = &MockEventArchiveMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventArchive) EXPECT() *MockEventArchiveMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockEventArchive) List(ctx context.Context, from, to time.Time) ([]ports.ArchivedEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, from, to)
+	ret0, _ := ret[0].([]ports.ArchivedEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockEventArchiveMockRecorder) List(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEventArchive)(nil).List), ctx, from, to)
+}
+
+// Put mocks base method.
+func (m *MockEventArchive) Put(ctx context.Context, venue event.VenueID, events []event.Event, seenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Put", ctx, venue, events, seenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Put indicates an expected call of Put.
+func (mr *MockEventArchiveMockRecorder) Put(ctx, venue, events, seenAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Put", reflect.TypeOf((*MockEventArchive)(nil).Put), ctx, venue, events, seenAt)
+}
@@ -15,6 +15,7 @@ import (
 	time "time"
 
 	event "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	ports "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -70,3 +71,108 @@ func (mr *MockEventFetcherMockRecorder) VenueID() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VenueID", reflect.TypeOf((*MockEventFetcher)(nil).VenueID))
 }
+
+// MockCapabilityReporter is a mock of CapabilityReporter interface.
+type MockCapabilityReporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockCapabilityReporterMockRecorder
+	isgomock struct{}
+}
+
+// MockCapabilityReporterMockRecorder is the mock recorder for MockCapabilityReporter.
+type MockCapabilityReporterMockRecorder struct {
+	mock *MockCapabilityReporter
+}
+
+// NewMockCapabilityReporter creates a new mock instance.
+func NewMockCapabilityReporter(ctrl *gomock.Controller) *MockCapabilityReporter {
+	mock := &MockCapabilityReporter{ctrl: ctrl}
+	mock.recorder = &MockCapabilityReporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCapabilityReporter) EXPECT() *MockCapabilityReporterMockRecorder {
+	return m.recorder
+}
+
+// Capabilities mocks base method.
+func (m *MockCapabilityReporter) Capabilities() ports.Capabilities {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capabilities")
+	ret0, _ := ret[0].(ports.Capabilities)
+	return ret0
+}
+
+// Capabilities indicates an expected call of Capabilities.
+func (mr *MockCapabilityReporterMockRecorder) Capabilities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capabilities", reflect.TypeOf((*MockCapabilityReporter)(nil).Capabilities))
+}
+
+// MockCapableEventFetcher is a mock of CapableEventFetcher interface.
+type MockCapableEventFetcher struct {
+	ctrl     *gomock.Controller
+	recorder *MockCapableEventFetcherMockRecorder
+	isgomock struct{}
+}
+
+// MockCapableEventFetcherMockRecorder is the mock recorder for MockCapableEventFetcher.
+type MockCapableEventFetcherMockRecorder struct {
+	mock *MockCapableEventFetcher
+}
+
+// NewMockCapableEventFetcher creates a new mock instance.
+func NewMockCapableEventFetcher(ctrl *gomock.Controller) *MockCapableEventFetcher {
+	mock := &MockCapableEventFetcher{ctrl: ctrl}
+	mock.recorder = &MockCapableEventFetcherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCapableEventFetcher) EXPECT() *MockCapableEventFetcherMockRecorder {
+	return m.recorder
+}
+
+// Capabilities mocks base method.
+func (m *MockCapableEventFetcher) Capabilities() ports.Capabilities {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Capabilities")
+	ret0, _ := ret[0].(ports.Capabilities)
+	return ret0
+}
+
+// Capabilities indicates an expected call of Capabilities.
+func (mr *MockCapableEventFetcherMockRecorder) Capabilities() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Capabilities", reflect.TypeOf((*MockCapableEventFetcher)(nil).Capabilities))
+}
+
+// FetchEvents mocks base method.
+func (m *MockCapableEventFetcher) FetchEvents(ctx context.Context, from, to time.Time) ([]event.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchEvents", ctx, from, to)
+	ret0, _ := ret[0].([]event.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchEvents indicates an expected call of FetchEvents.
+func (mr *MockCapableEventFetcherMockRecorder) FetchEvents(ctx, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchEvents", reflect.TypeOf((*MockCapableEventFetcher)(nil).FetchEvents), ctx, from, to)
+}
+
+// VenueID mocks base method.
+func (m *MockCapableEventFetcher) VenueID() event.VenueID {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VenueID")
+	ret0, _ := ret[0].(event.VenueID)
+	return ret0
+}
+
+// VenueID indicates an expected call of VenueID.
+func (mr *MockCapableEventFetcherMockRecorder) VenueID() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VenueID", reflect.TypeOf((*MockCapableEventFetcher)(nil).VenueID))
+}
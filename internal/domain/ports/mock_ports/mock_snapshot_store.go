@@ -0,0 +1,73 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: snapshot_store.go
+//
+// Generated by this command:
+//
+//	mockgen -source=snapshot_store.go -destination=mock_ports/mock_snapshot_store.go -package=mock_ports
+//
+
+// Package mock_ports is a generated GoMock package.
+package mock_ports
+
+import (
+	context "context"
+	reflect "reflect"
+
+	event "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	ports "github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSnapshotStore is a mock of SnapshotStore interface.
+type MockSnapshotStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockSnapshotStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockSnapshotStoreMockRecorder is the mock recorder for MockSnapshotStore.
+type MockSnapshotStoreMockRecorder struct {
+	mock *MockSnapshotStore
+}
+
+// NewMockSnapshotStore creates a new mock instance.
+func NewMockSnapshotStore(ctrl *gomock.Controller) *MockSnapshotStore {
+	mock := &MockSnapshotStore{ctrl: ctrl}
+	mock.recorder = &MockSnapshotStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSnapshotStore) EXPECT() *MockSnapshotStoreMockRecorder {
+	return m.recorder
+}
+
+// Load mocks base method.
+func (m *MockSnapshotStore) Load(ctx context.Context, venue event.VenueID, date string) (*ports.Snapshot, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Load", ctx, venue, date)
+	ret0, _ := ret[0].(*ports.Snapshot)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Load indicates an expected call of Load.
+func (mr *MockSnapshotStoreMockRecorder) Load(ctx, venue, date any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Load", reflect.TypeOf((*MockSnapshotStore)(nil).Load), ctx, venue, date)
+}
+
+// Save mocks base method.
+func (m *MockSnapshotStore) Save(ctx context.Context, venue event.VenueID, date string, snapshot *ports.Snapshot) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, venue, date, snapshot)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSnapshotStoreMockRecorder) Save(ctx, venue, date, snapshot any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSnapshotStore)(nil).Save), ctx, venue, date, snapshot)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+)
+
+// Snapshot is a venue's events on one date as of its last successful fetch. An empty
+// Events is a valid result: nothing was scheduled that day.
+type Snapshot struct {
+	FetchedAt time.Time     `json:"fetched_at"`
+	Events    []event.Event `json:"events"`
+}
+
+// SnapshotStore keeps the last successful fetch result per venue and date, formatted as
+// 2006-01-02, so a later run can fall back to it when the venue's site is down.
+//
+//go:generate mockgen -source=snapshot_store.go -destination=mock_ports/mock_snapshot_store.go -package=mock_ports
+type SnapshotStore interface {
+	Load(ctx context.Context, venue event.VenueID, date string) (*Snapshot, bool, error)
+	Save(ctx context.Context, venue event.VenueID, date string, snapshot *Snapshot) error
+}
@@ -0,0 +1,180 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+type DynamoDBClient interface {
+	UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error)
+	Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error)
+}
+
+// DynamoDBStore keeps one item per event in a table with the partition key "venue" and the
+// sort key "event_key", so a date range of a venue is a single query.
+type DynamoDBStore struct {
+	client DynamoDBClient
+	table  string
+	mu     sync.Mutex
+}
+
+// NewDynamoDBStore stores events in table.
+// A nil client is replaced by one built from the default AWS configuration on first use.
+func NewDynamoDBStore(client DynamoDBClient, table string) *DynamoDBStore {
+	return &DynamoDBStore{client: client, table: table}
+}
+
+func (s *DynamoDBStore) Put(ctx context.Context, venue event.VenueID, events []event.Event, seenAt time.Time) error {
+	client, err := s.dynamoDBClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		r := newRecord(venue, e, seenAt)
+		_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+			TableName: aws.String(s.table),
+			Key: map[string]types.AttributeValue{
+				"venue":     &types.AttributeValueMemberS{Value: string(r.Venue)},
+				"event_key": &types.AttributeValueMemberS{Value: r.Key},
+			},
+			UpdateExpression: aws.String("SET #date = :date, title = :title, schedules = :schedules, last_seen_at = :seen, first_seen_at = if_not_exists(first_seen_at, :seen)"),
+			// "date" is a reserved word.
+			ExpressionAttributeNames: map[string]string{"#date": "date"},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":date":      &types.AttributeValueMemberS{Value: r.Date},
+				":title":     &types.AttributeValueMemberS{Value: r.Title},
+				":schedules": schedulesAttribute(r.Schedules),
+				":seen":      &types.AttributeValueMemberS{Value: seenAt.UTC().Format(time.RFC3339)},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to archive %s event %q: %w", venue, r.Key, err)
+		}
+	}
+	return nil
+}
+
+func (s *DynamoDBStore) List(ctx context.Context, from, to time.Time) ([]ports.ArchivedEvent, error) {
+	client, err := s.dynamoDBClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys are the date, "#" and the title, so every key of the last date sorts before
+	// the date followed by "$".
+	fromKey := from.In(event.JST).Format(dateLayout)
+	toKey := to.In(event.JST).Format(dateLayout) + "$"
+
+	var events []ports.ArchivedEvent
+	for _, venue := range event.NewAllVenues() {
+		var startKey map[string]types.AttributeValue
+		for {
+			out, err := client.Query(ctx, &dynamodb.QueryInput{
+				TableName:              aws.String(s.table),
+				KeyConditionExpression: aws.String("venue = :venue AND event_key BETWEEN :from AND :to"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":venue": &types.AttributeValueMemberS{Value: string(venue.ID)},
+					":from":  &types.AttributeValueMemberS{Value: fromKey},
+					":to":    &types.AttributeValueMemberS{Value: toKey},
+				},
+				ExclusiveStartKey: startKey,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to query archived %s events: %w", venue.ID, err)
+			}
+
+			for _, item := range out.Items {
+				r, err := recordFromItem(item)
+				if err != nil {
+					return nil, err
+				}
+				e, err := r.archivedEvent()
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, e)
+			}
+
+			if len(out.LastEvaluatedKey) == 0 {
+				break
+			}
+			startKey = out.LastEvaluatedKey
+		}
+	}
+	return events, nil
+}
+
+func (s *DynamoDBStore) dynamoDBClient(ctx context.Context) (DynamoDBClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client == nil {
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		s.client = dynamodb.NewFromConfig(cfg)
+	}
+	return s.client, nil
+}
+
+func schedulesAttribute(schedules []schedule) types.AttributeValue {
+	list := make([]types.AttributeValue, 0, len(schedules))
+	for _, s := range schedules {
+		m := map[string]types.AttributeValue{}
+		if s.Start != "" {
+			m["start"] = &types.AttributeValueMemberS{Value: s.Start}
+		}
+		if s.Open != "" {
+			m["open"] = &types.AttributeValueMemberS{Value: s.Open}
+		}
+		list = append(list, &types.AttributeValueMemberM{Value: m})
+	}
+	return &types.AttributeValueMemberL{Value: list}
+}
+
+func recordFromItem(item map[string]types.AttributeValue) (record, error) {
+	r := record{
+		Venue: event.VenueID(stringAttribute(item, "venue")),
+		Key:   stringAttribute(item, "event_key"),
+		Date:  stringAttribute(item, "date"),
+		Title: stringAttribute(item, "title"),
+	}
+
+	var err error
+	if r.FirstSeenAt, err = time.Parse(time.RFC3339, stringAttribute(item, "first_seen_at")); err != nil {
+		return record{}, fmt.Errorf("invalid first_seen_at of archived event %q: %w", r.Key, err)
+	}
+	if r.LastSeenAt, err = time.Parse(time.RFC3339, stringAttribute(item, "last_seen_at")); err != nil {
+		return record{}, fmt.Errorf("invalid last_seen_at of archived event %q: %w", r.Key, err)
+	}
+
+	if list, ok := item["schedules"].(*types.AttributeValueMemberL); ok {
+		for _, v := range list.Value {
+			m, ok := v.(*types.AttributeValueMemberM)
+			if !ok {
+				continue
+			}
+			r.Schedules = append(r.Schedules, schedule{Start: stringAttribute(m.Value, "start"), Open: stringAttribute(m.Value, "open")})
+		}
+	}
+	return r, nil
+}
+
+func stringAttribute(item map[string]types.AttributeValue, name string) string {
+	if v, ok := item[name].(*types.AttributeValueMemberS); ok {
+		return v.Value
+	}
+	return ""
+}
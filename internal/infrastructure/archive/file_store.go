@@ -0,0 +1,130 @@
+package archive
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// FileStore keeps the archive in a single JSON file. It stands in for DynamoDB when
+// running locally or as a self-hosted server; the archive stays small, a few hundred
+// events a year.
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+func (s *FileStore) Put(_ context.Context, venue event.VenueID, events []event.Event, seenAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	records, err := s.read()
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string]int, len(records))
+	for i, r := range records {
+		index[string(r.Venue)+"#"+r.Key] = i
+	}
+	for _, e := range events {
+		r := newRecord(venue, e, seenAt)
+		if i, ok := index[string(venue)+"#"+r.Key]; ok {
+			r.FirstSeenAt = records[i].FirstSeenAt
+			records[i] = r
+			continue
+		}
+		index[string(venue)+"#"+r.Key] = len(records)
+		records = append(records, r)
+	}
+
+	return s.write(records)
+}
+
+func (s *FileStore) List(_ context.Context, from, to time.Time) ([]ports.ArchivedEvent, error) {
+	s.mu.Lock()
+	records, err := s.read()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	fromDate, toDate := from.In(event.JST).Format(dateLayout), to.In(event.JST).Format(dateLayout)
+	var events []ports.ArchivedEvent
+	for _, r := range records {
+		if r.Date < fromDate || r.Date > toDate {
+			continue
+		}
+		e, err := r.archivedEvent()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+func (s *FileStore) read() ([]record, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	var records []record
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to parse archive: %w", err)
+	}
+	return records, nil
+}
+
+func (s *FileStore) write(records []record) error {
+	// Sorted so the file diffs cleanly between runs.
+	slices.SortFunc(records, func(a, b record) int {
+		return cmp.Or(cmp.Compare(a.Date, b.Date), cmp.Compare(a.Venue, b.Venue), cmp.Compare(a.Title, b.Title))
+	})
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive: %w", err)
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, "archive-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
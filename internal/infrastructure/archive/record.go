@@ -0,0 +1,87 @@
+// Package archive stores every fetched event for later statistics.
+package archive
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04"
+)
+
+// record is the stored form of an event, shared by every store. Dates and times are in JST.
+type record struct {
+	FirstSeenAt time.Time     `json:"first_seen_at"`
+	LastSeenAt  time.Time     `json:"last_seen_at"`
+	Venue       event.VenueID `json:"venue"`
+	Key         string        `json:"key"`
+	Date        string        `json:"date"`
+	Title       string        `json:"title"`
+	Schedules   []schedule    `json:"schedules,omitempty"`
+}
+
+type schedule struct {
+	Start string `json:"start,omitempty"`
+	Open  string `json:"open,omitempty"`
+}
+
+func newRecord(venue event.VenueID, e event.Event, seenAt time.Time) record {
+	r := record{
+		FirstSeenAt: seenAt,
+		LastSeenAt:  seenAt,
+		Venue:       venue,
+		Key:         e.Key(),
+		Date:        e.Date.In(event.JST).Format(dateLayout),
+		Title:       e.Title,
+	}
+	for _, s := range e.Schedules {
+		r.Schedules = append(r.Schedules, schedule{Start: formatClock(s.StartTime), Open: formatClock(s.OpenTime)})
+	}
+	return r
+}
+
+func (r record) archivedEvent() (ports.ArchivedEvent, error) {
+	date, err := time.ParseInLocation(dateLayout, r.Date, event.JST)
+	if err != nil {
+		return ports.ArchivedEvent{}, fmt.Errorf("invalid archived date %q: %w", r.Date, err)
+	}
+
+	e := event.Event{Date: date, Title: r.Title}
+	for _, s := range r.Schedules {
+		start, err := parseClock(date, s.Start)
+		if err != nil {
+			return ports.ArchivedEvent{}, err
+		}
+		open, err := parseClock(date, s.Open)
+		if err != nil {
+			return ports.ArchivedEvent{}, err
+		}
+		e.Schedules = append(e.Schedules, event.Schedule{StartTime: start, OpenTime: open})
+	}
+
+	return ports.ArchivedEvent{FirstSeenAt: r.FirstSeenAt, LastSeenAt: r.LastSeenAt, Venue: r.Venue, Event: e}, nil
+}
+
+func formatClock(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.In(event.JST).Format(timeLayout)
+}
+
+func parseClock(date time.Time, s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+	clock, err := time.Parse(timeLayout, s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archived time %q: %w", s, err)
+	}
+	t := time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, event.JST)
+	return &t, nil
+}
@@ -0,0 +1,123 @@
+package archive
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// fakeDynamoDBClient implements just enough of UpdateItem and Query for DynamoDBStore.
+type fakeDynamoDBClient struct {
+	items map[string]map[string]types.AttributeValue
+}
+
+func (c *fakeDynamoDBClient) UpdateItem(_ context.Context, params *dynamodb.UpdateItemInput, _ ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	id := stringAttribute(params.Key, "venue") + "|" + stringAttribute(params.Key, "event_key")
+	item, ok := c.items[id]
+	if !ok {
+		item = map[string]types.AttributeValue{
+			"venue":         params.Key["venue"],
+			"event_key":     params.Key["event_key"],
+			"first_seen_at": params.ExpressionAttributeValues[":seen"],
+		}
+		c.items[id] = item
+	}
+	item["date"] = params.ExpressionAttributeValues[":date"]
+	item["title"] = params.ExpressionAttributeValues[":title"]
+	item["schedules"] = params.ExpressionAttributeValues[":schedules"]
+	item["last_seen_at"] = params.ExpressionAttributeValues[":seen"]
+	return &dynamodb.UpdateItemOutput{}, nil
+}
+
+func (c *fakeDynamoDBClient) Query(_ context.Context, params *dynamodb.QueryInput, _ ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	venue := stringAttribute(params.ExpressionAttributeValues, ":venue")
+	from := stringAttribute(params.ExpressionAttributeValues, ":from")
+	to := stringAttribute(params.ExpressionAttributeValues, ":to")
+
+	var ids []string
+	for id, item := range c.items {
+		key := stringAttribute(item, "event_key")
+		if strings.HasPrefix(id, venue+"|") && key >= from && key <= to {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	out := &dynamodb.QueryOutput{}
+	for _, id := range ids {
+		out.Items = append(out.Items, c.items[id])
+	}
+	return out, nil
+}
+
+func TestStores_PutAndList(t *testing.T) {
+	stores := map[string]ports.EventArchive{
+		"file":     NewFileStore(filepath.Join(t.TempDir(), "archive", "events.json")),
+		"dynamodb": NewDynamoDBStore(&fakeDynamoDBClient{items: map[string]map[string]types.AttributeValue{}}, "events"),
+	}
+
+	firstSeen := time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC)
+	lastSeen := time.Date(2026, 10, 8, 6, 0, 0, 0, time.UTC)
+	date := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+	start := time.Date(2026, 10, 18, 18, 30, 0, 0, event.JST)
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			require.NoError(t, store.Put(ctx, event.VenueIDYokohamaArena, []event.Event{
+				{Date: date, Title: "Live"},
+				{Date: date.AddDate(0, 0, 1), Title: "Next day"},
+			}, firstSeen))
+			require.NoError(t, store.Put(ctx, event.VenueIDYokohamaArena, []event.Event{
+				{Date: date, Title: "Live", Schedules: []event.Schedule{{StartTime: &start}}},
+			}, lastSeen))
+			require.NoError(t, store.Put(ctx, event.VenueIDNissanStadium, []event.Event{{Date: date, Title: "Match"}}, lastSeen))
+
+			events, err := store.List(ctx, date, date)
+			require.NoError(t, err)
+			require.Len(t, events, 2)
+
+			sort.Slice(events, func(i, j int) bool { return events[i].Venue < events[j].Venue })
+			assert.Equal(t, event.VenueIDNissanStadium, events[0].Venue)
+			arena := events[1]
+			assert.Equal(t, "Live", arena.Event.Title)
+			assert.True(t, date.Equal(arena.Event.Date))
+			assert.True(t, firstSeen.Equal(arena.FirstSeenAt), "first seen is kept on update")
+			assert.True(t, lastSeen.Equal(arena.LastSeenAt))
+			require.Len(t, arena.Event.Schedules, 1)
+			assert.True(t, start.Equal(*arena.Event.Schedules[0].StartTime))
+			assert.Nil(t, arena.Event.Schedules[0].OpenTime)
+		})
+	}
+}
+
+func TestFileStore_Missing(t *testing.T) {
+	store := NewFileStore(filepath.Join(t.TempDir(), "events.json"))
+
+	events, err := store.List(context.Background(), time.Now(), time.Now())
+
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestFileStore_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o644))
+
+	_, err := NewFileStore(path).List(context.Background(), time.Now(), time.Now())
+
+	require.Error(t, err)
+}
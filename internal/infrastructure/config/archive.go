@@ -0,0 +1,45 @@
+package config
+
+import "os"
+
+// Archive backends accepted by ARCHIVE_STORE.
+const (
+	ArchiveFile     = "file"
+	ArchiveDynamoDB = "dynamodb"
+)
+
+// DefaultArchiveFile is relative to the working directory, so a local archive is easy to
+// find and survives reboots, unlike the caches under the temporary directory.
+const DefaultArchiveFile = "event-archive.json"
+
+// ArchiveOptions configures the event archive. Backend is empty when archiving is disabled.
+type ArchiveOptions struct {
+	Backend string
+	File    string
+	Table   string
+}
+
+// LoadArchiveOptions reads the ARCHIVE_* environment variables.
+func LoadArchiveOptions() (ArchiveOptions, error) {
+	o := ArchiveOptions{
+		Backend: os.Getenv("ARCHIVE_STORE"),
+		File:    os.Getenv("ARCHIVE_FILE"),
+		Table:   os.Getenv("ARCHIVE_TABLE"),
+	}
+
+	switch o.Backend {
+	case "":
+	case ArchiveFile:
+		if o.File == "" {
+			o.File = DefaultArchiveFile
+		}
+	case ArchiveDynamoDB:
+		if o.Table == "" {
+			return o, invalidf("ARCHIVE_TABLE is required when ARCHIVE_STORE is %q", ArchiveDynamoDB)
+		}
+	default:
+		return o, invalidf("invalid ARCHIVE_STORE value %q: must be %s or %s", o.Backend, ArchiveFile, ArchiveDynamoDB)
+	}
+
+	return o, nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+func TestLoadArchiveOptions(t *testing.T) {
+	testCases := []struct {
+		want  ArchiveOptions
+		name  string
+		store string
+		table string
+	}{
+		{name: "off", want: ArchiveOptions{}},
+		{name: "file", store: "file", want: ArchiveOptions{Backend: ArchiveFile, File: DefaultArchiveFile}},
+		{name: "dynamodb", store: "dynamodb", table: "events", want: ArchiveOptions{Backend: ArchiveDynamoDB, Table: "events"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ARCHIVE_STORE", tc.store)
+			t.Setenv("ARCHIVE_FILE", "")
+			t.Setenv("ARCHIVE_TABLE", tc.table)
+
+			o, err := LoadArchiveOptions()
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, o)
+		})
+	}
+}
+
+func TestLoadArchiveOptions_Invalid(t *testing.T) {
+	testCases := []struct {
+		name    string
+		store   string
+		wantErr string
+	}{
+		{name: "unknown backend", store: "sqlite", wantErr: "ARCHIVE_STORE"},
+		{name: "dynamodb without table", store: "dynamodb", wantErr: "ARCHIVE_TABLE"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("ARCHIVE_STORE", tc.store)
+			t.Setenv("ARCHIVE_TABLE", "")
+
+			_, err := LoadArchiveOptions()
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, apperrors.ErrConfigInvalid)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
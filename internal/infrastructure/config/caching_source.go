@@ -0,0 +1,111 @@
+package config
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// DefaultCacheTTL is how long a warm Lambda reuses the configuration before re-reading it.
+const DefaultCacheTTL = 5 * time.Minute
+
+// CachingSource reuses the value of another source until the TTL expires or Invalidate
+// is called, so rotated secrets are picked up without a cold start.
+type CachingSource struct {
+	fetchedAt time.Time
+	source    Source
+	now       func() time.Time
+	value     string
+	ttl       time.Duration
+	mu        sync.Mutex
+	valid     bool
+}
+
+func NewCachingSource(source Source, ttl time.Duration) *CachingSource {
+	return &CachingSource{
+		source: source,
+		ttl:    ttl,
+		now:    time.Now,
+	}
+}
+
+func (s *CachingSource) Load(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.valid && s.now().Sub(s.fetchedAt) < s.ttl {
+		return s.value, nil
+	}
+
+	value, err := s.source.Load(ctx)
+	if err != nil {
+		// An outage of the secret store should not stop notifications that worked a moment ago.
+		if s.value != "" {
+			logging.FromContext(ctx).Warn("failed to refresh configuration, using cached value", logging.KeyError, err)
+			return s.value, nil
+		}
+		return "", err
+	}
+
+	s.value = value
+	s.fetchedAt = s.now()
+	s.valid = true
+	return value, nil
+}
+
+// Invalidate makes the next Load read the underlying source.
+func (s *CachingSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.valid = false
+}
+
+// DestinationWebhook resolves the current webhook URL of a named destination.
+type DestinationWebhook struct {
+	source *CachingSource
+	name   string
+}
+
+func NewDestinationWebhook(source *CachingSource, name string) *DestinationWebhook {
+	return &DestinationWebhook{source: source, name: name}
+}
+
+func (d *DestinationWebhook) WebhookURL(ctx context.Context) (string, error) {
+	secret, err := d.source.Load(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	destinations, _, err := parseSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	for _, dest := range destinations {
+		if dest.Name == d.name {
+			return dest.WebhookURL, nil
+		}
+	}
+	return "", invalidf("destination %q is no longer configured", d.name)
+}
+
+// Refresh discards the cached configuration, e.g. after Discord reports the webhook as revoked.
+func (d *DestinationWebhook) Refresh() {
+	d.source.Invalidate()
+}
+
+func cacheTTLFromEnv() (time.Duration, error) {
+	v := os.Getenv("CONFIG_CACHE_TTL")
+	if v == "" {
+		return DefaultCacheTTL, nil
+	}
+
+	ttl, err := time.ParseDuration(v)
+	if err != nil || ttl < 0 {
+		return 0, invalidf("invalid CONFIG_CACHE_TTL value %q: must be a non-negative duration such as 5m", v)
+	}
+	return ttl, nil
+}
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubSource struct {
+	err    error
+	values []string
+	calls  int
+}
+
+func (s *stubSource) Load(_ context.Context) (string, error) {
+	s.calls++
+	if s.err != nil {
+		return "", s.err
+	}
+	v := s.values[0]
+	if len(s.values) > 1 {
+		s.values = s.values[1:]
+	}
+	return v, nil
+}
+
+func newTestCachingSource(src Source, ttl time.Duration, now *time.Time) *CachingSource {
+	s := NewCachingSource(src, ttl)
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestCachingSource_ReusesValueWithinTTL(t *testing.T) {
+	now := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	src := &stubSource{values: []string{"first", "second"}}
+	cache := newTestCachingSource(src, 5*time.Minute, &now)
+
+	v, err := cache.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first", v)
+
+	now = now.Add(4 * time.Minute)
+	v, err = cache.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "first", v)
+	assert.Equal(t, 1, src.calls)
+
+	now = now.Add(time.Minute)
+	v, err = cache.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "second", v)
+	assert.Equal(t, 2, src.calls)
+}
+
+func TestCachingSource_Invalidate(t *testing.T) {
+	now := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	src := &stubSource{values: []string{"first", "second"}}
+	cache := newTestCachingSource(src, time.Hour, &now)
+
+	_, err := cache.Load(context.Background())
+	require.NoError(t, err)
+
+	cache.Invalidate()
+	v, err := cache.Load(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "second", v)
+}
+
+func TestCachingSource_KeepsStaleValueOnError(t *testing.T) {
+	now := time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)
+	src := &stubSource{values: []string{"first"}}
+	cache := newTestCachingSource(src, time.Minute, &now)
+
+	_, err := cache.Load(context.Background())
+	require.NoError(t, err)
+
+	src.err = errors.New("throttled")
+	now = now.Add(time.Hour)
+	v, err := cache.Load(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "first", v)
+}
+
+func TestCachingSource_ErrorWithoutCachedValue(t *testing.T) {
+	src := &stubSource{err: errors.New("access denied")}
+	cache := NewCachingSource(src, time.Minute)
+
+	_, err := cache.Load(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+}
+
+func TestDestinationWebhook_WebhookURL(t *testing.T) {
+	src := &stubSource{values: []string{
+		`{"version": 1, "destinations": [{"name": "main", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/1/old"}]}`,
+		`{"version": 1, "destinations": [{"name": "main", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/1/new"}]}`,
+	}}
+	webhook := NewDestinationWebhook(NewCachingSource(src, time.Hour), "main")
+
+	url, err := webhook.WebhookURL(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://discord.com/api/webhooks/1/old", url)
+
+	webhook.Refresh()
+	url, err = webhook.WebhookURL(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://discord.com/api/webhooks/1/new", url)
+
+	_, err = NewDestinationWebhook(NewCachingSource(src, time.Hour), "other").WebhookURL(context.Background())
+	assert.ErrorContains(t, err, `destination "other" is no longer configured`)
+}
+
+func TestLoadConfig_CacheTTL(t *testing.T) {
+	clearSourceEnv(t)
+	t.Setenv("DISCORD_WEBHOOK_URL", "https://discord.com/api/webhooks/123/abc")
+
+	cfg, err := LoadConfig(context.Background())
+	require.NoError(t, err)
+	require.NotNil(t, cfg.Source)
+	assert.Equal(t, DefaultCacheTTL, cfg.Source.ttl)
+
+	t.Setenv("CONFIG_CACHE_TTL", "soon")
+	_, err = LoadConfig(context.Background())
+	assert.ErrorContains(t, err, "invalid CONFIG_CACHE_TTL")
+}
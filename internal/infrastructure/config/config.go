@@ -3,50 +3,301 @@ package config
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+// Values of RUN_REPORTS, which selects the runs whose fetch diagnostics are posted to
+// the maintainer webhook.
+const (
+	RunReportsOff      = "off"
+	RunReportsProblems = "problems"
+	RunReportsAll      = "all"
+)
+
+// Values of FAILURE_NOTICE and of a destination's failure_notice, which select what
+// subscribers see when events cannot be fetched.
+const (
+	FailureNoticeFull = "full"
+	FailureNoticeSoft = "soft"
+	FailureNoticeNone = "none"
 )
 
 type Config struct {
-	DiscordWebhookURL string
+	// Source is the cached source the configuration was read from. Senders use it to pick
+	// up rotated webhook URLs; it is nil when the configuration was loaded without caching.
+	Source *CachingSource
+	// MaintainerWebhookURL receives a detailed report of every fetch failure. Empty
+	// disables them.
+	MaintainerWebhookURL string
+	RunReports           string
+	Destinations         []Destination
+	Discord              DiscordOptions
+	Policy               NotificationPolicy
+	SkipEmptyTomorrow    bool
+}
+
+type DiscordOptions struct {
+	Username         string
+	AvatarURL        string
+	ThreadID         string
+	MessageStorePath string
+	// MessageStoreBucket keeps the posted messages in S3 instead of MessageStorePath, so
+	// they survive Lambda cold starts.
+	MessageStoreBucket string
+	MessageStorePrefix string
+	ForumThreads       bool
+}
+
+type NotificationPolicy struct {
+	RoleMentions              []RoleMention
+	MinCongestion             int
+	SkipEmpty                 bool
+	AlwaysOnWeekends          bool
+	MentionHereForLargeVenues bool
 }
 
 type SecretsManagerClient interface {
 	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
 }
 
+// LoadConfig loads the configuration from the source selected by SourceFromEnv,
+// cached for CONFIG_CACHE_TTL.
 func LoadConfig(ctx context.Context) (*Config, error) {
-	return LoadConfigWithClient(ctx, nil)
+	src, err := SourceFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := cacheTTLFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	cached := NewCachingSource(src, ttl)
+	cfg, err := LoadConfigFromSource(ctx, cached)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Source = cached
+	return cfg, nil
 }
+
+// LoadConfigWithClient loads the configuration from the Secrets Manager secret in SECRET_ARN.
 func LoadConfigWithClient(ctx context.Context, client SecretsManagerClient) (*Config, error) {
 	secretARN := os.Getenv("SECRET_ARN")
 	if secretARN == "" {
-		return nil, fmt.Errorf("SECRET_ARN environment variable is required")
+		return nil, invalidf("SECRET_ARN environment variable is required")
+	}
+	return LoadConfigFromSource(ctx, NewSecretsManagerSource(client, secretARN))
+}
+
+// LoadConfigFromSource combines the destinations and features from src with the options
+// set by environment variables. Environment variables are validated before src is read.
+func LoadConfigFromSource(ctx context.Context, src Source) (*Config, error) {
+	skipEmptyTomorrow, err := boolEnv("SKIP_EMPTY_TOMORROW")
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := loadNotificationPolicy()
+	if err != nil {
+		return nil, err
 	}
 
-	if client == nil {
-		cfg, err := config.LoadDefaultConfig(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	discordOptions, err := loadDiscordOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	maintainerWebhookURL := os.Getenv("MAINTAINER_WEBHOOK_URL")
+	if maintainerWebhookURL != "" {
+		if u, err := url.Parse(maintainerWebhookURL); err != nil || u.Scheme != "https" || u.Host == "" {
+			return nil, invalidf("invalid MAINTAINER_WEBHOOK_URL: must be an https URL")
 		}
-		client = secretsmanager.NewFromConfig(cfg)
 	}
 
-	result, err := client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
-		SecretId: aws.String(secretARN),
-	})
+	runReports := os.Getenv("RUN_REPORTS")
+	switch runReports {
+	case "":
+		runReports = RunReportsOff
+	case RunReportsOff, RunReportsProblems, RunReportsAll:
+	default:
+		return nil, invalidf("invalid RUN_REPORTS value %q: must be %s, %s or %s", runReports, RunReportsOff, RunReportsProblems, RunReportsAll)
+	}
+	if runReports != RunReportsOff && maintainerWebhookURL == "" {
+		return nil, invalidf("RUN_REPORTS requires MAINTAINER_WEBHOOK_URL")
+	}
+
+	// Once the maintainer gets the details, subscribers only need to know the run was skipped.
+	failureNotice := os.Getenv("FAILURE_NOTICE")
+	switch {
+	case failureNotice == "" && maintainerWebhookURL != "":
+		failureNotice = FailureNoticeSoft
+	case failureNotice == "":
+		failureNotice = FailureNoticeFull
+	case !isFailureNotice(failureNotice):
+		return nil, invalidf("invalid FAILURE_NOTICE value %q: must be %s, %s or %s", failureNotice, FailureNoticeFull, FailureNoticeSoft, FailureNoticeNone)
+	}
+
+	secret, err := src.Load(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get secret value: %w", err)
+		return nil, err
 	}
 
-	if result.SecretString == nil {
-		return nil, fmt.Errorf("secret value is empty")
+	destinations, features, err := parseSecret(secret)
+	if err != nil {
+		return nil, err
 	}
+	for i := range destinations {
+		if destinations[i].FailureNotice == "" {
+			destinations[i].FailureNotice = failureNotice
+		}
+	}
+
+	// A flag is enabled when either the environment or the secret turns it on.
+	policy.SkipEmpty = policy.SkipEmpty || features.SkipEmpty
+	policy.AlwaysOnWeekends = policy.AlwaysOnWeekends || features.AlwaysOnWeekends
+	policy.MentionHereForLargeVenues = policy.MentionHereForLargeVenues || features.MentionHereForLargeVenues
 
 	return &Config{
-		DiscordWebhookURL: *result.SecretString,
+		MaintainerWebhookURL: maintainerWebhookURL,
+		RunReports:           runReports,
+		Destinations:         destinations,
+		Discord:              discordOptions,
+		Policy:               policy,
+		SkipEmptyTomorrow:    skipEmptyTomorrow || features.SkipEmptyTomorrow,
 	}, nil
 }
+
+func isFailureNotice(s string) bool {
+	return s == FailureNoticeFull || s == FailureNoticeSoft || s == FailureNoticeNone
+}
+
+type RoleMention struct {
+	Venue        string
+	RoleID       string
+	WeekdaysOnly bool
+}
+
+func loadDiscordOptions() (DiscordOptions, error) {
+	o := DiscordOptions{
+		Username:           os.Getenv("DISCORD_USERNAME"),
+		AvatarURL:          os.Getenv("DISCORD_AVATAR_URL"),
+		ThreadID:           os.Getenv("DISCORD_THREAD_ID"),
+		MessageStorePath:   os.Getenv("DISCORD_MESSAGE_STORE_PATH"),
+		MessageStoreBucket: os.Getenv("DISCORD_MESSAGE_STORE_BUCKET"),
+		MessageStorePrefix: os.Getenv("DISCORD_MESSAGE_STORE_PREFIX"),
+	}
+
+	if o.ThreadID != "" && !isSnowflake(o.ThreadID) {
+		return o, invalidf("invalid DISCORD_THREAD_ID value %q: must be numeric", o.ThreadID)
+	}
+	if o.MessageStorePath != "" && o.MessageStoreBucket != "" {
+		return o, invalidf("DISCORD_MESSAGE_STORE_PATH and DISCORD_MESSAGE_STORE_BUCKET cannot both be set")
+	}
+	if o.MessageStoreBucket != "" && o.MessageStorePrefix == "" {
+		o.MessageStorePrefix = "discord-messages/"
+	}
+
+	var err error
+	if o.ForumThreads, err = boolEnv("DISCORD_FORUM_THREADS"); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+func loadNotificationPolicy() (NotificationPolicy, error) {
+	var p NotificationPolicy
+	var err error
+
+	if p.SkipEmpty, err = boolEnv("NOTIFY_SKIP_EMPTY"); err != nil {
+		return p, err
+	}
+	if p.AlwaysOnWeekends, err = boolEnv("NOTIFY_ALWAYS_ON_WEEKENDS"); err != nil {
+		return p, err
+	}
+	if p.MentionHereForLargeVenues, err = boolEnv("NOTIFY_MENTION_HERE_LARGE_VENUES"); err != nil {
+		return p, err
+	}
+	if v := os.Getenv("NOTIFY_MIN_CONGESTION"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return p, invalidf("invalid NOTIFY_MIN_CONGESTION value %q: must be a non-negative integer", v)
+		}
+		p.MinCongestion = n
+	}
+	if p.RoleMentions, err = parseRoleMentions(os.Getenv("NOTIFY_ROLE_MENTIONS")); err != nil {
+		return p, err
+	}
+
+	return p, nil
+}
+
+// parseRoleMentions parses a comma-separated list of "venue:roleID" or "venue:roleID:weekdays".
+func parseRoleMentions(v string) ([]RoleMention, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	var mentions []RoleMention
+	for _, entry := range strings.Split(v, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) < 2 || len(parts) > 3 || (len(parts) == 3 && parts[2] != "weekdays") {
+			return nil, invalidf("invalid NOTIFY_ROLE_MENTIONS entry %q: expected venue:roleID[:weekdays]", entry)
+		}
+		if !isKnownVenue(parts[0]) {
+			return nil, invalidf("invalid NOTIFY_ROLE_MENTIONS entry %q: unknown venue %q", entry, parts[0])
+		}
+		if !isSnowflake(parts[1]) {
+			return nil, invalidf("invalid NOTIFY_ROLE_MENTIONS entry %q: role ID must be numeric", entry)
+		}
+		mentions = append(mentions, RoleMention{
+			Venue:        parts[0],
+			RoleID:       parts[1],
+			WeekdaysOnly: len(parts) == 3,
+		})
+	}
+	return mentions, nil
+}
+
+func isKnownVenue(id string) bool {
+	for _, v := range event.NewAllVenues() {
+		if string(v.ID) == id {
+			return true
+		}
+	}
+	return false
+}
+
+func isSnowflake(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func boolEnv(key string) (bool, error) {
+	v := os.Getenv(key)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, apperrors.NewConfigInvalidError(fmt.Sprintf("invalid %s value %q", key, v), err)
+	}
+	return b, nil
+}
@@ -9,6 +9,8 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
 )
 
 type mockSecretsManagerClient struct {
@@ -34,7 +36,8 @@ func TestLoadConfig_Success(t *testing.T) {
 
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
-	assert.Equal(t, "https://discord.com/api/webhooks/123/abc", cfg.DiscordWebhookURL)
+	require.Len(t, cfg.Destinations, 1)
+	assert.Equal(t, "https://discord.com/api/webhooks/123/abc", cfg.Destinations[0].WebhookURL)
 }
 
 func TestLoadConfig_MissingEnvVar(t *testing.T) {
@@ -116,7 +119,336 @@ func TestLoadConfig_ValidURL(t *testing.T) {
 			cfg, err := LoadConfigWithClient(context.Background(), mockClient)
 
 			require.NoError(t, err)
-			assert.Equal(t, tc.webhookURL, cfg.DiscordWebhookURL)
+			require.Len(t, cfg.Destinations, 1)
+			assert.Equal(t, tc.webhookURL, cfg.Destinations[0].WebhookURL)
+		})
+	}
+}
+
+func TestLoadConfig_SkipEmptyTomorrow(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("SKIP_EMPTY_TOMORROW", "true")
+
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFunc: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{
+				SecretString: aws.String("https://discord.com/api/webhooks/123/abc"),
+			}, nil
+		},
+	}
+
+	cfg, err := LoadConfigWithClient(context.Background(), mockClient)
+
+	require.NoError(t, err)
+	assert.True(t, cfg.SkipEmptyTomorrow)
+}
+
+func TestLoadConfig_InvalidSkipEmptyTomorrow(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("SKIP_EMPTY_TOMORROW", "sometimes")
+
+	cfg, err := LoadConfigWithClient(context.Background(), nil)
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Contains(t, err.Error(), "SKIP_EMPTY_TOMORROW")
+}
+
+func TestLoadConfig_NotificationPolicy(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("NOTIFY_SKIP_EMPTY", "true")
+	t.Setenv("NOTIFY_ALWAYS_ON_WEEKENDS", "true")
+	t.Setenv("NOTIFY_MENTION_HERE_LARGE_VENUES", "1")
+	t.Setenv("NOTIFY_MIN_CONGESTION", "2")
+
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFunc: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{
+				SecretString: aws.String("https://discord.com/api/webhooks/123/abc"),
+			}, nil
+		},
+	}
+
+	cfg, err := LoadConfigWithClient(context.Background(), mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, NotificationPolicy{
+		MinCongestion:             2,
+		SkipEmpty:                 true,
+		AlwaysOnWeekends:          true,
+		MentionHereForLargeVenues: true,
+	}, cfg.Policy)
+}
+
+func TestLoadConfig_InvalidMinCongestion(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("NOTIFY_MIN_CONGESTION", "-1")
+
+	cfg, err := LoadConfigWithClient(context.Background(), nil)
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Contains(t, err.Error(), "NOTIFY_MIN_CONGESTION")
+}
+
+func TestParseRoleMentions(t *testing.T) {
+	mentions, err := parseRoleMentions("nissan_stadium:111:weekdays, yokohama_arena:222")
+
+	require.NoError(t, err)
+	assert.Equal(t, []RoleMention{
+		{Venue: "nissan_stadium", RoleID: "111", WeekdaysOnly: true},
+		{Venue: "yokohama_arena", RoleID: "222"},
+	}, mentions)
+}
+
+func TestParseRoleMentions_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{name: "missing role", value: "nissan_stadium", want: "expected venue:roleID"},
+		{name: "unknown venue", value: "tokyo_dome:111", want: "unknown venue"},
+		{name: "non-numeric role", value: "nissan_stadium:@everyone", want: "role ID must be numeric"},
+		{name: "unknown qualifier", value: "nissan_stadium:111:weekends", want: "expected venue:roleID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseRoleMentions(tt.value)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
 		})
 	}
 }
+
+func TestLoadConfig_DiscordOptions(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("DISCORD_USERNAME", "新横浜bot")
+	t.Setenv("DISCORD_AVATAR_URL", "https://example.com/avatar.png")
+	t.Setenv("DISCORD_THREAD_ID", "999")
+	t.Setenv("DISCORD_FORUM_THREADS", "true")
+	t.Setenv("DISCORD_MESSAGE_STORE_PATH", "/tmp/discord-messages.json")
+
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFunc: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{
+				SecretString: aws.String("https://discord.com/api/webhooks/123/abc"),
+			}, nil
+		},
+	}
+
+	cfg, err := LoadConfigWithClient(context.Background(), mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, DiscordOptions{
+		Username:         "新横浜bot",
+		AvatarURL:        "https://example.com/avatar.png",
+		ThreadID:         "999",
+		MessageStorePath: "/tmp/discord-messages.json",
+		ForumThreads:     true,
+	}, cfg.Discord)
+}
+
+func TestLoadConfig_DiscordMessageStoreBucket(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("DISCORD_MESSAGE_STORE_BUCKET", "artifacts")
+
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFunc: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{
+				SecretString: aws.String("https://discord.com/api/webhooks/123/abc"),
+			}, nil
+		},
+	}
+
+	cfg, err := LoadConfigWithClient(context.Background(), mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, "artifacts", cfg.Discord.MessageStoreBucket)
+	assert.Equal(t, "discord-messages/", cfg.Discord.MessageStorePrefix)
+}
+
+func TestLoadConfig_DiscordMessageStorePathAndBucket(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("DISCORD_MESSAGE_STORE_PATH", "/tmp/discord-messages.json")
+	t.Setenv("DISCORD_MESSAGE_STORE_BUCKET", "artifacts")
+
+	_, err := LoadConfigWithClient(context.Background(), nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot both be set")
+}
+
+func TestLoadConfig_InvalidThreadID(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("DISCORD_THREAD_ID", "general")
+
+	cfg, err := LoadConfigWithClient(context.Background(), nil)
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Contains(t, err.Error(), "DISCORD_THREAD_ID")
+}
+
+func TestLoadConfig_MaintainerWebhookURL(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("MAINTAINER_WEBHOOK_URL", "https://discord.com/api/webhooks/456/ops")
+
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFunc: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{
+				SecretString: aws.String("https://discord.com/api/webhooks/123/abc"),
+			}, nil
+		},
+	}
+
+	cfg, err := LoadConfigWithClient(context.Background(), mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://discord.com/api/webhooks/456/ops", cfg.MaintainerWebhookURL)
+	assert.Equal(t, RunReportsOff, cfg.RunReports)
+}
+
+func TestLoadConfig_RunReports(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("MAINTAINER_WEBHOOK_URL", "https://discord.com/api/webhooks/456/ops")
+	t.Setenv("RUN_REPORTS", "problems")
+
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFunc: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{
+				SecretString: aws.String("https://discord.com/api/webhooks/123/abc"),
+			}, nil
+		},
+	}
+
+	cfg, err := LoadConfigWithClient(context.Background(), mockClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, RunReportsProblems, cfg.RunReports)
+}
+
+func TestLoadConfig_InvalidRunReports(t *testing.T) {
+	testCases := []struct {
+		name          string
+		runReports    string
+		maintainerURL string
+		wantErr       string
+	}{
+		{name: "unknown value", runReports: "sometimes", maintainerURL: "https://discord.com/api/webhooks/456/ops", wantErr: "invalid RUN_REPORTS"},
+		{name: "no maintainer webhook", runReports: "all", wantErr: "requires MAINTAINER_WEBHOOK_URL"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+			t.Setenv("RUN_REPORTS", tc.runReports)
+			t.Setenv("MAINTAINER_WEBHOOK_URL", tc.maintainerURL)
+
+			cfg, err := LoadConfigWithClient(context.Background(), nil)
+
+			require.Error(t, err)
+			assert.Nil(t, cfg)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestLoadConfig_FailureNotice(t *testing.T) {
+	secret := `{"version": 1, "destinations": [
+		{"name": "ops", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/1/a", "failure_notice": "full"},
+		{"name": "fans", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/2/b"}
+	]}`
+
+	testCases := []struct {
+		name          string
+		env           string
+		maintainerURL string
+		want          string
+	}{
+		{name: "defaults to full without maintainer", want: FailureNoticeFull},
+		{name: "defaults to soft with maintainer", maintainerURL: "https://discord.com/api/webhooks/456/ops", want: FailureNoticeSoft},
+		{name: "environment default", env: "none", maintainerURL: "https://discord.com/api/webhooks/456/ops", want: FailureNoticeNone},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("FAILURE_NOTICE", tc.env)
+			t.Setenv("MAINTAINER_WEBHOOK_URL", tc.maintainerURL)
+
+			cfg, err := LoadConfigFromSource(context.Background(), &stubSource{values: []string{secret}})
+
+			require.NoError(t, err)
+			require.Len(t, cfg.Destinations, 2)
+			assert.Equal(t, FailureNoticeFull, cfg.Destinations[0].FailureNotice, "set in the secret")
+			assert.Equal(t, tc.want, cfg.Destinations[1].FailureNotice)
+		})
+	}
+}
+
+func TestLoadConfig_InvalidFailureNotice(t *testing.T) {
+	t.Setenv("FAILURE_NOTICE", "loud")
+
+	cfg, err := LoadConfigFromSource(context.Background(), &stubSource{values: []string{"https://discord.com/api/webhooks/123/abc"}})
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.ErrorIs(t, err, apperrors.ErrConfigInvalid)
+	assert.Contains(t, err.Error(), "FAILURE_NOTICE")
+}
+
+func TestLoadConfig_InvalidMaintainerWebhookURL(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("MAINTAINER_WEBHOOK_URL", "http://example.com/hook")
+
+	cfg, err := LoadConfigWithClient(context.Background(), nil)
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Contains(t, err.Error(), "MAINTAINER_WEBHOOK_URL")
+}
+
+func TestLoadConfig_SecretFeaturesEnableFlags(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+	t.Setenv("NOTIFY_SKIP_EMPTY", "true")
+
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFunc: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{
+				SecretString: aws.String(`{
+					"version": 1,
+					"destinations": [{"name": "main", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/123/abc"}],
+					"features": {"skip_empty_tomorrow": true, "always_on_weekends": true}
+				}`),
+			}, nil
+		},
+	}
+
+	cfg, err := LoadConfigWithClient(context.Background(), mockClient)
+
+	require.NoError(t, err)
+	assert.True(t, cfg.SkipEmptyTomorrow)
+	assert.True(t, cfg.Policy.SkipEmpty)
+	assert.True(t, cfg.Policy.AlwaysOnWeekends)
+	assert.False(t, cfg.Policy.MentionHereForLargeVenues)
+}
+
+func TestLoadConfig_InvalidSecretDocument(t *testing.T) {
+	t.Setenv("SECRET_ARN", "arn:aws:secretsmanager:ap-northeast-1:123456789012:secret:test-secret")
+
+	mockClient := &mockSecretsManagerClient{
+		getSecretValueFunc: func(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+			return &secretsmanager.GetSecretValueOutput{
+				SecretString: aws.String(`{"version": 2, "destinations": []}`),
+			}, nil
+		},
+	}
+
+	cfg, err := LoadConfigWithClient(context.Background(), mockClient)
+
+	require.Error(t, err)
+	assert.Nil(t, cfg)
+	assert.Contains(t, err.Error(), "unsupported version 2")
+}
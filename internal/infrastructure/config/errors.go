@@ -0,0 +1,12 @@
+package config
+
+import (
+	"fmt"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+// invalidf reports a configuration problem that only the operator can fix.
+func invalidf(format string, args ...any) error {
+	return apperrors.NewConfigInvalidError(fmt.Sprintf(format, args...), nil)
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Fallback store backends accepted by FALLBACK_STORE.
+const (
+	FallbackMemory = "memory"
+	FallbackDisk   = "disk"
+	FallbackS3     = "s3"
+)
+
+// DefaultFallbackMaxAge lets a daily run fall back to the weekly run two days earlier.
+const DefaultFallbackMaxAge = 48 * time.Hour
+
+// FallbackOptions configures the last-known-good store used when a venue's site is down.
+// Backend is empty when the fallback is disabled.
+type FallbackOptions struct {
+	Backend string
+	Dir     string
+	Bucket  string
+	Prefix  string
+	MaxAge  time.Duration
+}
+
+// LoadFallbackOptions reads the FALLBACK_* environment variables.
+func LoadFallbackOptions() (FallbackOptions, error) {
+	o := FallbackOptions{
+		Backend: os.Getenv("FALLBACK_STORE"),
+		Dir:     os.Getenv("FALLBACK_DIR"),
+		Bucket:  os.Getenv("FALLBACK_BUCKET"),
+		Prefix:  os.Getenv("FALLBACK_PREFIX"),
+		MaxAge:  DefaultFallbackMaxAge,
+	}
+
+	switch o.Backend {
+	case "", FallbackMemory:
+	case FallbackDisk:
+		if o.Dir == "" {
+			o.Dir = filepath.Join(os.TempDir(), "shin-yokohama-event-notifier", "last-known-good")
+		}
+	case FallbackS3:
+		if o.Bucket == "" {
+			return o, invalidf("FALLBACK_BUCKET is required when FALLBACK_STORE is %q", FallbackS3)
+		}
+		if o.Prefix == "" {
+			o.Prefix = "last-known-good/"
+		}
+	default:
+		return o, invalidf("invalid FALLBACK_STORE value %q: must be %s, %s or %s", o.Backend, FallbackMemory, FallbackDisk, FallbackS3)
+	}
+
+	if v := os.Getenv("FALLBACK_MAX_AGE"); v != "" {
+		maxAge, err := time.ParseDuration(v)
+		if err != nil || maxAge <= 0 {
+			return o, invalidf("invalid FALLBACK_MAX_AGE value %q: must be a positive duration such as 48h", v)
+		}
+		o.MaxAge = maxAge
+	}
+
+	return o, nil
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+func clearFallbackEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"FALLBACK_STORE", "FALLBACK_DIR", "FALLBACK_BUCKET", "FALLBACK_PREFIX", "FALLBACK_MAX_AGE"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestLoadFallbackOptions_Defaults(t *testing.T) {
+	clearFallbackEnv(t)
+
+	o, err := LoadFallbackOptions()
+
+	require.NoError(t, err)
+	assert.Empty(t, o.Backend)
+	assert.Equal(t, DefaultFallbackMaxAge, o.MaxAge)
+}
+
+func TestLoadFallbackOptions_S3(t *testing.T) {
+	clearFallbackEnv(t)
+	t.Setenv("FALLBACK_STORE", "s3")
+	t.Setenv("FALLBACK_BUCKET", "bucket")
+	t.Setenv("FALLBACK_MAX_AGE", "24h")
+
+	o, err := LoadFallbackOptions()
+
+	require.NoError(t, err)
+	assert.Equal(t, "bucket", o.Bucket)
+	assert.Equal(t, "last-known-good/", o.Prefix)
+	assert.Equal(t, 24*time.Hour, o.MaxAge)
+}
+
+func TestLoadFallbackOptions_Invalid(t *testing.T) {
+	testCases := []struct {
+		env     map[string]string
+		name    string
+		wantErr string
+	}{
+		{name: "unknown backend", env: map[string]string{"FALLBACK_STORE": "redis"}, wantErr: "FALLBACK_STORE"},
+		{name: "s3 without bucket", env: map[string]string{"FALLBACK_STORE": "s3"}, wantErr: "FALLBACK_BUCKET"},
+		{name: "zero max age", env: map[string]string{"FALLBACK_STORE": "memory", "FALLBACK_MAX_AGE": "0s"}, wantErr: "FALLBACK_MAX_AGE"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearFallbackEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := LoadFallbackOptions()
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, apperrors.ErrConfigInvalid)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Fetch cache backends accepted by FETCH_CACHE.
+const (
+	FetchCacheMemory = "memory"
+	FetchCacheDisk   = "disk"
+	FetchCacheS3     = "s3"
+)
+
+// DefaultFetchCacheTTL keeps a weekly run and the daily run right after it on one download.
+const DefaultFetchCacheTTL = 30 * time.Minute
+
+// FetchCacheOptions configures caching of venue site responses. Backend is empty when
+// caching is disabled.
+type FetchCacheOptions struct {
+	// TTLs overrides TTL per venue ID.
+	TTLs    map[string]time.Duration
+	Backend string
+	Dir     string
+	Bucket  string
+	Prefix  string
+	TTL     time.Duration
+}
+
+// TTLFor returns the TTL for responses of the given venue's site.
+func (o FetchCacheOptions) TTLFor(venueID string) time.Duration {
+	if ttl, ok := o.TTLs[venueID]; ok {
+		return ttl
+	}
+	return o.TTL
+}
+
+// LoadFetchCacheOptions reads the FETCH_CACHE* environment variables. Unlike the rest of
+// the configuration it needs no source, since fetchers run in every binary.
+func LoadFetchCacheOptions() (FetchCacheOptions, error) {
+	o := FetchCacheOptions{
+		Backend: os.Getenv("FETCH_CACHE"),
+		Dir:     os.Getenv("FETCH_CACHE_DIR"),
+		Bucket:  os.Getenv("FETCH_CACHE_BUCKET"),
+		Prefix:  os.Getenv("FETCH_CACHE_PREFIX"),
+		TTL:     DefaultFetchCacheTTL,
+	}
+
+	switch o.Backend {
+	case "", FetchCacheMemory:
+	case FetchCacheDisk:
+		if o.Dir == "" {
+			o.Dir = filepath.Join(os.TempDir(), "shin-yokohama-event-notifier", "http-cache")
+		}
+	case FetchCacheS3:
+		if o.Bucket == "" {
+			return o, invalidf("FETCH_CACHE_BUCKET is required when FETCH_CACHE is %q", FetchCacheS3)
+		}
+		if o.Prefix == "" {
+			o.Prefix = "http-cache/"
+		}
+	default:
+		return o, invalidf("invalid FETCH_CACHE value %q: must be %s, %s or %s", o.Backend, FetchCacheMemory, FetchCacheDisk, FetchCacheS3)
+	}
+
+	if v := os.Getenv("FETCH_CACHE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil || ttl < 0 {
+			return o, invalidf("invalid FETCH_CACHE_TTL value %q: must be a non-negative duration such as 30m", v)
+		}
+		o.TTL = ttl
+	}
+
+	var err error
+	if o.TTLs, err = parseVenueTTLs(os.Getenv("FETCH_CACHE_VENUE_TTLS")); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// parseVenueTTLs parses a comma-separated list of "venue:duration".
+func parseVenueTTLs(v string) (map[string]time.Duration, error) {
+	if v == "" {
+		return nil, nil
+	}
+
+	ttls := make(map[string]time.Duration)
+	for _, entry := range strings.Split(v, ",") {
+		venue, value, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok {
+			return nil, invalidf("invalid FETCH_CACHE_VENUE_TTLS entry %q: expected venue:duration", entry)
+		}
+		if !isKnownVenue(venue) {
+			return nil, invalidf("invalid FETCH_CACHE_VENUE_TTLS entry %q: unknown venue %q", entry, venue)
+		}
+		ttl, err := time.ParseDuration(value)
+		if err != nil || ttl < 0 {
+			return nil, invalidf("invalid FETCH_CACHE_VENUE_TTLS entry %q: must be a non-negative duration such as 1h", entry)
+		}
+		ttls[venue] = ttl
+	}
+	return ttls, nil
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func clearFetchCacheEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"FETCH_CACHE", "FETCH_CACHE_DIR", "FETCH_CACHE_BUCKET", "FETCH_CACHE_PREFIX", "FETCH_CACHE_TTL", "FETCH_CACHE_VENUE_TTLS"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestLoadFetchCacheOptions_Defaults(t *testing.T) {
+	clearFetchCacheEnv(t)
+
+	o, err := LoadFetchCacheOptions()
+
+	require.NoError(t, err)
+	assert.Empty(t, o.Backend)
+	assert.Equal(t, DefaultFetchCacheTTL, o.TTL)
+}
+
+func TestLoadFetchCacheOptions_Disk(t *testing.T) {
+	clearFetchCacheEnv(t)
+	t.Setenv("FETCH_CACHE", "disk")
+
+	o, err := LoadFetchCacheOptions()
+
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(os.TempDir(), "shin-yokohama-event-notifier", "http-cache"), o.Dir)
+}
+
+func TestLoadFetchCacheOptions_S3(t *testing.T) {
+	clearFetchCacheEnv(t)
+	t.Setenv("FETCH_CACHE", "s3")
+	t.Setenv("FETCH_CACHE_BUCKET", "bucket")
+
+	o, err := LoadFetchCacheOptions()
+
+	require.NoError(t, err)
+	assert.Equal(t, "bucket", o.Bucket)
+	assert.Equal(t, "http-cache/", o.Prefix)
+}
+
+func TestLoadFetchCacheOptions_VenueTTLs(t *testing.T) {
+	clearFetchCacheEnv(t)
+	t.Setenv("FETCH_CACHE", "memory")
+	t.Setenv("FETCH_CACHE_TTL", "10m")
+	t.Setenv("FETCH_CACHE_VENUE_TTLS", "skate_center:2h, nissan_stadium:0s")
+
+	o, err := LoadFetchCacheOptions()
+
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, o.TTLFor("skate_center"))
+	assert.Equal(t, time.Duration(0), o.TTLFor("nissan_stadium"))
+	assert.Equal(t, 10*time.Minute, o.TTLFor("yokohama_arena"))
+}
+
+func TestLoadFetchCacheOptions_Invalid(t *testing.T) {
+	testCases := []struct {
+		env     map[string]string
+		name    string
+		wantErr string
+	}{
+		{name: "unknown backend", env: map[string]string{"FETCH_CACHE": "redis"}, wantErr: "invalid FETCH_CACHE value"},
+		{name: "s3 without bucket", env: map[string]string{"FETCH_CACHE": "s3"}, wantErr: "FETCH_CACHE_BUCKET is required"},
+		{name: "bad ttl", env: map[string]string{"FETCH_CACHE_TTL": "soon"}, wantErr: "invalid FETCH_CACHE_TTL value"},
+		{name: "unknown venue", env: map[string]string{"FETCH_CACHE_VENUE_TTLS": "tokyo_dome:1h"}, wantErr: `unknown venue "tokyo_dome"`},
+		{name: "missing duration", env: map[string]string{"FETCH_CACHE_VENUE_TTLS": "skate_center"}, wantErr: "expected venue:duration"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearFetchCacheEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			_, err := LoadFetchCacheOptions()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
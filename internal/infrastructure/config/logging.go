@@ -0,0 +1,38 @@
+package config
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+type LoggingOptions struct {
+	Format string
+	Level  slog.Level
+}
+
+// LoadLoggingOptions reads LOG_FORMAT and LOG_LEVEL. Logs are JSON in Lambda, where
+// CloudWatch Logs Insights parses the fields, and text elsewhere. defaultLevel applies
+// when LOG_LEVEL is unset.
+func LoadLoggingOptions(defaultLevel slog.Level) (LoggingOptions, error) {
+	o := LoggingOptions{Format: os.Getenv("LOG_FORMAT"), Level: defaultLevel}
+
+	switch o.Format {
+	case "":
+		o.Format = logging.FormatText
+		if os.Getenv("AWS_LAMBDA_FUNCTION_NAME") != "" {
+			o.Format = logging.FormatJSON
+		}
+	case logging.FormatJSON, logging.FormatText:
+	default:
+		return o, invalidf("invalid LOG_FORMAT value %q: must be %s or %s", o.Format, logging.FormatJSON, logging.FormatText)
+	}
+
+	if v := os.Getenv("LOG_LEVEL"); v != "" {
+		if err := o.Level.UnmarshalText([]byte(v)); err != nil {
+			return o, invalidf("invalid LOG_LEVEL value %q: must be debug, info, warn or error", v)
+		}
+	}
+	return o, nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+func TestLoadLoggingOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   string
+		level    string
+		function string
+		want     LoggingOptions
+	}{
+		{name: "defaults", want: LoggingOptions{Format: logging.FormatText, Level: slog.LevelInfo}},
+		{name: "lambda", function: "notifier-daily", want: LoggingOptions{Format: logging.FormatJSON, Level: slog.LevelInfo}},
+		{name: "explicit format in lambda", format: "text", function: "notifier-daily", want: LoggingOptions{Format: logging.FormatText, Level: slog.LevelInfo}},
+		{name: "level", format: "json", level: "debug", want: LoggingOptions{Format: logging.FormatJSON, Level: slog.LevelDebug}},
+		{name: "upper case level", level: "WARN", want: LoggingOptions{Format: logging.FormatText, Level: slog.LevelWarn}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("LOG_FORMAT", tc.format)
+			t.Setenv("LOG_LEVEL", tc.level)
+			t.Setenv("AWS_LAMBDA_FUNCTION_NAME", tc.function)
+
+			o, err := LoadLoggingOptions(slog.LevelInfo)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, o)
+		})
+	}
+}
+
+func TestLoadLoggingOptions_Invalid(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "logfmt")
+	_, err := LoadLoggingOptions(slog.LevelInfo)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LOG_FORMAT")
+
+	t.Setenv("LOG_FORMAT", "")
+	t.Setenv("LOG_LEVEL", "verbose")
+	_, err = LoadLoggingOptions(slog.LevelInfo)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "LOG_LEVEL")
+}
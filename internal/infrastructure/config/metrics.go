@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+)
+
+// Metrics backends accepted by METRICS.
+const (
+	MetricsOff = "off"
+	MetricsEMF = "emf"
+)
+
+// DefaultMetricsNamespace is the CloudWatch namespace used when METRICS_NAMESPACE is unset.
+const DefaultMetricsNamespace = "ShinYokohamaEventNotifier"
+
+type MetricsOptions struct {
+	Backend   string
+	Namespace string
+}
+
+// LoadMetricsOptions reads METRICS and METRICS_NAMESPACE. Metrics are off by default,
+// since EMF lines are only useful where CloudWatch Logs picks up stdout.
+func LoadMetricsOptions() (MetricsOptions, error) {
+	o := MetricsOptions{
+		Backend:   os.Getenv("METRICS"),
+		Namespace: os.Getenv("METRICS_NAMESPACE"),
+	}
+
+	switch o.Backend {
+	case "":
+		o.Backend = MetricsOff
+	case MetricsOff, MetricsEMF:
+	default:
+		return o, invalidf("invalid METRICS value %q: must be %s or %s", o.Backend, MetricsOff, MetricsEMF)
+	}
+
+	if o.Namespace == "" {
+		o.Namespace = DefaultMetricsNamespace
+	}
+	return o, nil
+}
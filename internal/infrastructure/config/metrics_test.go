@@ -0,0 +1,45 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+func TestLoadMetricsOptions(t *testing.T) {
+	testCases := []struct {
+		name      string
+		backend   string
+		namespace string
+		want      MetricsOptions
+	}{
+		{name: "defaults", want: MetricsOptions{Backend: MetricsOff, Namespace: DefaultMetricsNamespace}},
+		{name: "emf", backend: "emf", want: MetricsOptions{Backend: MetricsEMF, Namespace: DefaultMetricsNamespace}},
+		{name: "custom namespace", backend: "emf", namespace: "Staging", want: MetricsOptions{Backend: MetricsEMF, Namespace: "Staging"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("METRICS", tc.backend)
+			t.Setenv("METRICS_NAMESPACE", tc.namespace)
+
+			o, err := LoadMetricsOptions()
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, o)
+		})
+	}
+}
+
+func TestLoadMetricsOptions_Invalid(t *testing.T) {
+	t.Setenv("METRICS", "prometheus")
+
+	_, err := LoadMetricsOptions()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "METRICS")
+	assert.ErrorIs(t, err, apperrors.ErrConfigInvalid)
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+const (
+	// SecretVersion is the only JSON secret schema version currently understood.
+	SecretVersion = 1
+
+	DestinationTypeDiscordWebhook = "discord_webhook"
+
+	// legacyDestinationName keeps message store keys stable for secrets that are a plain URL.
+	legacyDestinationName = "discord"
+)
+
+// Destination is a notification target defined in the secret.
+type Destination struct {
+	Name       string
+	Type       string
+	WebhookURL string
+	// FailureNotice is one of the FailureNotice* values. LoadConfigFromSource fills in the
+	// default when the secret leaves it empty.
+	FailureNotice string
+	RoleMentions  []RoleMention
+}
+
+// Features are flags that may be set in the secret in addition to environment variables.
+type Features struct {
+	SkipEmptyTomorrow         bool `json:"skip_empty_tomorrow"`
+	SkipEmpty                 bool `json:"skip_empty"`
+	AlwaysOnWeekends          bool `json:"always_on_weekends"`
+	MentionHereForLargeVenues bool `json:"mention_here_for_large_venues"`
+}
+
+type secretDocument struct {
+	Version      *int                `json:"version"`
+	Destinations []secretDestination `json:"destinations"`
+	Features     Features            `json:"features"`
+}
+
+// secretDestination describes a Discord webhook either by its full URL or by its ID and token.
+type secretDestination struct {
+	Name          string              `json:"name"`
+	Type          string              `json:"type"`
+	URL           string              `json:"url"`
+	ID            string              `json:"id"`
+	Token         string              `json:"token"`
+	FailureNotice string              `json:"failure_notice"`
+	RoleMentions  []secretRoleMention `json:"role_mentions"`
+}
+
+type secretRoleMention struct {
+	Venue        string `json:"venue"`
+	RoleID       string `json:"role_id"`
+	WeekdaysOnly bool   `json:"weekdays_only"`
+}
+
+// parseSecret accepts either a versioned JSON document or, for backward compatibility,
+// a plain Discord webhook URL.
+func parseSecret(secret string) ([]Destination, Features, error) {
+	trimmed := strings.TrimSpace(secret)
+	if trimmed == "" {
+		return nil, Features{}, apperrors.NewConfigInvalidError("secret value is empty", nil)
+	}
+
+	if !strings.HasPrefix(trimmed, "{") {
+		if problem := checkWebhookURL(trimmed); problem != "" {
+			return nil, Features{}, apperrors.NewConfigInvalidError("invalid secret: "+problem, nil)
+		}
+		return []Destination{{
+			Name:       legacyDestinationName,
+			Type:       DestinationTypeDiscordWebhook,
+			WebhookURL: trimmed,
+		}}, Features{}, nil
+	}
+
+	var doc secretDocument
+	dec := json.NewDecoder(bytes.NewReader([]byte(trimmed)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, Features{}, apperrors.NewConfigInvalidError("secret is not a valid configuration document", err)
+	}
+
+	destinations, problems := doc.validate()
+	if len(problems) > 0 {
+		return nil, Features{}, apperrors.NewConfigInvalidError("invalid secret: "+strings.Join(problems, "; "), nil)
+	}
+
+	return destinations, doc.Features, nil
+}
+
+// validate reports every problem at once so a broken secret can be fixed in one go.
+func (doc *secretDocument) validate() ([]Destination, []string) {
+	var problems []string
+
+	switch {
+	case doc.Version == nil:
+		problems = append(problems, "version is required")
+	case *doc.Version != SecretVersion:
+		problems = append(problems, fmt.Sprintf("unsupported version %d (supported: %d)", *doc.Version, SecretVersion))
+	}
+
+	if len(doc.Destinations) == 0 {
+		problems = append(problems, "at least one destination is required")
+	}
+
+	var destinations []Destination
+	seen := make(map[string]bool)
+	for i, d := range doc.Destinations {
+		field := fmt.Sprintf("destinations[%d]", i)
+
+		if d.Name == "" {
+			problems = append(problems, field+".name is required")
+		} else if seen[d.Name] {
+			problems = append(problems, fmt.Sprintf("%s.name %q is duplicated", field, d.Name))
+		}
+		seen[d.Name] = true
+
+		if d.Type != DestinationTypeDiscordWebhook {
+			problems = append(problems, fmt.Sprintf("%s.type %q is not supported (supported: %s)", field, d.Type, DestinationTypeDiscordWebhook))
+			continue
+		}
+
+		webhookURL, problem := d.webhookURL()
+		if problem != "" {
+			problems = append(problems, field+": "+problem)
+		}
+
+		if d.FailureNotice != "" && !isFailureNotice(d.FailureNotice) {
+			problems = append(problems, fmt.Sprintf("%s.failure_notice %q is not supported (supported: %s, %s, %s)", field, d.FailureNotice, FailureNoticeFull, FailureNoticeSoft, FailureNoticeNone))
+		}
+
+		dest := Destination{Name: d.Name, Type: d.Type, WebhookURL: webhookURL, FailureNotice: d.FailureNotice}
+		for j, rm := range d.RoleMentions {
+			rmField := fmt.Sprintf("%s.role_mentions[%d]", field, j)
+			if !isKnownVenue(rm.Venue) {
+				problems = append(problems, fmt.Sprintf("%s.venue %q is unknown", rmField, rm.Venue))
+			}
+			if !isSnowflake(rm.RoleID) {
+				problems = append(problems, rmField+".role_id must be numeric")
+			}
+			dest.RoleMentions = append(dest.RoleMentions, RoleMention(rm))
+		}
+		destinations = append(destinations, dest)
+	}
+
+	return destinations, problems
+}
+
+func (d secretDestination) webhookURL() (string, string) {
+	switch {
+	case d.URL != "" && (d.ID != "" || d.Token != ""):
+		return "", "url cannot be combined with id and token"
+	case d.URL != "":
+		if problem := checkWebhookURL(d.URL); problem != "" {
+			return "", problem
+		}
+		return d.URL, ""
+	case d.ID == "" || d.Token == "":
+		return "", "either url or both id and token are required"
+	case !isSnowflake(d.ID):
+		return "", "id must be numeric"
+	default:
+		return "https://discord.com/api/webhooks/" + d.ID + "/" + url.PathEscape(d.Token), ""
+	}
+}
+
+// discordHosts are the hosts Discord serves webhooks from.
+var discordHosts = []string{"discord.com", "discordapp.com", "ptb.discord.com", "canary.discord.com"}
+
+// checkWebhookURL reports why raw is not a Discord webhook URL, or returns "".
+func checkWebhookURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || !slices.Contains(discordHosts, u.Host) || !strings.HasPrefix(u.Path, "/api/webhooks/") {
+		return "url must be an https Discord webhook URL (https://discord.com/api/webhooks/...)"
+	}
+	return ""
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+func TestParseSecret_LegacyURL(t *testing.T) {
+	destinations, features, err := parseSecret(" https://discord.com/api/webhooks/123/abc\n")
+
+	require.NoError(t, err)
+	assert.Equal(t, []Destination{{
+		Name:       "discord",
+		Type:       DestinationTypeDiscordWebhook,
+		WebhookURL: "https://discord.com/api/webhooks/123/abc",
+	}}, destinations)
+	assert.Equal(t, Features{}, features)
+}
+
+func TestParseSecret_Document(t *testing.T) {
+	secret := `{
+		"version": 1,
+		"destinations": [
+			{
+				"name": "main",
+				"type": "discord_webhook",
+				"url": "https://discord.com/api/webhooks/123/abc",
+				"role_mentions": [{"venue": "nissan_stadium", "role_id": "111", "weekdays_only": true}]
+			},
+			{"name": "sub", "type": "discord_webhook", "id": "456", "token": "def"}
+		],
+		"features": {"mention_here_for_large_venues": true}
+	}`
+
+	destinations, features, err := parseSecret(secret)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Destination{
+		{
+			Name:         "main",
+			Type:         DestinationTypeDiscordWebhook,
+			WebhookURL:   "https://discord.com/api/webhooks/123/abc",
+			RoleMentions: []RoleMention{{Venue: "nissan_stadium", RoleID: "111", WeekdaysOnly: true}},
+		},
+		{
+			Name:       "sub",
+			Type:       DestinationTypeDiscordWebhook,
+			WebhookURL: "https://discord.com/api/webhooks/456/def",
+		},
+	}, destinations)
+	assert.True(t, features.MentionHereForLargeVenues)
+}
+
+func TestParseSecret_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		secret  string
+		wantMsg []string
+	}{
+		{
+			name:    "empty",
+			secret:  "  ",
+			wantMsg: []string{"secret value is empty"},
+		},
+		{
+			name:    "malformed JSON",
+			secret:  `{"version": 1,`,
+			wantMsg: []string{"secret is not a valid configuration document"},
+		},
+		{
+			name:    "unknown field",
+			secret:  `{"version": 1, "destinations": [], "webhook": "x"}`,
+			wantMsg: []string{`unknown field "webhook"`},
+		},
+		{
+			name:    "missing version and destinations",
+			secret:  `{}`,
+			wantMsg: []string{"version is required", "at least one destination is required"},
+		},
+		{
+			name:    "unsupported type",
+			secret:  `{"version": 1, "destinations": [{"name": "a", "type": "slack", "url": "https://example.com"}]}`,
+			wantMsg: []string{`destinations[0].type "slack" is not supported`},
+		},
+		{
+			name:    "missing url and token",
+			secret:  `{"version": 1, "destinations": [{"name": "a", "type": "discord_webhook", "id": "1"}]}`,
+			wantMsg: []string{"destinations[0]: either url or both id and token are required"},
+		},
+		{
+			name:    "url combined with token",
+			secret:  `{"version": 1, "destinations": [{"name": "a", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/1/a", "token": "b"}]}`,
+			wantMsg: []string{"destinations[0]: url cannot be combined with id and token"},
+		},
+		{
+			name:    "insecure url",
+			secret:  `{"version": 1, "destinations": [{"name": "a", "type": "discord_webhook", "url": "http://discord.com/api/webhooks/1/a"}]}`,
+			wantMsg: []string{"destinations[0]: url must be an https Discord webhook URL"},
+		},
+		{
+			name:    "url of another site",
+			secret:  `{"version": 1, "destinations": [{"name": "a", "type": "discord_webhook", "url": "https://example.com/api/webhooks/1/a"}]}`,
+			wantMsg: []string{"destinations[0]: url must be an https Discord webhook URL"},
+		},
+		{
+			name:    "legacy url that is not a webhook",
+			secret:  "discord.com/api/webhooks/1/a",
+			wantMsg: []string{"invalid secret: url must be an https Discord webhook URL"},
+		},
+		{
+			name:    "legacy url outside the webhooks path",
+			secret:  "https://discord.com/channels/1/2",
+			wantMsg: []string{"invalid secret: url must be an https Discord webhook URL"},
+		},
+		{
+			name:    "unknown failure notice",
+			secret:  `{"version": 1, "destinations": [{"name": "a", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/1/a", "failure_notice": "loud"}]}`,
+			wantMsg: []string{`destinations[0].failure_notice "loud" is not supported (supported: full, soft, none)`},
+		},
+		{
+			name: "duplicate names and bad role mentions",
+			secret: `{"version": 1, "destinations": [
+				{"name": "a", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/1/a"},
+				{"name": "a", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/2/b",
+				 "role_mentions": [{"venue": "tokyo_dome", "role_id": "abc"}]}
+			]}`,
+			wantMsg: []string{
+				`destinations[1].name "a" is duplicated`,
+				`destinations[1].role_mentions[0].venue "tokyo_dome" is unknown`,
+				"destinations[1].role_mentions[0].role_id must be numeric",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			destinations, _, err := parseSecret(tc.secret)
+
+			require.Error(t, err)
+			assert.Nil(t, destinations)
+			var domainErr *apperrors.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, apperrors.CodeConfigInvalid, domainErr.Code)
+			for _, msg := range tc.wantMsg {
+				assert.Contains(t, err.Error(), msg)
+			}
+		})
+	}
+}
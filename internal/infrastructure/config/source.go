@@ -0,0 +1,204 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+// Source names accepted by CONFIG_SOURCE.
+const (
+	SourceEnv            = "env"
+	SourceFile           = "file"
+	SourceSecretsManager = "secretsmanager"
+	SourceSSM            = "ssm"
+)
+
+// Source supplies the secret part of the configuration: either a plain Discord webhook URL
+// or a versioned JSON document (see parseSecret).
+type Source interface {
+	Load(ctx context.Context) (string, error)
+}
+
+type SSMClient interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// SourceFromEnv selects the source named by CONFIG_SOURCE. When it is unset, the first source
+// whose settings are present wins, in the order Secrets Manager, SSM, file, environment.
+func SourceFromEnv() (Source, error) {
+	name := os.Getenv("CONFIG_SOURCE")
+	if name == "" {
+		switch {
+		case os.Getenv("SECRET_ARN") != "":
+			name = SourceSecretsManager
+		case os.Getenv("SSM_PARAMETER_NAME") != "":
+			name = SourceSSM
+		case os.Getenv("CONFIG_FILE") != "":
+			name = SourceFile
+		case os.Getenv("DISCORD_WEBHOOK_URL") != "":
+			name = SourceEnv
+		default:
+			return nil, invalidf("no configuration source found: set SECRET_ARN, SSM_PARAMETER_NAME, CONFIG_FILE or DISCORD_WEBHOOK_URL")
+		}
+	}
+
+	switch name {
+	case SourceEnv:
+		return EnvSource{}, nil
+	case SourceFile:
+		path := os.Getenv("CONFIG_FILE")
+		if path == "" {
+			return nil, invalidf("CONFIG_FILE environment variable is required")
+		}
+		return NewFileSource(path), nil
+	case SourceSecretsManager:
+		arn := os.Getenv("SECRET_ARN")
+		if arn == "" {
+			return nil, invalidf("SECRET_ARN environment variable is required")
+		}
+		return NewSecretsManagerSource(nil, arn), nil
+	case SourceSSM:
+		parameter := os.Getenv("SSM_PARAMETER_NAME")
+		if parameter == "" {
+			return nil, invalidf("SSM_PARAMETER_NAME environment variable is required")
+		}
+		return NewSSMSource(nil, parameter), nil
+	default:
+		return nil, invalidf("invalid CONFIG_SOURCE value %q: must be one of %s, %s, %s, %s",
+			name, SourceEnv, SourceFile, SourceSecretsManager, SourceSSM)
+	}
+}
+
+// EnvSource reads DISCORD_CONFIG (a JSON document) or, failing that, DISCORD_WEBHOOK_URL.
+type EnvSource struct{}
+
+func (EnvSource) Load(_ context.Context) (string, error) {
+	if v := os.Getenv("DISCORD_CONFIG"); v != "" {
+		return v, nil
+	}
+	if v := os.Getenv("DISCORD_WEBHOOK_URL"); v != "" {
+		return v, nil
+	}
+	return "", invalidf("DISCORD_CONFIG or DISCORD_WEBHOOK_URL environment variable is required")
+}
+
+// FileSource reads the configuration document from a local YAML, TOML or JSON file,
+// chosen by extension. YAML and TOML are converted to JSON so every format is
+// validated by the same schema.
+type FileSource struct {
+	path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+func (s *FileSource) Load(_ context.Context) (string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc map[string]any
+	switch ext := strings.ToLower(filepath.Ext(s.path)); ext {
+	case ".json":
+		return string(data), nil
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return "", invalidf("unsupported config file extension %q: use .yaml, .yml, .toml or .json", ext)
+	}
+	if err != nil {
+		return "", apperrors.NewConfigInvalidError(fmt.Sprintf("failed to parse config file %s", s.path), err)
+	}
+
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return "", apperrors.NewConfigInvalidError(fmt.Sprintf("failed to convert config file %s", s.path), err)
+	}
+	return string(converted), nil
+}
+
+type SecretsManagerSource struct {
+	client    SecretsManagerClient
+	secretARN string
+}
+
+// NewSecretsManagerSource creates a source for secretARN. A nil client is replaced by one
+// built from the default AWS configuration on first use.
+func NewSecretsManagerSource(client SecretsManagerClient, secretARN string) *SecretsManagerSource {
+	return &SecretsManagerSource{client: client, secretARN: secretARN}
+}
+
+func (s *SecretsManagerSource) Load(ctx context.Context) (string, error) {
+	if s.client == nil {
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		s.client = secretsmanager.NewFromConfig(cfg)
+	}
+
+	result, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(s.secretARN),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret value: %w", err)
+	}
+
+	if result.SecretString == nil {
+		return "", invalidf("secret value is empty")
+	}
+
+	return *result.SecretString, nil
+}
+
+type SSMSource struct {
+	client        SSMClient
+	parameterName string
+}
+
+// NewSSMSource creates a source for an SSM parameter, which may be a SecureString.
+// A nil client is replaced by one built from the default AWS configuration on first use.
+func NewSSMSource(client SSMClient, parameterName string) *SSMSource {
+	return &SSMSource{client: client, parameterName: parameterName}
+}
+
+func (s *SSMSource) Load(ctx context.Context) (string, error) {
+	if s.client == nil {
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		s.client = ssm.NewFromConfig(cfg)
+	}
+
+	result, err := s.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(s.parameterName),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get SSM parameter: %w", err)
+	}
+
+	if result.Parameter == nil || result.Parameter.Value == nil {
+		return "", invalidf("SSM parameter value is empty")
+	}
+
+	return *result.Parameter.Value, nil
+}
@@ -0,0 +1,239 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSSMClient struct {
+	getParameterFunc func(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+func (m *mockSSMClient) GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	return m.getParameterFunc(ctx, params, optFns...)
+}
+
+func clearSourceEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{"CONFIG_SOURCE", "SECRET_ARN", "SSM_PARAMETER_NAME", "CONFIG_FILE", "DISCORD_WEBHOOK_URL", "DISCORD_CONFIG"} {
+		t.Setenv(key, "")
+	}
+}
+
+func TestSourceFromEnv(t *testing.T) {
+	testCases := []struct {
+		env     map[string]string
+		want    Source
+		name    string
+		wantErr string
+	}{
+		{
+			name: "secrets manager is preferred",
+			env:  map[string]string{"SECRET_ARN": "arn", "DISCORD_WEBHOOK_URL": "https://example.com"},
+			want: NewSecretsManagerSource(nil, "arn"),
+		},
+		{
+			name: "ssm",
+			env:  map[string]string{"SSM_PARAMETER_NAME": "/notifier/config"},
+			want: NewSSMSource(nil, "/notifier/config"),
+		},
+		{
+			name: "file",
+			env:  map[string]string{"CONFIG_FILE": "config.yaml"},
+			want: NewFileSource("config.yaml"),
+		},
+		{
+			name: "env",
+			env:  map[string]string{"DISCORD_WEBHOOK_URL": "https://example.com"},
+			want: EnvSource{},
+		},
+		{
+			name: "explicit source overrides detection",
+			env:  map[string]string{"CONFIG_SOURCE": "file", "CONFIG_FILE": "config.toml", "SECRET_ARN": "arn"},
+			want: NewFileSource("config.toml"),
+		},
+		{
+			name:    "explicit source without its settings",
+			env:     map[string]string{"CONFIG_SOURCE": "ssm"},
+			wantErr: "SSM_PARAMETER_NAME environment variable is required",
+		},
+		{
+			name:    "unknown source",
+			env:     map[string]string{"CONFIG_SOURCE": "vault"},
+			wantErr: `invalid CONFIG_SOURCE value "vault"`,
+		},
+		{
+			name:    "nothing configured",
+			env:     map[string]string{},
+			wantErr: "no configuration source found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			clearSourceEnv(t)
+			for k, v := range tc.env {
+				t.Setenv(k, v)
+			}
+
+			src, err := SourceFromEnv()
+
+			if tc.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, src)
+		})
+	}
+}
+
+func TestEnvSource_Load(t *testing.T) {
+	clearSourceEnv(t)
+	t.Setenv("DISCORD_WEBHOOK_URL", "https://discord.com/api/webhooks/123/abc")
+
+	secret, err := EnvSource{}.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "https://discord.com/api/webhooks/123/abc", secret)
+
+	t.Setenv("DISCORD_CONFIG", `{"version": 1}`)
+
+	secret, err = EnvSource{}.Load(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, `{"version": 1}`, secret)
+}
+
+func TestFileSource_Load(t *testing.T) {
+	testCases := []struct {
+		name    string
+		file    string
+		content string
+	}{
+		{
+			name: "yaml",
+			file: "config.yaml",
+			content: `version: 1
+destinations:
+  - name: home
+    type: discord_webhook
+    url: https://discord.com/api/webhooks/123/abc
+features:
+  skip_empty: true
+`,
+		},
+		{
+			name: "toml",
+			file: "config.toml",
+			content: `version = 1
+
+[features]
+skip_empty = true
+
+[[destinations]]
+name = "home"
+type = "discord_webhook"
+url = "https://discord.com/api/webhooks/123/abc"
+`,
+		},
+		{
+			name:    "json",
+			file:    "config.json",
+			content: `{"version": 1, "destinations": [{"name": "home", "type": "discord_webhook", "url": "https://discord.com/api/webhooks/123/abc"}], "features": {"skip_empty": true}}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.file)
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o600))
+
+			secret, err := NewFileSource(path).Load(context.Background())
+			require.NoError(t, err)
+
+			destinations, features, err := parseSecret(secret)
+			require.NoError(t, err)
+			assert.Equal(t, []Destination{{
+				Name:       "home",
+				Type:       DestinationTypeDiscordWebhook,
+				WebhookURL: "https://discord.com/api/webhooks/123/abc",
+			}}, destinations)
+			assert.True(t, features.SkipEmpty)
+		})
+	}
+}
+
+func TestFileSource_Load_Errors(t *testing.T) {
+	dir := t.TempDir()
+	unsupported := filepath.Join(dir, "config.ini")
+	require.NoError(t, os.WriteFile(unsupported, []byte("x=1"), 0o600))
+	invalid := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("version: [1"), 0o600))
+
+	_, err := NewFileSource(filepath.Join(dir, "missing.yaml")).Load(context.Background())
+	assert.ErrorContains(t, err, "failed to read config file")
+
+	_, err = NewFileSource(unsupported).Load(context.Background())
+	assert.ErrorContains(t, err, `unsupported config file extension ".ini"`)
+
+	_, err = NewFileSource(invalid).Load(context.Background())
+	assert.ErrorContains(t, err, "failed to parse config file")
+}
+
+func TestSSMSource_Load(t *testing.T) {
+	client := &mockSSMClient{
+		getParameterFunc: func(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+			assert.Equal(t, "/notifier/config", aws.ToString(params.Name))
+			assert.True(t, aws.ToBool(params.WithDecryption))
+			return &ssm.GetParameterOutput{
+				Parameter: &ssmtypes.Parameter{Value: aws.String("https://discord.com/api/webhooks/123/abc")},
+			}, nil
+		},
+	}
+
+	secret, err := NewSSMSource(client, "/notifier/config").Load(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://discord.com/api/webhooks/123/abc", secret)
+}
+
+func TestSSMSource_Load_Error(t *testing.T) {
+	client := &mockSSMClient{
+		getParameterFunc: func(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+			return nil, errors.New("access denied")
+		},
+	}
+
+	_, err := NewSSMSource(client, "/notifier/config").Load(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get SSM parameter")
+}
+
+func TestLoadConfig_FromFileSource(t *testing.T) {
+	clearSourceEnv(t)
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`version: 1
+destinations:
+  - name: home
+    type: discord_webhook
+    id: "123"
+    token: abc
+`), 0o600))
+	t.Setenv("CONFIG_FILE", path)
+
+	cfg, err := LoadConfig(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, cfg.Destinations, 1)
+	assert.Equal(t, "https://discord.com/api/webhooks/123/abc", cfg.Destinations[0].WebhookURL)
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"os"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/tracing"
+)
+
+type TracingOptions struct {
+	Exporter string
+}
+
+// LoadTracingOptions reads the standard OTEL_TRACES_EXPORTER variable. Tracing is off by
+// default; "console" is accepted as an alias for "stdout", as in other OpenTelemetry SDKs.
+func LoadTracingOptions() (TracingOptions, error) {
+	o := TracingOptions{Exporter: os.Getenv("OTEL_TRACES_EXPORTER")}
+
+	switch o.Exporter {
+	case "":
+		o.Exporter = tracing.ExporterNone
+	case "console":
+		o.Exporter = tracing.ExporterStdout
+	case tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout:
+	default:
+		return o, invalidf("invalid OTEL_TRACES_EXPORTER value %q: must be %s, %s or %s",
+			o.Exporter, tracing.ExporterNone, tracing.ExporterOTLP, tracing.ExporterStdout)
+	}
+	return o, nil
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/tracing"
+)
+
+func TestLoadTracingOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		exporter string
+		want     string
+	}{
+		{name: "default", want: tracing.ExporterNone},
+		{name: "none", exporter: "none", want: tracing.ExporterNone},
+		{name: "otlp", exporter: "otlp", want: tracing.ExporterOTLP},
+		{name: "stdout", exporter: "stdout", want: tracing.ExporterStdout},
+		{name: "console alias", exporter: "console", want: tracing.ExporterStdout},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_EXPORTER", tc.exporter)
+
+			o, err := LoadTracingOptions()
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, o.Exporter)
+		})
+	}
+}
+
+func TestLoadTracingOptions_Invalid(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+
+	_, err := LoadTracingOptions()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "OTEL_TRACES_EXPORTER")
+}
@@ -6,11 +6,28 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
+// tracer returns the package tracer from the current global provider.
+func tracer() trace.Tracer {
+	return otel.Tracer("github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/discord")
+}
+
 type WebhookClient struct {
 	httpClient *http.Client
+	metrics    ports.Metrics
 }
 
 func NewWebhookClient() *WebhookClient {
@@ -21,28 +38,154 @@ func NewWebhookClient() *WebhookClient {
 	}
 }
 
+// ExecuteParams are the query parameters accepted by the Execute Webhook endpoint.
+// See https://discord.com/developers/docs/resources/webhook#execute-webhook
+type ExecuteParams struct {
+	// ThreadID posts into an existing thread of the webhook's channel.
+	ThreadID string
+	// Wait makes Discord return the created message instead of 204 No Content.
+	Wait bool
+}
+
+// Message is the subset of Discord's message object returned when Wait is set.
+type Message struct {
+	ID        string `json:"id"`
+	ChannelID string `json:"channel_id"`
+}
+
 func (c *WebhookClient) Execute(ctx context.Context, webhookURL string, payload *WebhookPayload) error {
+	_, err := c.ExecuteWithParams(ctx, webhookURL, ExecuteParams{}, payload)
+	return err
+}
+
+// StatusError is returned when Discord responds with a non-2xx status.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("webhook returned non-success status: %d", e.StatusCode)
+}
+
+// ExecuteWithParams posts payload and, when params.Wait is set, returns the created message.
+func (c *WebhookClient) ExecuteWithParams(ctx context.Context, webhookURL string, params ExecuteParams, payload *WebhookPayload) (*Message, error) {
+	reqURL, err := withQuery(webhookURL, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build webhook URL: %w", err)
+	}
+
+	return c.do(ctx, http.MethodPost, reqURL, payload, params.Wait)
+}
+
+// EditMessage replaces the content of a message previously sent by the webhook.
+// threadID must be set when the message lives in a thread.
+// See https://discord.com/developers/docs/resources/webhook#edit-webhook-message
+func (c *WebhookClient) EditMessage(ctx context.Context, webhookURL, messageID, threadID string, payload *WebhookPayload) (*Message, error) {
+	reqURL, err := withQuery(strings.TrimSuffix(webhookURL, "/")+"/messages/"+url.PathEscape(messageID), ExecuteParams{ThreadID: threadID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build webhook URL: %w", err)
+	}
+
+	return c.do(ctx, http.MethodPatch, reqURL, payload, true)
+}
+
+// do sends the request within a span. The webhook URL is not recorded, since its token
+// is a credential; the webhook ID identifies the destination instead.
+func (c *WebhookClient) do(ctx context.Context, method, reqURL string, payload *WebhookPayload, decode bool) (msg *Message, err error) {
+	ctx, span := tracer().Start(ctx, "WebhookClient."+method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("http.request.method", method),
+		attribute.String("discord.webhook_id", webhookID(reqURL)),
+	))
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+		return nil, fmt.Errorf("failed to marshal webhook payload: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to execute webhook request: %w", err)
+		return nil, fmt.Errorf("failed to execute webhook request: %w", err)
 	}
 	defer resp.Body.Close()
 
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if c.metrics != nil {
+		// Sends outside a run, such as slash command follow-ups, have no mode.
+		dims := map[string]string{"status_code": strconv.Itoa(resp.StatusCode)}
+		if mode := logging.ModeFromContext(ctx); mode != "" {
+			dims["mode"] = mode
+		}
+		c.metrics.Record(ctx, ports.Metric{
+			Name:       "DiscordResponses",
+			Unit:       ports.UnitCount,
+			Value:      1,
+			Dimensions: dims,
+		})
+	}
+
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("webhook returned non-success status: %d", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
+	}
+
+	if !decode {
+		return nil, nil
+	}
+
+	var m Message
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("failed to decode webhook message: %w", err)
+	}
+
+	return &m, nil
+}
+
+// webhookID extracts the ID from a ".../webhooks/{id}/{token}" URL.
+func webhookID(webhookURL string) string {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return ""
+	}
+	parts := strings.Split(u.Path, "/")
+	for i, p := range parts {
+		if p == "webhooks" && i+1 < len(parts) {
+			return parts[i+1]
+		}
+	}
+	return ""
+}
+
+func withQuery(webhookURL string, params ExecuteParams) (string, error) {
+	if params.ThreadID == "" && !params.Wait {
+		return webhookURL, nil
+	}
+
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return "", err
+	}
+
+	q := u.Query()
+	if params.ThreadID != "" {
+		q.Set("thread_id", params.ThreadID)
+	}
+	if params.Wait {
+		q.Set("wait", "true")
 	}
+	u.RawQuery = q.Encode()
 
-	return nil
+	return u.String(), nil
 }
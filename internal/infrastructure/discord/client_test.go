@@ -10,6 +10,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 type RoundTripFunc func(req *http.Request) (*http.Response, error)
@@ -172,6 +176,56 @@ func TestWebhookClient_Execute_RequestConstruction(t *testing.T) {
 	assert.Contains(t, string(body), "Test Title")
 }
 
+func TestWebhookClient_ExecuteWithParams_WaitReturnsMessage(t *testing.T) {
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "https://discord.com/api/webhooks/123/abc?thread_id=999&wait=true", req.URL.String())
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"id":"42","channel_id":"999","content":""}`)),
+		}, nil
+	})
+
+	client := newTestClient(mockTransport)
+	msg, err := client.ExecuteWithParams(context.Background(), "https://discord.com/api/webhooks/123/abc", ExecuteParams{ThreadID: "999", Wait: true}, &WebhookPayload{})
+
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	assert.Equal(t, "42", msg.ID)
+	assert.Equal(t, "999", msg.ChannelID)
+}
+
+func TestWebhookClient_ExecuteWithParams_NoWait(t *testing.T) {
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Empty(t, req.URL.RawQuery)
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}, nil
+	})
+
+	client := newTestClient(mockTransport)
+	msg, err := client.ExecuteWithParams(context.Background(), "https://discord.com/api/webhooks/123/abc", ExecuteParams{}, &WebhookPayload{})
+
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+}
+
+func TestWebhookClient_ExecuteWithParams_InvalidMessageBody(t *testing.T) {
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString("not json")),
+		}, nil
+	})
+
+	client := newTestClient(mockTransport)
+	msg, err := client.ExecuteWithParams(context.Background(), "https://discord.com/api/webhooks/123/abc", ExecuteParams{Wait: true}, &WebhookPayload{})
+
+	require.Error(t, err)
+	assert.Nil(t, msg)
+	assert.Contains(t, err.Error(), "failed to decode webhook message")
+}
+
 func TestNewWebhookClient(t *testing.T) {
 	client := NewWebhookClient()
 
@@ -179,3 +233,72 @@ func TestNewWebhookClient(t *testing.T) {
 	require.NotNil(t, client.httpClient)
 	assert.NotZero(t, client.httpClient.Timeout)
 }
+
+func TestWebhookClient_EditMessage(t *testing.T) {
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPatch, req.Method)
+		assert.Equal(t, "https://discord.com/api/webhooks/123/abc/messages/42", req.URL.String())
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"id":"42","channel_id":"7"}`)),
+		}, nil
+	})
+
+	client := newTestClient(mockTransport)
+	msg, err := client.EditMessage(context.Background(), "https://discord.com/api/webhooks/123/abc", "42", "", &WebhookPayload{})
+
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	assert.Equal(t, "42", msg.ID)
+}
+
+func TestWebhookClient_EditMessage_NotFound(t *testing.T) {
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(bytes.NewBufferString("Unknown Message")),
+		}, nil
+	})
+
+	client := newTestClient(mockTransport)
+	_, err := client.EditMessage(context.Background(), "https://discord.com/api/webhooks/123/abc", "42", "", &WebhookPayload{})
+
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, 404, statusErr.StatusCode)
+}
+
+func TestWebhookClient_Execute_Span(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	original := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(original) })
+
+	client := newTestClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusTooManyRequests, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+	})
+
+	err := client.Execute(context.Background(), "https://discord.com/api/webhooks/123/secret-token", &WebhookPayload{})
+	require.Error(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "WebhookClient.POST", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+
+	attrs := make(map[string]string)
+	for _, kv := range spans[0].Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	assert.Equal(t, "123", attrs["discord.webhook_id"])
+	assert.Equal(t, "429", attrs["http.response.status_code"])
+	for _, v := range attrs {
+		assert.NotContains(t, v, "secret-token")
+	}
+}
+
+func TestWebhookID(t *testing.T) {
+	assert.Equal(t, "123", webhookID("https://discord.com/api/webhooks/123/abc?wait=true"))
+	assert.Equal(t, "123", webhookID("https://discord.com/api/webhooks/123/abc/messages/456"))
+	assert.Empty(t, webhookID("https://example.com/hook"))
+}
@@ -0,0 +1,106 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// CommandName is the slash command handled by InteractionHandler.
+const CommandName = "shinyoko"
+
+// Application command option types.
+// See https://discord.com/developers/docs/interactions/application-commands#application-command-object-application-command-option-type
+const (
+	commandOptionSubCommand = 1
+	commandOptionString     = 3
+)
+
+type ApplicationCommand struct {
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Options     []ApplicationCommandOption `json:"options,omitempty"`
+}
+
+type ApplicationCommandOption struct {
+	Name        string                     `json:"name"`
+	Description string                     `json:"description"`
+	Options     []ApplicationCommandOption `json:"options,omitempty"`
+	Type        int                        `json:"type"`
+	Required    bool                       `json:"required,omitempty"`
+}
+
+// Commands returns the schema of the slash commands handled by InteractionHandler.
+func Commands() []ApplicationCommand {
+	return []ApplicationCommand{{
+		Name:        CommandName,
+		Description: "新横浜のイベント情報を表示します",
+		Options: []ApplicationCommandOption{
+			{Type: commandOptionSubCommand, Name: "today", Description: "今日のイベント"},
+			{Type: commandOptionSubCommand, Name: "week", Description: "今日から7日間のイベント"},
+			{
+				Type:        commandOptionSubCommand,
+				Name:        "date",
+				Description: "指定した日のイベント",
+				Options: []ApplicationCommandOption{
+					{Type: commandOptionString, Name: "date", Description: "日付 (YYYY-MM-DD)", Required: true},
+				},
+			},
+		},
+	}}
+}
+
+// CommandRegistrar registers slash commands with the bot token of the application.
+type CommandRegistrar struct {
+	httpClient    *http.Client
+	applicationID string
+	botToken      string
+}
+
+func NewCommandRegistrar(applicationID, botToken string) *CommandRegistrar {
+	return &CommandRegistrar{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		applicationID: applicationID,
+		botToken:      botToken,
+	}
+}
+
+// Register overwrites the application's commands. With a guildID the commands are
+// registered only in that guild, where changes apply immediately.
+// See https://discord.com/developers/docs/interactions/application-commands#bulk-overwrite-global-application-commands
+func (r *CommandRegistrar) Register(ctx context.Context, guildID string, commands []ApplicationCommand) error {
+	reqURL := apiBaseURL + "/applications/" + url.PathEscape(r.applicationID)
+	if guildID != "" {
+		reqURL += "/guilds/" + url.PathEscape(guildID)
+	}
+	reqURL += "/commands"
+
+	jsonData, err := json.Marshal(commands)
+	if err != nil {
+		return fmt.Errorf("failed to marshal commands: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, reqURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bot "+r.botToken)
+
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to register commands: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to register commands: %w", &StatusError{StatusCode: resp.StatusCode})
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistrar(fn RoundTripFunc) *CommandRegistrar {
+	r := NewCommandRegistrar("app", "bot-token")
+	r.httpClient = &http.Client{Transport: fn}
+	return r
+}
+
+func TestCommands_Schema(t *testing.T) {
+	body, err := json.Marshal(Commands())
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `[{
+		"name": "shinyoko",
+		"description": "新横浜のイベント情報を表示します",
+		"options": [
+			{"type": 1, "name": "today", "description": "今日のイベント"},
+			{"type": 1, "name": "week", "description": "今日から7日間のイベント"},
+			{"type": 1, "name": "date", "description": "指定した日のイベント", "options": [
+				{"type": 3, "name": "date", "description": "日付 (YYYY-MM-DD)", "required": true}
+			]}
+		]
+	}]`, string(body))
+}
+
+func TestCommandRegistrar_Register(t *testing.T) {
+	testCases := []struct {
+		name    string
+		guildID string
+		wantURL string
+	}{
+		{name: "global", wantURL: "https://discord.com/api/v10/applications/app/commands"},
+		{name: "guild", guildID: "guild", wantURL: "https://discord.com/api/v10/applications/app/guilds/guild/commands"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := newTestRegistrar(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, http.MethodPut, req.Method)
+				assert.Equal(t, tc.wantURL, req.URL.String())
+				assert.Equal(t, "Bot bot-token", req.Header.Get("Authorization"))
+
+				var commands []ApplicationCommand
+				require.NoError(t, json.NewDecoder(req.Body).Decode(&commands))
+				assert.Equal(t, Commands(), commands)
+
+				return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString("[]"))}, nil
+			})
+
+			err := r.Register(context.Background(), tc.guildID, Commands())
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestCommandRegistrar_Register_ErrorStatus(t *testing.T) {
+	r := newTestRegistrar(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusUnauthorized, Body: io.NopCloser(bytes.NewBufferString(""))}, nil
+	})
+
+	err := r.Register(context.Background(), "", Commands())
+
+	var statusErr *StatusError
+	require.ErrorAs(t, err, &statusErr)
+	assert.Equal(t, http.StatusUnauthorized, statusErr.StatusCode)
+}
@@ -1,10 +1,13 @@
 package discord
 
 import (
+	"strings"
+
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
 )
 
 type Embed struct {
+	Footer      *EmbedFooter `json:"footer,omitempty"`
 	Title       string       `json:"title,omitempty"`
 	Description string       `json:"description,omitempty"`
 	Timestamp   string       `json:"timestamp,omitempty"`
@@ -18,10 +21,26 @@ type EmbedField struct {
 	Inline bool   `json:"inline"`
 }
 
+type EmbedFooter struct {
+	Text string `json:"text"`
+}
+
 type WebhookPayload struct {
-	Content  string  `json:"content,omitempty"`
-	Username string  `json:"username,omitempty"`
-	Embeds   []Embed `json:"embeds,omitempty"`
+	AllowedMentions *AllowedMentions `json:"allowed_mentions,omitempty"`
+	Content         string           `json:"content,omitempty"`
+	Username        string           `json:"username,omitempty"`
+	AvatarURL       string           `json:"avatar_url,omitempty"`
+	// ThreadName creates a new forum post; only valid for webhooks of forum or media channels.
+	ThreadName string  `json:"thread_name,omitempty"`
+	Embeds     []Embed `json:"embeds,omitempty"`
+}
+
+// AllowedMentions restricts which mentions in content actually ping.
+// See https://discord.com/developers/docs/resources/message#allowed-mentions-object
+type AllowedMentions struct {
+	Parse []string `json:"parse"`
+	Roles []string `json:"roles,omitempty"`
+	Users []string `json:"users,omitempty"`
 }
 
 // See docs/discord_message_format.md for format specification.
@@ -44,3 +63,26 @@ func mapNotificationToEmbed(notif *notification.Notification) Embed {
 
 	return embed
 }
+
+// mapMentions renders mentions into message content and an allowed_mentions object that
+// permits exactly those pings, so text elsewhere in the message can never mass-ping.
+func mapMentions(mentions []notification.Mention) (string, *AllowedMentions) {
+	allowed := &AllowedMentions{Parse: []string{}}
+	var parts []string
+
+	for _, m := range mentions {
+		switch m.Kind {
+		case notification.MentionHere:
+			parts = append(parts, "@here")
+			allowed.Parse = append(allowed.Parse, "everyone")
+		case notification.MentionRole:
+			parts = append(parts, "<@&"+m.ID+">")
+			allowed.Roles = append(allowed.Roles, m.ID)
+		case notification.MentionUser:
+			parts = append(parts, "<@"+m.ID+">")
+			allowed.Users = append(allowed.Users, m.ID)
+		}
+	}
+
+	return strings.Join(parts, " "), allowed
+}
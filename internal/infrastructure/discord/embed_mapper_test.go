@@ -172,3 +172,47 @@ func TestWebhookPayload_OmitEmptyFields(t *testing.T) {
 	assert.NotContains(t, jsonStr, "username")
 	assert.Contains(t, jsonStr, "embeds")
 }
+
+func TestMapMentions(t *testing.T) {
+	tests := []struct {
+		expectedAllowed *AllowedMentions
+		name            string
+		expectedContent string
+		mentions        []notification.Mention
+	}{
+		{
+			name:            "no mentions",
+			mentions:        nil,
+			expectedContent: "",
+			expectedAllowed: &AllowedMentions{Parse: []string{}},
+		},
+		{
+			name:            "here",
+			mentions:        []notification.Mention{notification.HereMention()},
+			expectedContent: "@here",
+			expectedAllowed: &AllowedMentions{Parse: []string{"everyone"}},
+		},
+		{
+			name:            "role and user",
+			mentions:        []notification.Mention{notification.RoleMention("111"), notification.UserMention("222")},
+			expectedContent: "<@&111> <@222>",
+			expectedAllowed: &AllowedMentions{Parse: []string{}, Roles: []string{"111"}, Users: []string{"222"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, allowed := mapMentions(tt.mentions)
+
+			assert.Equal(t, tt.expectedContent, content)
+			assert.Equal(t, tt.expectedAllowed, allowed)
+		})
+	}
+}
+
+func TestAllowedMentions_EmptyParseSerializesAsArray(t *testing.T) {
+	data, err := json.Marshal(&AllowedMentions{Parse: []string{}})
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"parse":[]}`, string(data))
+}
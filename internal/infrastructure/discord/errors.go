@@ -0,0 +1,33 @@
+package discord
+
+import (
+	"errors"
+	"net/http"
+
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+// DestinationName identifies Discord as the destination of errors returned by an adapter
+// that was not given a name with WithDestinationName.
+const DestinationName = "discord"
+
+// classifyError maps a Discord status to the domain error reported by the service for
+// destination. The StatusError stays wrapped, so errors.As still finds it.
+func classifyError(err error, destination string) error {
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) {
+		return err
+	}
+
+	switch code := statusErr.StatusCode; {
+	case code == http.StatusTooManyRequests:
+		return apperrors.NewDestinationRateLimitedError(destination, err)
+	case code == http.StatusUnauthorized || code == http.StatusForbidden || code == http.StatusNotFound:
+		// Discord answers 404 for a deleted webhook.
+		return apperrors.NewDestinationUnauthorizedError(destination, err)
+	case code >= http.StatusBadRequest && code < http.StatusInternalServerError:
+		return apperrors.NewDestinationRejectedError(destination, err)
+	default:
+		return err
+	}
+}
@@ -0,0 +1,278 @@
+package discord
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// Interaction and response types.
+// See https://discord.com/developers/docs/interactions/receiving-and-responding
+const (
+	interactionTypePing               = 1
+	interactionTypeApplicationCommand = 2
+
+	responseTypePong                             = 1
+	responseTypeChannelMessageWithSource         = 4
+	responseTypeDeferredChannelMessageWithSource = 5
+
+	messageFlagEphemeral = 1 << 6
+)
+
+const (
+	// DefaultDeferAfter leaves headroom within Discord's 3 second deadline for the initial response.
+	DefaultDeferAfter = 2 * time.Second
+
+	// followUpTimeout bounds a deferred command; interaction tokens stay valid for 15 minutes.
+	followUpTimeout = 5 * time.Minute
+
+	// maxTimestampSkew is how far X-Signature-Timestamp may be from now, so captured
+	// requests cannot be replayed later.
+	maxTimestampSkew = 5 * time.Minute
+
+	maxInteractionBodySize = 1 << 20
+
+	apiBaseURL = "https://discord.com/api/v10"
+)
+
+type Interaction struct {
+	Data          *InteractionData `json:"data,omitempty"`
+	ApplicationID string           `json:"application_id"`
+	Token         string           `json:"token"`
+	Type          int              `json:"type"`
+}
+
+type InteractionData struct {
+	Name    string          `json:"name"`
+	Options []CommandOption `json:"options,omitempty"`
+}
+
+// CommandOption is a subcommand or argument supplied with an application command.
+type CommandOption struct {
+	Value   any             `json:"value,omitempty"`
+	Name    string          `json:"name"`
+	Options []CommandOption `json:"options,omitempty"`
+	Type    int             `json:"type"`
+}
+
+type InteractionResponse struct {
+	Data *InteractionResponseData `json:"data,omitempty"`
+	Type int                      `json:"type"`
+}
+
+type InteractionResponseData struct {
+	Content string  `json:"content,omitempty"`
+	Embeds  []Embed `json:"embeds,omitempty"`
+	Flags   int     `json:"flags,omitempty"`
+}
+
+// CommandService builds the notifications shown in reply to slash commands.
+type CommandService interface {
+	TodayNotification(ctx context.Context) (*notification.Notification, error)
+	WeeklyNotification(ctx context.Context) (*notification.Notification, error)
+	DateNotification(ctx context.Context, date time.Time) (*notification.Notification, error)
+}
+
+// InteractionHandler serves Discord's interactions endpoint for the /shinyoko command.
+// Replies are ephemeral. Commands that take longer than the defer threshold are
+// acknowledged first and answered by editing the original response when done.
+type InteractionHandler struct {
+	service    CommandService
+	clock      ports.Clock
+	client     *WebhookClient
+	publicKey  ed25519.PublicKey
+	wg         sync.WaitGroup
+	deferAfter time.Duration
+}
+
+type InteractionOption func(*InteractionHandler)
+
+// WithDeferAfter sets how long a command may run before a deferred response is sent instead.
+func WithDeferAfter(d time.Duration) InteractionOption {
+	return func(h *InteractionHandler) {
+		h.deferAfter = d
+	}
+}
+
+// WithInteractionClock sets the clock that request timestamps are checked against.
+func WithInteractionClock(clock ports.Clock) InteractionOption {
+	return func(h *InteractionHandler) {
+		h.clock = clock
+	}
+}
+
+func NewInteractionHandler(publicKey ed25519.PublicKey, service CommandService, opts ...InteractionOption) *InteractionHandler {
+	h := &InteractionHandler{
+		service:    service,
+		clock:      ports.ClockFunc(time.Now),
+		client:     NewWebhookClient(),
+		publicKey:  publicKey,
+		deferAfter: DefaultDeferAfter,
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *InteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxInteractionBodySize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	timestamp := r.Header.Get("X-Signature-Timestamp")
+	if !VerifySignature(h.publicKey, r.Header.Get("X-Signature-Ed25519"), timestamp, body) {
+		http.Error(w, "invalid request signature", http.StatusUnauthorized)
+		return
+	}
+	if !h.isRecent(timestamp) {
+		http.Error(w, "stale request timestamp", http.StatusUnauthorized)
+		return
+	}
+
+	var in Interaction
+	if err := json.Unmarshal(body, &in); err != nil {
+		http.Error(w, "invalid interaction", http.StatusBadRequest)
+		return
+	}
+
+	switch in.Type {
+	case interactionTypePing:
+		writeInteractionResponse(r.Context(), w, InteractionResponse{Type: responseTypePong})
+	case interactionTypeApplicationCommand:
+		writeInteractionResponse(r.Context(), w, h.handleCommand(r.Context(), &in))
+	default:
+		http.Error(w, "unsupported interaction type", http.StatusBadRequest)
+	}
+}
+
+// isRecent reports whether timestamp, in Unix seconds, is within maxTimestampSkew of now.
+func (h *InteractionHandler) isRecent(timestamp string) bool {
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	skew := h.clock.Now().Sub(time.Unix(sec, 0))
+	return skew <= maxTimestampSkew && skew >= -maxTimestampSkew
+}
+
+// Wait blocks until every deferred command has sent its follow-up.
+func (h *InteractionHandler) Wait() {
+	h.wg.Wait()
+}
+
+type commandFunc func(ctx context.Context) (*notification.Notification, error)
+
+func (h *InteractionHandler) handleCommand(ctx context.Context, in *Interaction) InteractionResponse {
+	run, problem := h.resolveCommand(in.Data)
+	if problem != "" {
+		return messageResponse(InteractionResponseData{Content: problem, Flags: messageFlagEphemeral})
+	}
+
+	// result is unbuffered so the command goroutine knows whether its result was taken
+	// for the initial response or has to be sent as a follow-up.
+	result := make(chan InteractionResponseData)
+	deferred := make(chan struct{})
+
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+
+		runCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), followUpTimeout)
+		defer cancel()
+
+		data := execute(runCtx, run)
+		select {
+		case result <- data:
+		case <-deferred:
+			h.followUp(runCtx, in, data)
+		}
+	}()
+
+	timer := time.NewTimer(h.deferAfter)
+	defer timer.Stop()
+
+	select {
+	case data := <-result:
+		return messageResponse(data)
+	case <-timer.C:
+		close(deferred)
+		return InteractionResponse{
+			Type: responseTypeDeferredChannelMessageWithSource,
+			Data: &InteractionResponseData{Flags: messageFlagEphemeral},
+		}
+	}
+}
+
+func (h *InteractionHandler) resolveCommand(data *InteractionData) (commandFunc, string) {
+	if data == nil || data.Name != CommandName || len(data.Options) == 0 {
+		return nil, "不明なコマンドです"
+	}
+
+	sub := data.Options[0]
+	switch sub.Name {
+	case "today":
+		return h.service.TodayNotification, ""
+	case "week":
+		return h.service.WeeklyNotification, ""
+	case "date":
+		var value string
+		for _, opt := range sub.Options {
+			if opt.Name == "date" {
+				value, _ = opt.Value.(string)
+			}
+		}
+		date, err := time.ParseInLocation("2006-01-02", value, event.JST)
+		if err != nil {
+			return nil, "日付は YYYY-MM-DD 形式で指定してください"
+		}
+		return func(ctx context.Context) (*notification.Notification, error) {
+			return h.service.DateNotification(ctx, date)
+		}, ""
+	default:
+		return nil, "不明なコマンドです"
+	}
+}
+
+// followUp replaces the deferred "thinking" response with the command's result.
+// See https://discord.com/developers/docs/interactions/receiving-and-responding#edit-original-interaction-response
+func (h *InteractionHandler) followUp(ctx context.Context, in *Interaction, data InteractionResponseData) {
+	webhookURL := apiBaseURL + "/webhooks/" + in.ApplicationID + "/" + in.Token
+	payload := &WebhookPayload{Content: data.Content, Embeds: data.Embeds}
+
+	if _, err := h.client.EditMessage(ctx, webhookURL, "@original", "", payload); err != nil {
+		logging.FromContext(ctx).Error("failed to send interaction follow-up", logging.KeyError, err)
+	}
+}
+
+func execute(ctx context.Context, run commandFunc) InteractionResponseData {
+	notif, err := run(ctx)
+	if err != nil {
+		logging.FromContext(ctx).Error("failed to run command", logging.KeyError, err)
+		return InteractionResponseData{Content: "❌ イベント情報の取得に失敗しました", Flags: messageFlagEphemeral}
+	}
+	return InteractionResponseData{Embeds: []Embed{mapNotificationToEmbed(notif)}, Flags: messageFlagEphemeral}
+}
+
+func messageResponse(data InteractionResponseData) InteractionResponse {
+	return InteractionResponse{Type: responseTypeChannelMessageWithSource, Data: &data}
+}
+
+func writeInteractionResponse(ctx context.Context, w http.ResponseWriter, resp InteractionResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		logging.FromContext(ctx).Warn("failed to write interaction response", logging.KeyError, err)
+	}
+}
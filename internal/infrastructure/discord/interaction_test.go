@@ -0,0 +1,295 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+type stubCommandService struct {
+	lastDate time.Time
+	err      error
+	block    chan struct{}
+	calls    []string
+}
+
+func (s *stubCommandService) run(name string) (*notification.Notification, error) {
+	s.calls = append(s.calls, name)
+	if s.block != nil {
+		<-s.block
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return notification.NewNotification(name, "description", notification.ColorGreen), nil
+}
+
+func (s *stubCommandService) TodayNotification(context.Context) (*notification.Notification, error) {
+	return s.run("today")
+}
+
+func (s *stubCommandService) WeeklyNotification(context.Context) (*notification.Notification, error) {
+	return s.run("week")
+}
+
+func (s *stubCommandService) DateNotification(_ context.Context, date time.Time) (*notification.Notification, error) {
+	s.lastDate = date
+	return s.run("date")
+}
+
+// testRequestTime is when signedRequest says requests were sent and what the test handler's clock reads.
+var testRequestTime = time.Unix(1700000000, 0)
+
+func newTestInteractionHandler(t *testing.T, svc CommandService, opts ...InteractionOption) (*InteractionHandler, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv := generateKey(t)
+	opts = append([]InteractionOption{WithInteractionClock(ports.ClockFunc(func() time.Time { return testRequestTime }))}, opts...)
+	return NewInteractionHandler(pub, svc, opts...), priv
+}
+
+func signedRequest(t *testing.T, priv ed25519.PrivateKey, in any) *http.Request {
+	t.Helper()
+	return signedRequestAt(t, priv, testRequestTime, in)
+}
+
+func signedRequestAt(t *testing.T, priv ed25519.PrivateKey, at time.Time, in any) *http.Request {
+	t.Helper()
+	body, err := json.Marshal(in)
+	require.NoError(t, err)
+
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	req := httptest.NewRequest(http.MethodPost, "/interactions", bytes.NewReader(body))
+	req.Header.Set("X-Signature-Timestamp", timestamp)
+	req.Header.Set("X-Signature-Ed25519", sign(priv, timestamp, body))
+	return req
+}
+
+func commandInteraction(sub string, options ...CommandOption) Interaction {
+	return Interaction{
+		Type:          interactionTypeApplicationCommand,
+		ApplicationID: "app",
+		Token:         "token",
+		Data: &InteractionData{
+			Name:    CommandName,
+			Options: []CommandOption{{Type: commandOptionSubCommand, Name: sub, Options: options}},
+		},
+	}
+}
+
+func serveInteraction(t *testing.T, h *InteractionHandler, req *http.Request) (*httptest.ResponseRecorder, InteractionResponse) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	var resp InteractionResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec, resp
+}
+
+func TestInteractionHandler_Ping(t *testing.T) {
+	h, priv := newTestInteractionHandler(t, &stubCommandService{})
+
+	rec, resp := serveInteraction(t, h, signedRequest(t, priv, Interaction{Type: interactionTypePing}))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Equal(t, responseTypePong, resp.Type)
+	assert.Nil(t, resp.Data)
+}
+
+func TestInteractionHandler_RejectsInvalidSignature(t *testing.T) {
+	svc := &stubCommandService{}
+	h, _ := newTestInteractionHandler(t, svc)
+	_, otherPriv := generateKey(t)
+
+	rec, _ := serveInteraction(t, h, signedRequest(t, otherPriv, commandInteraction("today")))
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Empty(t, svc.calls)
+}
+
+func TestInteractionHandler_RejectsMissingSignature(t *testing.T) {
+	h, _ := newTestInteractionHandler(t, &stubCommandService{})
+	req := httptest.NewRequest(http.MethodPost, "/interactions", bytes.NewReader([]byte(`{"type":1}`)))
+
+	rec, _ := serveInteraction(t, h, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestInteractionHandler_TimestampWindow(t *testing.T) {
+	testCases := []struct {
+		sentAt   time.Time
+		name     string
+		wantCode int
+	}{
+		{name: "within window", sentAt: testRequestTime.Add(-4 * time.Minute), wantCode: http.StatusOK},
+		{name: "replayed", sentAt: testRequestTime.Add(-6 * time.Minute), wantCode: http.StatusUnauthorized},
+		{name: "from the future", sentAt: testRequestTime.Add(6 * time.Minute), wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &stubCommandService{}
+			h, priv := newTestInteractionHandler(t, svc)
+
+			rec, _ := serveInteraction(t, h, signedRequestAt(t, priv, tc.sentAt, commandInteraction("today")))
+
+			assert.Equal(t, tc.wantCode, rec.Code)
+			if tc.wantCode != http.StatusOK {
+				assert.Empty(t, svc.calls)
+			}
+		})
+	}
+}
+
+func TestInteractionHandler_Commands(t *testing.T) {
+	testCases := []struct {
+		name        string
+		wantCall    string
+		interaction Interaction
+	}{
+		{name: "today", interaction: commandInteraction("today"), wantCall: "today"},
+		{name: "week", interaction: commandInteraction("week"), wantCall: "week"},
+		{
+			name:        "date",
+			interaction: commandInteraction("date", CommandOption{Type: commandOptionString, Name: "date", Value: "2026-11-03"}),
+			wantCall:    "date",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &stubCommandService{}
+			h, priv := newTestInteractionHandler(t, svc)
+
+			rec, resp := serveInteraction(t, h, signedRequest(t, priv, tc.interaction))
+
+			require.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, []string{tc.wantCall}, svc.calls)
+			assert.Equal(t, responseTypeChannelMessageWithSource, resp.Type)
+			require.NotNil(t, resp.Data)
+			assert.Equal(t, messageFlagEphemeral, resp.Data.Flags)
+			require.Len(t, resp.Data.Embeds, 1)
+			assert.Equal(t, tc.wantCall, resp.Data.Embeds[0].Title)
+		})
+	}
+}
+
+func TestInteractionHandler_DateIsParsedInJST(t *testing.T) {
+	svc := &stubCommandService{}
+	h, priv := newTestInteractionHandler(t, svc)
+
+	serveInteraction(t, h, signedRequest(t, priv,
+		commandInteraction("date", CommandOption{Type: commandOptionString, Name: "date", Value: "2026-11-03"})))
+
+	assert.True(t, time.Date(2026, 11, 3, 0, 0, 0, 0, event.JST).Equal(svc.lastDate))
+}
+
+func TestInteractionHandler_InvalidInput(t *testing.T) {
+	testCases := []struct {
+		name        string
+		wantContent string
+		interaction Interaction
+	}{
+		{
+			name:        "malformed date",
+			interaction: commandInteraction("date", CommandOption{Type: commandOptionString, Name: "date", Value: "11/3"}),
+			wantContent: "日付は YYYY-MM-DD 形式で指定してください",
+		},
+		{
+			name:        "unknown subcommand",
+			interaction: commandInteraction("month"),
+			wantContent: "不明なコマンドです",
+		},
+		{
+			name:        "unknown command",
+			interaction: Interaction{Type: interactionTypeApplicationCommand, Data: &InteractionData{Name: "other"}},
+			wantContent: "不明なコマンドです",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &stubCommandService{}
+			h, priv := newTestInteractionHandler(t, svc)
+
+			_, resp := serveInteraction(t, h, signedRequest(t, priv, tc.interaction))
+
+			assert.Empty(t, svc.calls)
+			assert.Equal(t, responseTypeChannelMessageWithSource, resp.Type)
+			require.NotNil(t, resp.Data)
+			assert.Equal(t, tc.wantContent, resp.Data.Content)
+			assert.Equal(t, messageFlagEphemeral, resp.Data.Flags)
+		})
+	}
+}
+
+func TestInteractionHandler_CommandError(t *testing.T) {
+	h, priv := newTestInteractionHandler(t, &stubCommandService{err: errors.New("timeout")})
+
+	_, resp := serveInteraction(t, h, signedRequest(t, priv, commandInteraction("today")))
+
+	require.NotNil(t, resp.Data)
+	assert.Equal(t, "❌ イベント情報の取得に失敗しました", resp.Data.Content)
+	assert.Empty(t, resp.Data.Embeds)
+}
+
+func TestInteractionHandler_DefersSlowCommands(t *testing.T) {
+	svc := &stubCommandService{block: make(chan struct{})}
+	h, priv := newTestInteractionHandler(t, svc, WithDeferAfter(10*time.Millisecond))
+
+	var followUp *http.Request
+	var followUpBody []byte
+	h.client = newTestClient(func(req *http.Request) (*http.Response, error) {
+		followUp = req
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		followUpBody = body
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString(`{"id":"1"}`))}, nil
+	})
+
+	_, resp := serveInteraction(t, h, signedRequest(t, priv, commandInteraction("week")))
+
+	assert.Equal(t, responseTypeDeferredChannelMessageWithSource, resp.Type)
+	require.NotNil(t, resp.Data)
+	assert.Equal(t, messageFlagEphemeral, resp.Data.Flags)
+	assert.Nil(t, followUp)
+
+	close(svc.block)
+	h.Wait()
+
+	require.NotNil(t, followUp)
+	assert.Equal(t, http.MethodPatch, followUp.Method)
+	assert.Equal(t, "https://discord.com/api/v10/webhooks/app/token/messages/@original", followUp.URL.String())
+
+	var payload WebhookPayload
+	require.NoError(t, json.Unmarshal(followUpBody, &payload))
+	require.Len(t, payload.Embeds, 1)
+	assert.Equal(t, "week", payload.Embeds[0].Title)
+}
+
+func TestInteractionHandler_UnsupportedType(t *testing.T) {
+	h, priv := newTestInteractionHandler(t, &stubCommandService{})
+
+	rec, _ := serveInteraction(t, h, signedRequest(t, priv, Interaction{Type: 3}))
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,96 @@
+package discord
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// StoredMessage identifies a previously posted message so it can be edited later.
+type StoredMessage struct {
+	ID string `json:"id"`
+	// ThreadID is set when the message was posted into a thread or forum post.
+	ThreadID string `json:"thread_id,omitempty"`
+}
+
+// MessageStore remembers posted messages by notification edit key.
+type MessageStore interface {
+	Load(ctx context.Context, key string) (StoredMessage, bool, error)
+	Save(ctx context.Context, key string, msg StoredMessage) error
+}
+
+// FileMessageStore keeps messages in a single JSON file.
+type FileMessageStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileMessageStore(path string) *FileMessageStore {
+	return &FileMessageStore{path: path}
+}
+
+func (s *FileMessageStore) Load(_ context.Context, key string) (StoredMessage, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages, err := s.read()
+	if err != nil {
+		return StoredMessage{}, false, err
+	}
+
+	msg, ok := messages[key]
+	return msg, ok, nil
+}
+
+func (s *FileMessageStore) Save(_ context.Context, key string, msg StoredMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	messages, err := s.read()
+	if err != nil {
+		return err
+	}
+	messages[key] = msg
+
+	data, err := json.MarshalIndent(messages, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal message store: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create message store directory: %w", err)
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated store behind.
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write message store: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write message store: %w", err)
+	}
+
+	return nil
+}
+
+func (s *FileMessageStore) read() (map[string]StoredMessage, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return make(map[string]StoredMessage), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message store: %w", err)
+	}
+
+	messages := make(map[string]StoredMessage)
+	if err := json.Unmarshal(data, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse message store: %w", err)
+	}
+
+	return messages, nil
+}
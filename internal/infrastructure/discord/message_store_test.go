@@ -0,0 +1,88 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileMessageStore_SaveAndLoad(t *testing.T) {
+	store := NewFileMessageStore(filepath.Join(t.TempDir(), "state", "messages.json"))
+	ctx := context.Background()
+
+	_, ok, err := store.Load(ctx, "discord/daily/2026-10-18")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.Save(ctx, "discord/daily/2026-10-18", StoredMessage{ID: "42"}))
+	require.NoError(t, store.Save(ctx, "discord/weekly/2026-10-18", StoredMessage{ID: "43", ThreadID: "555"}))
+
+	reopened := NewFileMessageStore(store.path)
+	daily, ok, err := reopened.Load(ctx, "discord/daily/2026-10-18")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, StoredMessage{ID: "42"}, daily)
+
+	weekly, ok, err := reopened.Load(ctx, "discord/weekly/2026-10-18")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, StoredMessage{ID: "43", ThreadID: "555"}, weekly)
+}
+
+func TestFileMessageStore_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "messages.json")
+	require.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+
+	_, _, err := NewFileMessageStore(path).Load(context.Background(), "key")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse message store")
+}
+
+type fakeS3Client struct {
+	objects map[string][]byte
+}
+
+func (c *fakeS3Client) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	data, ok := c.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (c *fakeS3Client) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.objects[*params.Bucket+"/"+*params.Key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3MessageStore_SaveAndLoad(t *testing.T) {
+	client := &fakeS3Client{objects: map[string][]byte{}}
+	store := NewS3MessageStore(client, "bucket", "discord-messages/")
+	ctx := context.Background()
+
+	_, ok, err := store.Load(ctx, "discord/daily/2026-10-18")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, store.Save(ctx, "discord/daily/2026-10-18", StoredMessage{ID: "42", ThreadID: "555"}))
+
+	reopened := NewS3MessageStore(client, "bucket", "discord-messages/")
+	msg, ok, err := reopened.Load(ctx, "discord/daily/2026-10-18")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, StoredMessage{ID: "42", ThreadID: "555"}, msg)
+	assert.Contains(t, client.objects, "bucket/discord-messages/discord/daily/2026-10-18.json")
+}
@@ -0,0 +1,104 @@
+package discord
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+type S3Client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// S3MessageStore keeps one object per edit key, so reruns on a different Lambda instance
+// can still edit the message.
+type S3MessageStore struct {
+	client S3Client
+	bucket string
+	prefix string
+	mu     sync.Mutex
+}
+
+// NewS3MessageStore stores messages under prefix in bucket.
+// A nil client is replaced by one built from the default AWS configuration on first use.
+func NewS3MessageStore(client S3Client, bucket, prefix string) *S3MessageStore {
+	return &S3MessageStore{client: client, bucket: bucket, prefix: prefix}
+}
+
+func (s *S3MessageStore) Load(ctx context.Context, key string) (StoredMessage, bool, error) {
+	client, err := s.s3Client(ctx)
+	if err != nil {
+		return StoredMessage{}, false, err
+	}
+
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(s.prefix + key + ".json"),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return StoredMessage{}, false, nil
+		}
+		return StoredMessage{}, false, fmt.Errorf("failed to get stored message: %w", err)
+	}
+	defer out.Body.Close()
+
+	data, err := io.ReadAll(out.Body)
+	if err != nil {
+		return StoredMessage{}, false, fmt.Errorf("failed to read stored message: %w", err)
+	}
+
+	var msg StoredMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return StoredMessage{}, false, fmt.Errorf("failed to parse stored message: %w", err)
+	}
+	return msg, true, nil
+}
+
+func (s *S3MessageStore) Save(ctx context.Context, key string, msg StoredMessage) error {
+	client, err := s.s3Client(ctx)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal stored message: %w", err)
+	}
+
+	_, err = client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(s.bucket),
+		Key:         aws.String(s.prefix + key + ".json"),
+		Body:        bytes.NewReader(data),
+		ContentType: aws.String("application/json"),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put stored message: %w", err)
+	}
+	return nil
+}
+
+func (s *S3MessageStore) s3Client(ctx context.Context) (S3Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client == nil {
+		cfg, err := config.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		s.client = s3.NewFromConfig(cfg)
+	}
+	return s.client, nil
+}
@@ -0,0 +1,36 @@
+package discord
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// ParsePublicKey decodes the hex-encoded application public key shown in the Discord
+// developer portal.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid public key: wrong length")
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// VerifySignature checks the X-Signature-Ed25519 header of an interaction request,
+// which signs the X-Signature-Timestamp header followed by the raw body.
+// See https://discord.com/developers/docs/interactions/overview#setting-up-an-endpoint-validating-security-request-headers
+func VerifySignature(publicKey ed25519.PublicKey, signature, timestamp string, body []byte) bool {
+	sig, err := hex.DecodeString(signature)
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return false
+	}
+
+	msg := make([]byte, 0, len(timestamp)+len(body))
+	msg = append(msg, timestamp...)
+	msg = append(msg, body...)
+	return ed25519.Verify(publicKey, msg, sig)
+}
@@ -0,0 +1,66 @@
+package discord
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func generateKey(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	return pub, priv
+}
+
+func sign(priv ed25519.PrivateKey, timestamp string, body []byte) string {
+	return hex.EncodeToString(ed25519.Sign(priv, append([]byte(timestamp), body...)))
+}
+
+func TestParsePublicKey(t *testing.T) {
+	pub, _ := generateKey(t)
+
+	key, err := ParsePublicKey(hex.EncodeToString(pub))
+
+	require.NoError(t, err)
+	assert.Equal(t, pub, key)
+}
+
+func TestParsePublicKey_Invalid(t *testing.T) {
+	for _, s := range []string{"not-hex", "abcd"} {
+		_, err := ParsePublicKey(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	pub, priv := generateKey(t)
+	otherPub, _ := generateKey(t)
+	body := []byte(`{"type":1}`)
+	sig := sign(priv, "1700000000", body)
+
+	testCases := []struct {
+		name      string
+		key       ed25519.PublicKey
+		signature string
+		timestamp string
+		body      []byte
+		want      bool
+	}{
+		{name: "valid", key: pub, signature: sig, timestamp: "1700000000", body: body, want: true},
+		{name: "tampered body", key: pub, signature: sig, timestamp: "1700000000", body: []byte(`{"type":2}`)},
+		{name: "different timestamp", key: pub, signature: sig, timestamp: "1700000001", body: body},
+		{name: "different key", key: otherPub, signature: sig, timestamp: "1700000000", body: body},
+		{name: "malformed signature", key: pub, signature: "zz", timestamp: "1700000000", body: body},
+		{name: "empty signature", key: pub, signature: "", timestamp: "1700000000", body: body},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, VerifySignature(tc.key, tc.signature, tc.timestamp, tc.body))
+		})
+	}
+}
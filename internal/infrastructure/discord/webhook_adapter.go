@@ -2,34 +2,263 @@ package discord
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
 type WebhookAdapter struct {
 	client     *WebhookClient
+	store      MessageStore
+	urls       WebhookURLProvider
 	webhookURL string
+	// destination names the configured destination in the errors the adapter returns.
+	destination string
+	username    string
+	avatarURL   string
+	threadID    string
+	// forumThreads posts each notification with a topic as a new forum thread named after it.
+	forumThreads bool
+	wait         bool
 }
 
-func NewWebhookAdapter(webhookURL string) ports.NotificationSender {
-	return &WebhookAdapter{
-		client:     NewWebhookClient(),
-		webhookURL: webhookURL,
+type AdapterOption func(*WebhookAdapter)
+
+// WebhookURLProvider supplies the webhook URL on every send so a rotated URL is picked up
+// without restarting.
+type WebhookURLProvider interface {
+	WebhookURL(ctx context.Context) (string, error)
+	// Refresh discards any cached URL; it is called when Discord reports the webhook as gone.
+	Refresh()
+}
+
+// WithWebhookURLProvider replaces the fixed webhook URL with one looked up before each send.
+// When Discord answers 401 or 404, the provider is refreshed and the send retried once
+// if the URL changed.
+func WithWebhookURLProvider(p WebhookURLProvider) AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.urls = p
+	}
+}
+
+// WithDestinationName sets the destination name carried by the errors the adapter
+// returns, so a failure can be traced to one of several webhooks.
+func WithDestinationName(name string) AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.destination = name
+	}
+}
+
+// WithUsername overrides the webhook's default display name.
+func WithUsername(username string) AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.username = username
+	}
+}
+
+// WithAvatarURL overrides the webhook's default avatar.
+func WithAvatarURL(avatarURL string) AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.avatarURL = avatarURL
+	}
+}
+
+// WithThreadID posts into an existing thread instead of the channel itself.
+func WithThreadID(threadID string) AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.threadID = threadID
+	}
+}
+
+// WithForumThreads is for webhooks of forum channels: notifications with a topic
+// (e.g. the weekly digest) start a new thread named after that topic.
+func WithForumThreads() AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.forumThreads = true
+	}
+}
+
+// WithWait asks Discord to return the created message so its ID can be recorded.
+func WithWait() AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.wait = true
+	}
+}
+
+// WithMessageStore enables edit-in-place: notifications with an edit key update the
+// message previously posted under that key instead of posting a new one.
+func WithMessageStore(store MessageStore) AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.store = store
 	}
 }
 
+// WithMetrics counts Discord's responses by HTTP status code.
+func WithMetrics(m ports.Metrics) AdapterOption {
+	return func(a *WebhookAdapter) {
+		a.client.metrics = m
+	}
+}
+
+func NewWebhookAdapter(webhookURL string, opts ...AdapterOption) ports.NotificationSender {
+	a := &WebhookAdapter{
+		client:      NewWebhookClient(),
+		webhookURL:  webhookURL,
+		destination: DestinationName,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
 func (a *WebhookAdapter) Send(ctx context.Context, notif *notification.Notification) error {
-	embed := mapNotificationToEmbed(notif)
+	key := notif.EditKey()
+	editable := a.store != nil && key != ""
 
-	payload := &WebhookPayload{
-		Embeds: []Embed{embed},
+	if editable {
+		edited, err := a.editPrevious(ctx, key, notif)
+		if err != nil {
+			return err
+		}
+		if edited {
+			return nil
+		}
+	}
+
+	payload := a.buildPayload(notif)
+
+	params := ExecuteParams{ThreadID: a.threadID, Wait: a.wait || editable}
+	if payload.ThreadName != "" {
+		params.ThreadID = ""
+	}
+
+	var msg *Message
+	err := a.withWebhookURL(ctx, func(webhookURL string) error {
+		var err error
+		msg, err = a.client.ExecuteWithParams(ctx, webhookURL, params, payload)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("failed to send Discord webhook: %w", classifyError(err, a.destination))
 	}
 
-	if err := a.client.Execute(ctx, a.webhookURL, payload); err != nil {
-		return fmt.Errorf("failed to send Discord webhook: %w", err)
+	if msg != nil {
+		logging.FromContext(ctx).Info("discord message posted", "message_id", msg.ID, "channel_id", msg.ChannelID)
+	}
+
+	if editable && msg != nil {
+		stored := StoredMessage{ID: msg.ID, ThreadID: params.ThreadID}
+		if payload.ThreadName != "" {
+			stored.ThreadID = msg.ChannelID
+		}
+		// The message is already posted; failing here would only make a rerun post it twice.
+		if err := a.store.Save(ctx, key, stored); err != nil {
+			logging.FromContext(ctx).Warn("failed to save discord message id", "key", key, logging.KeyError, err)
+		}
 	}
 
 	return nil
 }
+
+// editPrevious updates the message stored under key. It reports false when there is
+// nothing to edit, including when the stored message has since been deleted.
+func (a *WebhookAdapter) editPrevious(ctx context.Context, key string, notif *notification.Notification) (bool, error) {
+	stored, ok, err := a.store.Load(ctx, key)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to load discord message id", "key", key, logging.KeyError, err)
+		return false, nil
+	}
+	if !ok {
+		return false, nil
+	}
+
+	embed := mapNotificationToEmbed(notif)
+	embed.Footer = &EmbedFooter{Text: "更新: " + notif.Timestamp().In(event.JST).Format("15:04")}
+	content, allowedMentions := mapMentions(notif.Mentions())
+	payload := &WebhookPayload{
+		Content:         content,
+		AllowedMentions: allowedMentions,
+		Embeds:          []Embed{embed},
+	}
+
+	err = a.withWebhookURL(ctx, func(webhookURL string) error {
+		_, err := a.client.EditMessage(ctx, webhookURL, stored.ID, stored.ThreadID, payload)
+		return err
+	})
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound {
+		logging.FromContext(ctx).Info("previous discord message not found, posting a new one", "key", key, "message_id", stored.ID)
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to edit Discord message: %w", classifyError(err, a.destination))
+	}
+
+	logging.FromContext(ctx).Info("discord message edited", "message_id", stored.ID)
+	return true, nil
+}
+
+func (a *WebhookAdapter) currentWebhookURL(ctx context.Context) (string, error) {
+	if a.urls == nil {
+		return a.webhookURL, nil
+	}
+
+	webhookURL, err := a.urls.WebhookURL(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve Discord webhook URL: %w", err)
+	}
+	return webhookURL, nil
+}
+
+// withWebhookURL calls fn with the current webhook URL and, if Discord no longer knows the
+// webhook, once more with a freshly loaded URL.
+func (a *WebhookAdapter) withWebhookURL(ctx context.Context, fn func(webhookURL string) error) error {
+	webhookURL, err := a.currentWebhookURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = fn(webhookURL)
+	if a.urls == nil || !isRevoked(err) {
+		return err
+	}
+
+	a.urls.Refresh()
+	refreshed, refreshErr := a.currentWebhookURL(ctx)
+	if refreshErr != nil || refreshed == webhookURL {
+		return err
+	}
+
+	logging.FromContext(ctx).Info("discord webhook URL was rotated, retrying")
+	return fn(refreshed)
+}
+
+func isRevoked(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) &&
+		(statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusNotFound)
+}
+
+func (a *WebhookAdapter) buildPayload(notif *notification.Notification) *WebhookPayload {
+	embed := mapNotificationToEmbed(notif)
+	content, allowedMentions := mapMentions(notif.Mentions())
+
+	payload := &WebhookPayload{
+		Content:         content,
+		AllowedMentions: allowedMentions,
+		Username:        a.username,
+		AvatarURL:       a.avatarURL,
+		Embeds:          []Embed{embed},
+	}
+	if a.forumThreads {
+		payload.ThreadName = notif.Topic()
+	}
+
+	return payload
+}
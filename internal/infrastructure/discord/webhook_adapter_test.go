@@ -6,18 +6,25 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/notification"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/metrics"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
 func newTestWebhookAdapter(fn RoundTripFunc, webhookURL string) *WebhookAdapter {
 	return &WebhookAdapter{
-		client:     newTestClient(fn),
-		webhookURL: webhookURL,
+		client:      newTestClient(fn),
+		webhookURL:  webhookURL,
+		destination: DestinationName,
 	}
 }
 
@@ -95,6 +102,89 @@ func TestWebhookAdapter_Send_NonSuccessStatus(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to send Discord webhook")
 	assert.Contains(t, err.Error(), "400")
+	assert.ErrorIs(t, err, apperrors.ErrDestinationRejected)
+}
+
+func TestWebhookAdapter_Send_ErrorClassification(t *testing.T) {
+	testCases := []struct {
+		want      error
+		name      string
+		status    int
+		retryable bool
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests, want: apperrors.ErrRateLimited, retryable: true},
+		{name: "unauthorized", status: http.StatusUnauthorized, want: apperrors.ErrDestinationUnauthorized},
+		{name: "deleted webhook", status: http.StatusNotFound, want: apperrors.ErrDestinationUnauthorized},
+		{name: "rejected payload", status: http.StatusBadRequest, want: apperrors.ErrDestinationRejected},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			adapter := newTestWebhookAdapter(RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+				return &http.Response{StatusCode: tc.status, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+			}), "https://discord.com/api/webhooks/123/abc")
+
+			err := adapter.Send(context.Background(), notification.NewNotification("Test", "Test", notification.ColorRed))
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.want)
+			assert.Equal(t, tc.retryable, apperrors.IsRetryable(err))
+			var statusErr *StatusError
+			require.ErrorAs(t, err, &statusErr)
+			assert.Equal(t, tc.status, statusErr.StatusCode)
+		})
+	}
+}
+
+func TestWebhookAdapter_Send_ErrorNamesDestination(t *testing.T) {
+	adapter := newTestWebhookAdapter(RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+	}), "https://discord.com/api/webhooks/123/abc")
+	WithDestinationName("alerts")(adapter)
+
+	err := adapter.Send(context.Background(), notification.NewNotification("Test", "Test", notification.ColorRed))
+
+	domainErr, ok := apperrors.AsDomainError(err)
+	require.True(t, ok)
+	assert.Equal(t, "alerts", domainErr.Destination)
+}
+
+func TestWebhookAdapter_Send_ServerErrorIsNotClassified(t *testing.T) {
+	adapter := newTestWebhookAdapter(RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(bytes.NewBuffer(nil))}, nil
+	}), "https://discord.com/api/webhooks/123/abc")
+
+	err := adapter.Send(context.Background(), notification.NewNotification("Test", "Test", notification.ColorRed))
+
+	require.Error(t, err)
+	_, ok := apperrors.AsDomainError(err)
+	assert.False(t, ok)
+}
+
+func TestWebhookAdapter_Send_RecordsStatusMetrics(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+	statuses := []int{http.StatusTooManyRequests, http.StatusNoContent}
+
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		status := statuses[0]
+		statuses = statuses[1:]
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}, nil
+	})
+
+	recorder := metrics.NewMemory()
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithMetrics(recorder)(adapter)
+	notif := notification.NewNotification("Test", "Test", notification.ColorGreen)
+
+	require.Error(t, adapter.Send(logging.WithMode(context.Background(), "weekly"), notif))
+	require.NoError(t, adapter.Send(context.Background(), notif))
+
+	assert.Equal(t, []float64{1}, recorder.Find("DiscordResponses", map[string]string{"status_code": "429", "mode": "weekly"}))
+	assert.Equal(t, []float64{1}, recorder.Find("DiscordResponses", map[string]string{"status_code": "204"}))
+	assert.Empty(t, recorder.Find("DiscordResponses", map[string]string{"status_code": "204", "mode": "weekly"}))
 }
 
 func TestWebhookAdapter_Send_EmbedMapping(t *testing.T) {
@@ -132,6 +222,155 @@ func TestWebhookAdapter_Send_EmbedMapping(t *testing.T) {
 	assert.Contains(t, bodyStr, "Value3")
 }
 
+func TestWebhookAdapter_Send_MentionHere(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+
+	var capturedBody []byte
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var err error
+		capturedBody, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBuffer([]byte(""))),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	notif := notification.NewNotification("Title", "Description", notification.ColorRed)
+	notif.AddMention(notification.HereMention())
+	notif.AddMention(notification.RoleMention("111"))
+
+	err := adapter.Send(context.Background(), notif)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(capturedBody), `"content":"@here \u003c@\u0026111\u003e"`)
+	assert.Contains(t, string(capturedBody), `"allowed_mentions":{"parse":["everyone"],"roles":["111"]}`)
+}
+
+func TestWebhookAdapter_Send_NoMentionsDisablesPings(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+
+	var capturedBody []byte
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var err error
+		capturedBody, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBuffer([]byte(""))),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	notif := notification.NewNotification("@everyone Title", "Description", notification.ColorRed)
+
+	err := adapter.Send(context.Background(), notif)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(capturedBody), `"allowed_mentions":{"parse":[]}`)
+	assert.NotContains(t, string(capturedBody), `"content"`)
+}
+
+func TestWebhookAdapter_Send_Identity(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+
+	var capturedBody []byte
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var err error
+		capturedBody, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithUsername("新横浜bot")(adapter)
+	WithAvatarURL("https://example.com/avatar.png")(adapter)
+
+	err := adapter.Send(context.Background(), notification.NewNotification("Title", "", notification.ColorGreen))
+
+	require.NoError(t, err)
+	assert.Contains(t, string(capturedBody), `"username":"新横浜bot"`)
+	assert.Contains(t, string(capturedBody), `"avatar_url":"https://example.com/avatar.png"`)
+}
+
+func TestWebhookAdapter_Send_ThreadAndWait(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "999", req.URL.Query().Get("thread_id"))
+		assert.Equal(t, "true", req.URL.Query().Get("wait"))
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"id":"42","channel_id":"999"}`)),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithThreadID("999")(adapter)
+	WithWait()(adapter)
+
+	err := adapter.Send(context.Background(), notification.NewNotification("Title", "", notification.ColorGreen))
+
+	require.NoError(t, err)
+}
+
+func TestWebhookAdapter_Send_ForumThreadFromTopic(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+
+	var capturedReq *http.Request
+	var capturedBody []byte
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var err error
+		capturedReq = req
+		capturedBody, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithForumThreads()(adapter)
+	WithThreadID("999")(adapter)
+	notif := notification.NewNotification("Title", "", notification.ColorGreen)
+	notif.SetTopic("10/19週")
+
+	err := adapter.Send(context.Background(), notif)
+
+	require.NoError(t, err)
+	assert.Contains(t, string(capturedBody), `"thread_name":"10/19週"`)
+	assert.Empty(t, capturedReq.URL.Query().Get("thread_id"))
+}
+
+func TestWebhookAdapter_Send_TopicIgnoredWithoutForum(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+
+	var capturedBody []byte
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var err error
+		capturedBody, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	notif := notification.NewNotification("Title", "", notification.ColorGreen)
+	notif.SetTopic("10/19週")
+
+	err := adapter.Send(context.Background(), notif)
+
+	require.NoError(t, err)
+	assert.NotContains(t, string(capturedBody), "thread_name")
+}
+
 func TestWebhookAdapter_Send_ContextPropagation(t *testing.T) {
 	webhookURL := "https://discord.com/api/webhooks/123/abc"
 
@@ -175,3 +414,273 @@ func TestWebhookAdapter_Send_ContextCancellation(t *testing.T) {
 	require.Error(t, err)
 	assert.ErrorIs(t, err, context.Canceled)
 }
+
+func newEditableNotification(t *testing.T) *notification.Notification {
+	t.Helper()
+	notif := notification.NewNotification("Title", "", notification.ColorGreen)
+	notif.SetEditKey("discord/daily/2026-10-18")
+	return notif
+}
+
+func TestWebhookAdapter_Send_SavesMessageForEditKey(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+	store := NewFileMessageStore(filepath.Join(t.TempDir(), "messages.json"))
+
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "true", req.URL.Query().Get("wait"))
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"id":"42","channel_id":"7"}`)),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithMessageStore(store)(adapter)
+
+	err := adapter.Send(context.Background(), newEditableNotification(t))
+
+	require.NoError(t, err)
+	stored, ok, err := store.Load(context.Background(), "discord/daily/2026-10-18")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, StoredMessage{ID: "42"}, stored)
+}
+
+func TestWebhookAdapter_Send_EditsStoredMessage(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+	store := NewFileMessageStore(filepath.Join(t.TempDir(), "messages.json"))
+	require.NoError(t, store.Save(context.Background(), "discord/daily/2026-10-18", StoredMessage{ID: "42", ThreadID: "999"}))
+
+	var capturedReq *http.Request
+	var capturedBody []byte
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var err error
+		capturedReq = req
+		capturedBody, err = io.ReadAll(req.Body)
+		require.NoError(t, err)
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"id":"42","channel_id":"999"}`)),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithMessageStore(store)(adapter)
+	WithUsername("bot")(adapter)
+
+	notif := newEditableNotification(t)
+	err := adapter.Send(context.Background(), notif)
+
+	require.NoError(t, err)
+	require.NotNil(t, capturedReq)
+	assert.Equal(t, http.MethodPatch, capturedReq.Method)
+	assert.Equal(t, "https://discord.com/api/webhooks/123/abc/messages/42?thread_id=999", capturedReq.URL.String())
+	wantFooter := "更新: " + notif.Timestamp().In(event.JST).Format("15:04")
+	assert.Contains(t, string(capturedBody), `"footer":{"text":"`+wantFooter+`"}`)
+	assert.NotContains(t, string(capturedBody), "username")
+}
+
+func TestWebhookAdapter_Send_PostsAgainWhenStoredMessageDeleted(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+	store := NewFileMessageStore(filepath.Join(t.TempDir(), "messages.json"))
+	require.NoError(t, store.Save(context.Background(), "discord/daily/2026-10-18", StoredMessage{ID: "42"}))
+
+	var methods []string
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		methods = append(methods, req.Method)
+		if req.Method == http.MethodPatch {
+			return &http.Response{
+				StatusCode: 404,
+				Body:       io.NopCloser(bytes.NewBufferString("Unknown Message")),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"id":"43","channel_id":"7"}`)),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithMessageStore(store)(adapter)
+
+	err := adapter.Send(context.Background(), newEditableNotification(t))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{http.MethodPatch, http.MethodPost}, methods)
+	stored, _, err := store.Load(context.Background(), "discord/daily/2026-10-18")
+	require.NoError(t, err)
+	assert.Equal(t, "43", stored.ID)
+}
+
+func TestWebhookAdapter_Send_EditError(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+	store := NewFileMessageStore(filepath.Join(t.TempDir(), "messages.json"))
+	require.NoError(t, store.Save(context.Background(), "discord/daily/2026-10-18", StoredMessage{ID: "42"}))
+
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 500,
+			Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithMessageStore(store)(adapter)
+
+	err := adapter.Send(context.Background(), newEditableNotification(t))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to edit Discord message")
+}
+
+func TestWebhookAdapter_Send_ForumThreadStoresThreadID(t *testing.T) {
+	webhookURL := "https://discord.com/api/webhooks/123/abc"
+	store := NewFileMessageStore(filepath.Join(t.TempDir(), "messages.json"))
+
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"id":"42","channel_id":"555"}`)),
+		}, nil
+	})
+
+	adapter := newTestWebhookAdapter(mockTransport, webhookURL)
+	WithMessageStore(store)(adapter)
+	WithForumThreads()(adapter)
+
+	notif := newEditableNotification(t)
+	notif.SetTopic("10/19週")
+	err := adapter.Send(context.Background(), notif)
+
+	require.NoError(t, err)
+	stored, _, err := store.Load(context.Background(), "discord/daily/2026-10-18")
+	require.NoError(t, err)
+	assert.Equal(t, StoredMessage{ID: "42", ThreadID: "555"}, stored)
+}
+
+type stubURLProvider struct {
+	urls      []string
+	refreshed int
+}
+
+func (p *stubURLProvider) WebhookURL(_ context.Context) (string, error) {
+	return p.urls[0], nil
+}
+
+func (p *stubURLProvider) Refresh() {
+	p.refreshed++
+	if len(p.urls) > 1 {
+		p.urls = p.urls[1:]
+	}
+}
+
+func TestWebhookAdapter_Send_RetriesWithRotatedURL(t *testing.T) {
+	var requested []string
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.String())
+		if req.URL.Path == "/api/webhooks/123/old" {
+			return &http.Response{
+				StatusCode: 401,
+				Body:       io.NopCloser(bytes.NewBufferString("Invalid Webhook Token")),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 204,
+			Body:       io.NopCloser(bytes.NewBuffer(nil)),
+		}, nil
+	})
+
+	provider := &stubURLProvider{urls: []string{
+		"https://discord.com/api/webhooks/123/old",
+		"https://discord.com/api/webhooks/123/new",
+	}}
+	adapter := newTestWebhookAdapter(mockTransport, "")
+	WithWebhookURLProvider(provider)(adapter)
+
+	err := adapter.Send(context.Background(), notification.NewNotification("Title", "", notification.ColorGreen))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.refreshed)
+	assert.Equal(t, []string{
+		"https://discord.com/api/webhooks/123/old",
+		"https://discord.com/api/webhooks/123/new",
+	}, requested)
+}
+
+func TestWebhookAdapter_Send_EditRetriesWithRotatedURL(t *testing.T) {
+	store := NewFileMessageStore(filepath.Join(t.TempDir(), "messages.json"))
+	require.NoError(t, store.Save(context.Background(), "discord/daily/2026-10-18", StoredMessage{ID: "42"}))
+
+	var requested []string
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.Method+" "+req.URL.Path)
+		if strings.HasPrefix(req.URL.Path, "/api/webhooks/123/old") {
+			return &http.Response{
+				StatusCode: 401,
+				Body:       io.NopCloser(bytes.NewBufferString("Invalid Webhook Token")),
+			}, nil
+		}
+		return &http.Response{
+			StatusCode: 200,
+			Body:       io.NopCloser(bytes.NewBufferString(`{"id":"42","channel_id":"7"}`)),
+		}, nil
+	})
+
+	provider := &stubURLProvider{urls: []string{
+		"https://discord.com/api/webhooks/123/old",
+		"https://discord.com/api/webhooks/123/new",
+	}}
+	adapter := newTestWebhookAdapter(mockTransport, "")
+	WithWebhookURLProvider(provider)(adapter)
+	WithMessageStore(store)(adapter)
+
+	err := adapter.Send(context.Background(), newEditableNotification(t))
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.refreshed)
+	assert.Equal(t, []string{
+		"PATCH /api/webhooks/123/old/messages/42",
+		"PATCH /api/webhooks/123/new/messages/42",
+	}, requested)
+}
+
+func TestWebhookAdapter_Send_RevokedWithoutRotation(t *testing.T) {
+	calls := 0
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return &http.Response{
+			StatusCode: 404,
+			Body:       io.NopCloser(bytes.NewBufferString("Unknown Webhook")),
+		}, nil
+	})
+
+	provider := &stubURLProvider{urls: []string{"https://discord.com/api/webhooks/123/abc"}}
+	adapter := newTestWebhookAdapter(mockTransport, "")
+	WithWebhookURLProvider(provider)(adapter)
+
+	err := adapter.Send(context.Background(), notification.NewNotification("Title", "", notification.ColorGreen))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook returned non-success status: 404")
+	assert.Equal(t, 1, provider.refreshed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWebhookAdapter_Send_ServerErrorDoesNotRefresh(t *testing.T) {
+	mockTransport := RoundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: 500,
+			Body:       io.NopCloser(bytes.NewBufferString("Internal Server Error")),
+		}, nil
+	})
+
+	provider := &stubURLProvider{urls: []string{"https://discord.com/api/webhooks/123/abc"}}
+	adapter := newTestWebhookAdapter(mockTransport, "")
+	WithWebhookURLProvider(provider)(adapter)
+
+	err := adapter.Send(context.Background(), notification.NewNotification("Title", "", notification.ColorGreen))
+
+	require.Error(t, err)
+	assert.Zero(t, provider.refreshed)
+}
@@ -0,0 +1,80 @@
+package fetcher
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
+)
+
+// newTransport wraps next, or the default transport when nil, so every request is traced
+// and recorded in the diagnostics report.
+func newTransport(next http.RoundTripper) http.RoundTripper {
+	return diagnosticsTransport{next: otelhttp.NewTransport(next)}
+}
+
+// diagnosticsTransport records every request in the diagnostics report carried by the
+// request context. The request is recorded when the body is closed, so the duration and
+// size cover the whole download.
+type diagnosticsTransport struct {
+	next http.RoundTripper
+}
+
+func (t diagnosticsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	report := diagnostics.FromContext(req.Context())
+	if report == nil {
+		return next.RoundTrip(req)
+	}
+
+	start := time.Now()
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		report.RecordRequest(diagnostics.Request{
+			URL:        req.URL.String(),
+			Error:      err.Error(),
+			DurationMS: time.Since(start).Milliseconds(),
+		})
+		return nil, err
+	}
+
+	resp.Body = &countingBody{
+		ReadCloser: resp.Body,
+		onClose: func(n int64) {
+			report.RecordRequest(diagnostics.Request{
+				URL:        req.URL.String(),
+				Status:     resp.StatusCode,
+				DurationMS: time.Since(start).Milliseconds(),
+				Bytes:      n,
+			})
+		},
+	}
+	return resp, nil
+}
+
+type countingBody struct {
+	io.ReadCloser
+	onClose func(n int64)
+	n       int64
+	once    sync.Once
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() { b.onClose(b.n) })
+	return err
+}
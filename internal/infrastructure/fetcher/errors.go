@@ -0,0 +1,21 @@
+package fetcher
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+)
+
+// statusError classifies an unexpected HTTP status from the site of venue. Server errors
+// are usually transient, while other client errors need the fetcher to be fixed.
+func statusError(venue event.VenueID, status int) error {
+	err := fmt.Errorf("unexpected status code: %d", status)
+	if status == http.StatusTooManyRequests {
+		return apperrors.NewSourceRateLimitedError(string(venue), err)
+	}
+	unavailable := apperrors.NewSourceUnavailableError(string(venue), err)
+	unavailable.Retryable = status >= http.StatusInternalServerError || status == http.StatusRequestTimeout
+	return unavailable
+}
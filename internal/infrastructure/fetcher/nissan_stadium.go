@@ -5,25 +5,36 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/gocolly/colly/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/sync/semaphore"
 
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
 type NissanStadiumFetcher struct {
-	baseURL string
+	transport http.RoundTripper
+	clock     ports.Clock
+	baseURL   string
 }
 
-func NewNissanStadiumFetcher() ports.EventFetcher {
+func NewNissanStadiumFetcher(opts ...Option) ports.EventFetcher {
+	o := newOptions(opts)
 	return &NissanStadiumFetcher{
-		baseURL: "https://www.nissan-stadium.jp",
+		baseURL:   "https://www.nissan-stadium.jp",
+		transport: o.transport,
+		clock:     o.clock,
 	}
 }
 
@@ -34,72 +45,107 @@ type eventCandidate struct {
 
 var (
 	errNotForNissanStadium = errors.New("event is not for Nissan Stadium")
-	errRangeExceedsLimit   = errors.New("date range exceeds the supported 2-month window")
+	errMonthNotListed      = errors.New("month is not on the calendar")
 )
 
-func (s *NissanStadiumFetcher) FetchEvents(ctx context.Context, from, to time.Time) ([]event.Event, error) {
-	jst := time.FixedZone("JST", 9*60*60)
-	from = from.In(jst)
-	to = to.In(jst)
+// The calendar publishes two months: the current one and, with m=x, the next one. It has
+// no other month navigation and no archive of past months.
+const (
+	calendarPath          = "/calendar/"
+	nextMonthCalendarPath = "/calendar/?m=x"
+)
+
+// detailConcurrency limits how many event detail pages are downloaded at once.
+const detailConcurrency = 5
 
-	if distinctMonthCount(from, to) > 2 {
-		return nil, errRangeExceedsLimit
+// Capabilities reports the calendar's two-month window. Event pages list a start time only.
+func (s *NissanStadiumFetcher) Capabilities() ports.Capabilities {
+	return ports.Capabilities{
+		Earliest:              s.currentMonth(),
+		Horizon:               s.horizon(),
+		MaxConcurrentRequests: detailConcurrency,
 	}
+}
+
+// horizon is the last day of next month, the last month the calendar lists.
+func (s *NissanStadiumFetcher) horizon() time.Time {
+	return endOfMonth(s.currentMonth().AddDate(0, 1, 0))
+}
+
+func (s *NissanStadiumFetcher) currentMonth() time.Time {
+	loc := event.JST
+	now := s.clock.Now().In(loc)
+	return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+}
+
+// FetchEvents returns the events between from and to, which must lie within the months
+// the calendar lists, as advertised by Capabilities.
+func (s *NissanStadiumFetcher) FetchEvents(ctx context.Context, from, to time.Time) ([]event.Event, error) {
+	loc := event.JST
+	from = from.In(loc)
+	to = to.In(loc)
 
-	slog.Info("fetching nissan stadium events", "from", from.Format("2006-01-02"), "to", to.Format("2006-01-02"))
+	logger := logging.FromContext(ctx)
+	logger.Info("fetching nissan stadium events", "from", from.Format("2006-01-02"), "to", to.Format("2006-01-02"))
 
 	candidates, err := s.fetchEventCandidatesForRange(ctx, from, to)
 	if err != nil {
-		slog.Error("failed to fetch event candidates", "error", err)
+		logger.Error("failed to fetch event candidates", logging.KeyError, err)
 		return nil, fmt.Errorf("failed to fetch event candidates: %w", err)
 	}
 
 	if len(candidates) == 0 {
-		slog.Info("no event candidates found", "from", from.Format("2006-01-02"), "to", to.Format("2006-01-02"))
+		logger.Info("no event candidates found", "from", from.Format("2006-01-02"), "to", to.Format("2006-01-02"))
 		return []event.Event{}, nil
 	}
 
-	slog.Info("found event candidates", "candidates", candidates)
+	logger.Info("found event candidates", "candidates", candidates)
 
 	events, err := s.fetchEventDetails(ctx, candidates, from)
 	if err != nil {
-		slog.Error("failed to fetch event details", "error", err)
+		logger.Error("failed to fetch event details", logging.KeyError, err)
 		return nil, fmt.Errorf("failed to fetch event details: %w", err)
 	}
 
-	slog.Info("fetched nissan stadium events", "count", len(events))
+	logger.Info("fetched nissan stadium events", "count", len(events))
 
 	return events, nil
 }
 
+// fetchEventCandidatesForRange reads the calendar page of every month in the range. It
+// fails for a month other than the current or next one, since the calendar has no page
+// for it.
 func (s *NissanStadiumFetcher) fetchEventCandidatesForRange(ctx context.Context, from, to time.Time) ([]eventCandidate, error) {
-	crossMonth := from.Year() != to.Year() || from.Month() != to.Month()
-
-	currentMonthTo := to
-	if crossMonth {
-		currentMonthTo = endOfMonth(from)
+	current := s.currentMonth()
+	if from.Before(current) || to.After(s.horizon()) {
+		return nil, fmt.Errorf("%w: %s to %s", errMonthNotListed, from.Format("2006-01"), to.Format("2006-01"))
 	}
 
-	candidates, err := s.fetchEventCandidatesForMonth(ctx, from, currentMonthTo, s.baseURL+"/calendar/")
-	if err != nil {
-		return nil, err
-	}
+	var candidates []eventCandidate
+	for monthFrom := from; !monthFrom.After(to); monthFrom = endOfMonth(monthFrom).AddDate(0, 0, 1) {
+		monthTo := endOfMonth(monthFrom)
+		if monthTo.After(to) {
+			monthTo = to
+		}
+
+		path := calendarPath
+		if monthFrom.Year() != current.Year() || monthFrom.Month() != current.Month() {
+			path = nextMonthCalendarPath
+		}
 
-	if crossMonth {
-		nextMonthFrom := time.Date(to.Year(), to.Month(), 1, 0, 0, 0, 0, to.Location())
-		nextMonthCandidates, err := s.fetchEventCandidatesForMonth(ctx, nextMonthFrom, to, s.baseURL+"/calendar/?m=x")
+		monthCandidates, err := s.fetchEventCandidatesForMonth(ctx, monthFrom, monthTo, s.baseURL+path)
 		if err != nil {
 			return nil, err
 		}
-		candidates = append(candidates, nextMonthCandidates...)
+		candidates = append(candidates, monthCandidates...)
 	}
 
 	return candidates, nil
 }
 
 func (s *NissanStadiumFetcher) fetchEventCandidatesForMonth(ctx context.Context, from, to time.Time, calendarURL string) ([]eventCandidate, error) {
-	c := colly.NewCollector()
-	c.SetRequestTimeout(10 * time.Second)
+	c := s.newCollector(ctx)
+	logger := logging.FromContext(ctx)
 
 	var candidates []eventCandidate
 	var currentDate int
@@ -107,13 +153,15 @@ func (s *NissanStadiumFetcher) fetchEventCandidatesForMonth(ctx context.Context,
 	targetDays := buildTargetDays(from, to)
 
 	c.OnHTML("#areacontents01 > div:nth-child(2) > table > tbody tr", func(row *colly.HTMLElement) {
-		if candidate, ok := s.parseCalendarRow(row, &currentDate, targetDays); ok {
+		if candidate, ok := s.parseCalendarRow(logger, row, &currentDate, targetDays); ok {
 			candidates = append(candidates, candidate)
 		}
 	})
 
+	var status int
 	c.OnError(func(r *colly.Response, err error) {
-		slog.Error("error during calendar scraping", "status", r.StatusCode, "error", err)
+		status = r.StatusCode
+		logger.Error("error during calendar scraping", "status", r.StatusCode, logging.KeyError, err)
 	})
 
 	var visitErr error
@@ -126,21 +174,34 @@ func (s *NissanStadiumFetcher) fetchEventCandidatesForMonth(ctx context.Context,
 		}
 	})
 
-	slog.Debug("visiting calendar page", "url", calendarURL)
+	logger.Debug("visiting calendar page", "url", calendarURL)
 
 	if err := c.Visit(calendarURL); err != nil {
-		return nil, fmt.Errorf("failed to visit calendar page: %w", err)
+		return nil, fmt.Errorf("failed to visit calendar page: %w", visitError(status, err))
 	}
 
 	if visitErr != nil {
 		return nil, fmt.Errorf("fetch nissan stadium calendar: %w", visitErr)
 	}
 
-	slog.Debug("calendar scraping completed", "candidates", len(candidates))
+	// currentDate is only set by rows whose first cell is a day of the month, so it stays
+	// zero when the calendar table is gone or restructured.
+	if currentDate == 0 {
+		return nil, apperrors.NewLayoutChangedError(string(event.VenueIDNissanStadium), "calendar page has no dated rows")
+	}
+
+	logger.Debug("calendar scraping completed", "candidates", len(candidates))
 
 	return candidates, nil
 }
 
+func (s *NissanStadiumFetcher) newCollector(ctx context.Context) *colly.Collector {
+	c := colly.NewCollector(colly.StdlibContext(ctx))
+	c.SetRequestTimeout(10 * time.Second)
+	c.WithTransport(newTransport(s.transport))
+	return c
+}
+
 func buildTargetDays(from, to time.Time) map[int]bool {
 	loc := from.Location()
 	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc)
@@ -158,11 +219,7 @@ func endOfMonth(t time.Time) time.Time {
 	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, loc)
 }
 
-func distinctMonthCount(from, to time.Time) int {
-	return (to.Year()-from.Year())*12 + int(to.Month()-from.Month()) + 1
-}
-
-func (s *NissanStadiumFetcher) parseCalendarRow(row *colly.HTMLElement, currentDate *int, targetDays map[int]bool) (eventCandidate, bool) {
+func (s *NissanStadiumFetcher) parseCalendarRow(logger *slog.Logger, row *colly.HTMLElement, currentDate *int, targetDays map[int]bool) (eventCandidate, bool) {
 	if dateStr := row.ChildText("th:nth-child(1)"); dateStr != "" {
 		var date int
 		if _, err := fmt.Sscanf(dateStr, "%d", &date); err == nil {
@@ -179,47 +236,65 @@ func (s *NissanStadiumFetcher) parseCalendarRow(row *colly.HTMLElement, currentD
 	id := extractEventID(href)
 	detailURL := fmt.Sprintf("%s/calendar/detail.php?id%s", s.baseURL, id)
 
-	slog.Debug("processing row", "date", *currentDate, "title", title, "href", href, "id", id)
+	logger.Debug("processing row", "date", *currentDate, "title", title, "href", href, "id", id)
 
 	if id == "" || title == "" {
 		return eventCandidate{}, false
 	}
 
-	slog.Debug("found event candidate", "id", id, "title", title)
+	logger.Debug("found event candidate", "id", id, "title", title)
 
 	return eventCandidate{title: title, url: detailURL}, true
 }
 
 func (s *NissanStadiumFetcher) fetchEventDetails(ctx context.Context, candidates []eventCandidate, today time.Time) ([]event.Event, error) {
-	slog.Debug("fetching event details", "candidates", len(candidates))
+	logger := logging.FromContext(ctx)
+	logger.Debug("fetching event details", "candidates", len(candidates))
+
+	ctx, span := tracer().Start(ctx, "NissanStadiumFetcher.fetchEventDetails", trace.WithAttributes(attribute.Int("candidates", len(candidates))))
+	defer span.End()
 
 	eg, ctx := errgroup.WithContext(ctx)
-	sem := semaphore.NewWeighted(5)
+	sem := semaphore.NewWeighted(detailConcurrency)
 
+	report := diagnostics.FromContext(ctx)
 	var results []event.Event
 	var errorCount int
+	var lastErr error
 	var mu sync.Mutex
 
 	for _, candidate := range candidates {
 		candidate := candidate
 		eg.Go(func() error {
+			// The span starts before the semaphore so time spent queued behind the other
+			// detail pages shows up in the trace.
+			ctx, span := tracer().Start(ctx, "NissanStadiumFetcher.fetchEventDetail", trace.WithAttributes(attribute.String("url", candidate.url)))
+			defer span.End()
+
 			if err := sem.Acquire(ctx, 1); err != nil {
 				return fmt.Errorf("acquire semaphore for event detail fetch: %w", err)
 			}
 			defer sem.Release(1)
+			span.AddEvent("semaphore acquired")
 
 			evt, err := s.fetchEventDetail(ctx, candidate, today)
+			if err != nil {
+				span.RecordError(err)
+			}
 
 			mu.Lock()
 			defer mu.Unlock()
 
 			if err != nil {
 				if errors.Is(err, errNotForNissanStadium) {
-					slog.Info("skipping event not for Nissan Stadium", "url", candidate.url)
+					logger.Info("skipping event not for Nissan Stadium", "url", candidate.url)
+					report.Skip("not for Nissan Stadium", candidate.url)
 					return nil
 				}
 				errorCount++
-				slog.Error("failed to fetch event detail", "error", err)
+				lastErr = err
+				logger.Error("failed to fetch event detail", "url", candidate.url, logging.KeyError, err)
+				report.Skip("detail fetch failed", err.Error())
 				return nil
 			}
 
@@ -234,11 +309,14 @@ func (s *NissanStadiumFetcher) fetchEventDetails(ctx context.Context, candidates
 		return nil, fmt.Errorf("fetch nissan stadium event details: %w", err)
 	}
 
+	span.SetAttributes(attribute.Int("events", len(results)), attribute.Int("errors", errorCount))
+
 	if len(results) == 0 && errorCount > 0 {
-		return nil, fmt.Errorf("all event detail fetches failed")
+		// Keep one cause so the failure is classified, e.g. as the site being unavailable.
+		return nil, fmt.Errorf("all %d event detail fetches failed: %w", errorCount, lastErr)
 	}
 
-	slog.Debug("event details fetched", "success", len(results), "errors", errorCount)
+	logger.Debug("event details fetched", "success", len(results), "errors", errorCount)
 
 	return results, nil
 }
@@ -251,8 +329,8 @@ type eventDetailFields struct {
 }
 
 func (s *NissanStadiumFetcher) fetchEventDetail(ctx context.Context, candidate eventCandidate, today time.Time) (event.Event, error) {
-	c := colly.NewCollector()
-	c.SetRequestTimeout(10 * time.Second)
+	c := s.newCollector(ctx)
+	logger := logging.FromContext(ctx)
 
 	var fields eventDetailFields
 
@@ -260,8 +338,10 @@ func (s *NissanStadiumFetcher) fetchEventDetail(ctx context.Context, candidate e
 		s.parseDetailTableRow(row, &fields)
 	})
 
+	var status int
 	c.OnError(func(r *colly.Response, err error) {
-		slog.Error("error during event detail scraping", "status", r.StatusCode, "error", err)
+		status = r.StatusCode
+		logger.Error("error during event detail scraping", "status", r.StatusCode, logging.KeyError, err)
 	})
 
 	var visitErr error
@@ -274,17 +354,17 @@ func (s *NissanStadiumFetcher) fetchEventDetail(ctx context.Context, candidate e
 		}
 	})
 
-	slog.Debug("fetching event detail", "url", candidate.url)
+	logger.Debug("fetching event detail", "url", candidate.url)
 
 	if err := c.Visit(candidate.url); err != nil {
-		return event.Event{}, fmt.Errorf("failed to visit detail page for event %s: %w", candidate.url, err)
+		return event.Event{}, fmt.Errorf("failed to visit detail page for event %s: %w", candidate.url, visitError(status, err))
 	}
 
 	if visitErr != nil {
 		return event.Event{}, fmt.Errorf("fetch nissan stadium event detail %s: %w", candidate.url, visitErr)
 	}
 
-	return s.buildEventFromFields(fields, candidate, today)
+	return s.buildEventFromFields(ctx, fields, candidate, today)
 }
 
 func (s *NissanStadiumFetcher) parseDetailTableRow(row *colly.HTMLElement, fields *eventDetailFields) {
@@ -303,7 +383,7 @@ func (s *NissanStadiumFetcher) parseDetailTableRow(row *colly.HTMLElement, field
 	}
 }
 
-func (s *NissanStadiumFetcher) buildEventFromFields(fields eventDetailFields, candidate eventCandidate, today time.Time) (event.Event, error) {
+func (s *NissanStadiumFetcher) buildEventFromFields(ctx context.Context, fields eventDetailFields, candidate eventCandidate, today time.Time) (event.Event, error) {
 	if !strings.Contains(fields.venue, "日産スタジアム") {
 		return event.Event{}, errNotForNissanStadium
 	}
@@ -315,7 +395,7 @@ func (s *NissanStadiumFetcher) buildEventFromFields(fields eventDetailFields, ca
 
 	parsedDate, err := parseJapaneseDate(fields.date, today)
 	if err != nil {
-		return event.Event{}, fmt.Errorf("failed to parse date for event %s: %w", candidate.url, err)
+		return event.Event{}, apperrors.NewParseError(string(event.VenueIDNissanStadium), fmt.Sprintf("unparseable date %q on %s", fields.date, candidate.url), err)
 	}
 
 	evt := event.Event{Title: title, Date: parsedDate}
@@ -323,12 +403,13 @@ func (s *NissanStadiumFetcher) buildEventFromFields(fields eventDetailFields, ca
 	if fields.time != "" {
 		t, err := parseJapaneseTime(fields.time, parsedDate)
 		if err != nil {
-			slog.Error("failed to parse event start time",
+			logging.FromContext(ctx).Error("failed to parse event start time",
 				"time", fields.time,
 				"date", parsedDate,
 				"url", candidate.url,
-				"err", err,
+				logging.KeyError, err,
 			)
+			diagnostics.FromContext(ctx).Warnf("%s: unparseable start time %q", title, fields.time)
 		} else {
 			evt.Schedules = []event.Schedule{{StartTime: &t}}
 		}
@@ -337,6 +418,14 @@ func (s *NissanStadiumFetcher) buildEventFromFields(fields eventDetailFields, ca
 	return evt, nil
 }
 
+// visitError classifies an error returned by colly's Visit, given the status seen by OnError.
+func visitError(status int, err error) error {
+	if status >= http.StatusBadRequest {
+		return statusError(event.VenueIDNissanStadium, status)
+	}
+	return apperrors.NewSourceUnavailableError(string(event.VenueIDNissanStadium), err)
+}
+
 func (s *NissanStadiumFetcher) VenueID() event.VenueID {
 	return event.VenueIDNissanStadium
 }
@@ -358,9 +447,7 @@ func parseJapaneseDate(dateStr string, today time.Time) (time.Time, error) {
 		dateStr = fmt.Sprintf("%d年%d月%d日", today.Year(), today.Month(), today.Day())
 	}
 
-	jst := time.FixedZone("JST", 9*60*60)
-
-	t, err := time.ParseInLocation("2006年1月2日", dateStr, jst)
+	t, err := time.ParseInLocation("2006年1月2日", dateStr, today.Location())
 	if err != nil {
 		return time.Time{}, fmt.Errorf("failed to parse date '%s': %w", dateStr, err)
 	}
@@ -369,8 +456,6 @@ func parseJapaneseDate(dateStr string, today time.Time) (time.Time, error) {
 }
 
 func parseJapaneseTime(timeStr string, baseDate time.Time) (time.Time, error) {
-	jst := time.FixedZone("JST", 9*60*60)
-
 	layouts := []string{
 		"15時04分",
 		"15時4分",
@@ -378,11 +463,11 @@ func parseJapaneseTime(timeStr string, baseDate time.Time) (time.Time, error) {
 	}
 
 	for _, layout := range layouts {
-		t, err := time.ParseInLocation(layout, timeStr, jst)
+		t, err := time.Parse(layout, timeStr)
 		if err == nil {
 			return time.Date(
 				baseDate.Year(), baseDate.Month(), baseDate.Day(),
-				t.Hour(), t.Minute(), 0, 0, jst,
+				t.Hour(), t.Minute(), 0, 0, baseDate.Location(),
 			), nil
 		}
 	}
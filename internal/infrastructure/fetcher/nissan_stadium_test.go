@@ -14,7 +14,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/httpcache"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
 )
 
 func TestNewNissanStadiumFetcher(t *testing.T) {
@@ -45,7 +49,7 @@ func TestNissanStadiumFetcher_FetchEvents_Success_SingleEvent(t *testing.T) {
 	server := createMockServer(calendarHTML, detailHTML)
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -60,6 +64,68 @@ func TestNissanStadiumFetcher_FetchEvents_Success_SingleEvent(t *testing.T) {
 	assert.Equal(t, 0, events[0].Schedules[0].StartTime.Minute())
 }
 
+type countingTransport struct {
+	requests atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestNissanStadiumFetcher_FetchEvents_WithCachingTransport(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	today := time.Now().In(jst)
+
+	calendarHTML := createMockCalendarHTML(today.Day(), "サッカー練習試合", "691aa8fccc37e", "日産スタジアム")
+	detailHTML := createMockDetailHTML("サッカー練習試合", fmt.Sprintf("2026年1月%d日", today.Day()), "14時", "日産スタジアム")
+
+	server := createMockServer(calendarHTML, detailHTML)
+	defer server.Close()
+
+	counter := &countingTransport{}
+	transport := httpcache.NewTransport(httpcache.NewMemoryStore(), time.Hour, httpcache.WithNext(counter))
+	scraper := NewNissanStadiumFetcher(WithTransport(transport)).(*NissanStadiumFetcher)
+	scraper.baseURL = server.URL
+
+	first, err := scraper.FetchEvents(context.Background(), today, today)
+	require.NoError(t, err)
+	requests := counter.requests.Load()
+	require.Equal(t, int32(2), requests, "calendar and detail page")
+
+	second, err := scraper.FetchEvents(context.Background(), today, today)
+	require.NoError(t, err)
+
+	assert.Equal(t, requests, counter.requests.Load(), "second run must be served from the cache")
+	assert.Equal(t, first, second)
+}
+
+func TestNissanStadiumFetcher_FetchEvents_Diagnostics(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	today := time.Now().In(jst)
+	currentDay := today.Day()
+
+	calendarHTML := createMockCalendarHTML(currentDay, "サッカー練習試合", "691aa8fccc37e", "日産スタジアム")
+	detailHTML := createMockDetailHTML("サッカー練習試合", fmt.Sprintf("2026年1月%d日", currentDay), "未定", "日産スタジアム")
+
+	server := createMockServer(calendarHTML, detailHTML)
+	defer server.Close()
+
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
+	report := diagnostics.NewReport(event.VenueIDNissanStadium)
+
+	events, err := scraper.FetchEvents(diagnostics.NewContext(context.Background(), report), today, today)
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Len(t, report.Requests, 2)
+	for _, req := range report.Requests {
+		assert.Equal(t, http.StatusOK, req.Status)
+		assert.Positive(t, req.Bytes)
+	}
+	assert.Equal(t, []string{`サッカー練習試合: unparseable start time "未定"`}, report.Warnings)
+}
+
 func TestNissanStadiumFetcher_FetchEvents_Success_MultipleEvents(t *testing.T) {
 	jst := time.FixedZone("JST", 9*60*60)
 	today := time.Now().In(jst)
@@ -98,7 +164,7 @@ func TestNissanStadiumFetcher_FetchEvents_Success_MultipleEvents(t *testing.T) {
 	})
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -118,7 +184,7 @@ func TestNissanStadiumFetcher_FetchEvents_NoEventsToday(t *testing.T) {
 	server := createMockServer(calendarHTML, detailHTML)
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -127,6 +193,24 @@ func TestNissanStadiumFetcher_FetchEvents_NoEventsToday(t *testing.T) {
 	assert.Empty(t, events)
 }
 
+func TestNissanStadiumFetcher_FetchEvents_LayoutChanged(t *testing.T) {
+	// The calendar moved into a new container, so the selector matches nothing.
+	calendarHTML := `<html><body><div class="calendar"><table><tbody>
+		<tr><th>1</th><td>月</td><td><a href="#">日産スタジアム</a><a href="detail.php?id=abc">イベント</a></td></tr>
+	</tbody></table></div></body></html>`
+
+	server := createMockServer(calendarHTML, "")
+	defer server.Close()
+
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
+	events, err := scraper.FetchEvents(context.Background(), time.Now(), time.Now())
+
+	require.Error(t, err)
+	assert.Nil(t, events)
+	assert.True(t, apperrors.IsLayoutChanged(err))
+	assert.Contains(t, err.Error(), "calendar page has no dated rows")
+}
+
 func TestNissanStadiumFetcher_FetchEvents_FiltersByVenue(t *testing.T) {
 	jst := time.FixedZone("JST", 9*60*60)
 	today := time.Now().In(jst)
@@ -168,7 +252,7 @@ func TestNissanStadiumFetcher_FetchEvents_FiltersByVenue(t *testing.T) {
 	})
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -184,7 +268,7 @@ func TestNissanStadiumFetcher_FetchEvents_CalendarFetchError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, time.Now(), time.Now())
@@ -192,6 +276,8 @@ func TestNissanStadiumFetcher_FetchEvents_CalendarFetchError(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, events)
 	assert.Contains(t, err.Error(), "failed to fetch event candidates")
+	assert.ErrorIs(t, err, apperrors.ErrSourceUnavailable)
+	assert.True(t, apperrors.IsRetryable(err))
 }
 
 func TestNissanStadiumFetcher_FetchEvents_ContextCancellation(t *testing.T) {
@@ -201,7 +287,7 @@ func TestNissanStadiumFetcher_FetchEvents_ContextCancellation(t *testing.T) {
 	server := createMockServer(calendarHTML, detailHTML)
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel()
 
@@ -222,7 +308,7 @@ func TestNissanStadiumFetcher_FetchEvents_MissingTime_DefaultsToZero(t *testing.
 	server := createMockServer(calendarHTML, detailHTML)
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -268,7 +354,7 @@ func TestNissanStadiumFetcher_FetchEvents_PartialFailure(t *testing.T) {
 	})
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -310,7 +396,7 @@ func TestNissanStadiumFetcher_FetchEvents_EmptyIDOrTitle(t *testing.T) {
 	server := createMockServer(calendarHTML, "")
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -330,7 +416,7 @@ func TestNissanStadiumFetcher_FetchEvents_TitleFallback(t *testing.T) {
 	server := createMockServer(calendarHTML, detailHTML)
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -378,7 +464,7 @@ func TestNissanStadiumFetcher_FetchEvents_AllDetailsFailed(t *testing.T) {
 	})
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -412,7 +498,7 @@ func TestNissanStadiumFetcher_FetchEvents_InvalidURL(t *testing.T) {
 	}))
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -433,7 +519,7 @@ func TestNissanStadiumFetcher_FetchEvents_InvalidTimeFormat_LogsError(t *testing
 	server := createMockServer(calendarHTML, detailHTML)
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(time.Now)}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, today, today)
@@ -519,7 +605,7 @@ func TestNissanStadiumFetcher_FetchEvents_DateRange_SameMonth(t *testing.T) {
 	}))
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(func() time.Time { return from })}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, from, to)
@@ -659,7 +745,7 @@ func TestNissanStadiumFetcher_FetchEvents_DateRange_CrossMonth(t *testing.T) {
 	}))
 	defer server.Close()
 
-	scraper := &NissanStadiumFetcher{baseURL: server.URL}
+	scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(func() time.Time { return from })}
 	ctx := context.Background()
 
 	events, err := scraper.FetchEvents(ctx, from, to)
@@ -719,59 +805,78 @@ func TestBuildTargetDays(t *testing.T) {
 	}
 }
 
-func TestNissanStadiumFetcher_FetchEvents_RangeExceedsTwoMonths(t *testing.T) {
+func TestNissanStadiumFetcher_FetchEvents_MonthNotListed(t *testing.T) {
 	jst := time.FixedZone("JST", 9*60*60)
-	from := time.Date(2026, 1, 1, 0, 0, 0, 0, jst)
-	to := time.Date(2026, 3, 1, 0, 0, 0, 0, jst)
-
-	scraper := &NissanStadiumFetcher{baseURL: "http://localhost"}
+	now := time.Date(2026, 2, 3, 6, 0, 0, 0, jst)
 
-	events, err := scraper.FetchEvents(context.Background(), from, to)
+	tests := []struct {
+		from time.Time
+		to   time.Time
+		name string
+	}{
+		{name: "before the current month", from: time.Date(2026, 1, 20, 0, 0, 0, 0, jst), to: time.Date(2026, 2, 10, 0, 0, 0, 0, jst)},
+		{name: "past the horizon", from: time.Date(2026, 3, 20, 0, 0, 0, 0, jst), to: time.Date(2026, 4, 10, 0, 0, 0, 0, jst)},
+	}
 
-	require.Error(t, err)
-	assert.Nil(t, events)
-	assert.ErrorIs(t, err, errRangeExceedsLimit)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+			}))
+			defer server.Close()
+			scraper := &NissanStadiumFetcher{baseURL: server.URL, clock: ports.ClockFunc(func() time.Time { return now })}
+
+			events, err := scraper.FetchEvents(context.Background(), tt.from, tt.to)
+
+			require.ErrorIs(t, err, errMonthNotListed)
+			assert.Nil(t, events)
+			assert.Zero(t, requests)
+		})
+	}
 }
 
-func TestDistinctMonthCount(t *testing.T) {
+func TestNissanStadiumFetcher_Capabilities(t *testing.T) {
 	jst := time.FixedZone("JST", 9*60*60)
 
 	tests := []struct {
-		from     time.Time
-		to       time.Time
+		now      time.Time
+		earliest time.Time
+		horizon  time.Time
 		name     string
-		expected int
 	}{
 		{
-			name:     "same month",
-			from:     time.Date(2026, 4, 1, 0, 0, 0, 0, jst),
-			to:       time.Date(2026, 4, 30, 0, 0, 0, 0, jst),
-			expected: 1,
+			name:     "mid month",
+			now:      time.Date(2026, 4, 15, 12, 0, 0, 0, jst),
+			earliest: time.Date(2026, 4, 1, 0, 0, 0, 0, jst),
+			horizon:  time.Date(2026, 5, 31, 0, 0, 0, 0, jst),
 		},
 		{
-			name:     "two months",
-			from:     time.Date(2026, 4, 27, 0, 0, 0, 0, jst),
-			to:       time.Date(2026, 5, 3, 0, 0, 0, 0, jst),
-			expected: 2,
+			name:     "shorter next month",
+			now:      time.Date(2026, 1, 31, 12, 0, 0, 0, jst),
+			earliest: time.Date(2026, 1, 1, 0, 0, 0, 0, jst),
+			horizon:  time.Date(2026, 2, 28, 0, 0, 0, 0, jst),
 		},
 		{
-			name:     "three months",
-			from:     time.Date(2026, 1, 1, 0, 0, 0, 0, jst),
-			to:       time.Date(2026, 3, 1, 0, 0, 0, 0, jst),
-			expected: 3,
+			name:     "cross year",
+			now:      time.Date(2026, 12, 1, 0, 0, 0, 0, jst),
+			earliest: time.Date(2026, 12, 1, 0, 0, 0, 0, jst),
+			horizon:  time.Date(2027, 1, 31, 0, 0, 0, 0, jst),
 		},
 		{
-			name:     "cross year",
-			from:     time.Date(2026, 12, 1, 0, 0, 0, 0, jst),
-			to:       time.Date(2027, 1, 1, 0, 0, 0, 0, jst),
-			expected: 2,
+			name:     "UTC clock still in the previous month",
+			now:      time.Date(2026, 3, 31, 20, 0, 0, 0, time.UTC),
+			earliest: time.Date(2026, 4, 1, 0, 0, 0, 0, jst),
+			horizon:  time.Date(2026, 5, 31, 0, 0, 0, 0, jst),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := distinctMonthCount(tt.from, tt.to)
-			assert.Equal(t, tt.expected, result)
+			scraper := &NissanStadiumFetcher{clock: ports.ClockFunc(func() time.Time { return tt.now })}
+			caps := scraper.Capabilities()
+			assert.True(t, tt.earliest.Equal(caps.Earliest), "got %s", caps.Earliest)
+			assert.True(t, tt.horizon.Equal(caps.Horizon), "got %s", caps.Horizon)
 		})
 	}
 }
@@ -0,0 +1,37 @@
+package fetcher
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+type Option func(*options)
+
+type options struct {
+	transport http.RoundTripper
+	clock     ports.Clock
+}
+
+// WithTransport sets the HTTP transport used to download venue pages, e.g. a caching one.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *options) {
+		o.transport = rt
+	}
+}
+
+// WithClock sets the clock used to tell which days a venue's site currently lists.
+func WithClock(clock ports.Clock) Option {
+	return func(o *options) {
+		o.clock = clock
+	}
+}
+
+func newOptions(opts []Option) options {
+	o := options{clock: ports.ClockFunc(time.Now)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
@@ -6,24 +6,31 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 
 	"golang.org/x/net/html"
 
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
 type SkateCenterFetcher struct {
-	baseURL string
+	transport http.RoundTripper
+	clock     ports.Clock
+	baseURL   string
 }
 
-func NewSkateCenterFetcher() ports.EventFetcher {
+func NewSkateCenterFetcher(opts ...Option) ports.EventFetcher {
+	o := newOptions(opts)
 	return &SkateCenterFetcher{
-		baseURL: "https://ticketjam.jp",
+		baseURL:   "https://ticketjam.jp",
+		transport: o.transport,
+		clock:     o.clock,
 	}
 }
 
@@ -39,40 +46,43 @@ type jsonLDLocation struct {
 }
 
 func (s *SkateCenterFetcher) FetchEvents(ctx context.Context, from, to time.Time) ([]event.Event, error) {
-	jst := time.FixedZone("JST", 9*60*60)
-	from = from.In(jst)
-	to = to.In(jst)
+	loc := event.JST
+	from = from.In(loc)
+	to = to.In(loc)
 	fromStr := from.Format("2006-01-02")
 	toStr := to.Format("2006-01-02")
 
-	slog.Info("fetching skate center events", "from", fromStr, "to", toStr)
+	logger := logging.FromContext(ctx)
+	logger.Info("fetching skate center events", "from", fromStr, "to", toStr)
 
 	htmlContent, err := s.fetchHTML(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch skate center events: %w", err)
 	}
 
-	rawEvents, err := extractJSONLDEvents(htmlContent)
+	rawEvents, err := extractJSONLDEvents(ctx, htmlContent)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract JSON-LD events: %w", err)
 	}
 
+	report := diagnostics.FromContext(ctx)
 	var events []event.Event
 	for _, raw := range rawEvents {
 		t, err := time.Parse(time.RFC3339, raw.StartDate)
 		if err != nil {
-			slog.Error("failed to parse startDate", "startDate", raw.StartDate, "err", err)
+			logger.Error("failed to parse startDate", "startDate", raw.StartDate, logging.KeyError, err)
+			report.Skip("unparseable startDate", fmt.Sprintf("%s: %q", raw.Name, raw.StartDate))
 			continue
 		}
-		dateStr := t.In(jst).Format("2006-01-02")
+		dateStr := t.In(loc).Format("2006-01-02")
 		if dateStr < fromStr || dateStr > toStr {
 			continue
 		}
-		eventDate := t.In(jst)
+		eventDate := t.In(loc)
 		events = append(events, buildSkateCenterEvent(raw, eventDate))
 	}
 
-	slog.Info("fetched skate center events", "count", len(events))
+	logger.Info("fetched skate center events", "count", len(events))
 
 	return events, nil
 }
@@ -85,26 +95,26 @@ func (s *SkateCenterFetcher) fetchHTML(ctx context.Context) (string, error) {
 		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: 10 * time.Second, Transport: newTransport(s.transport)}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+		return "", apperrors.NewSourceUnavailableError(string(event.VenueIDSkateCenter), fmt.Errorf("failed to execute request: %w", err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return "", statusError(event.VenueIDSkateCenter, resp.StatusCode)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return "", apperrors.NewSourceUnavailableError(string(event.VenueIDSkateCenter), fmt.Errorf("failed to read response body: %w", err))
 	}
 
 	return string(body), nil
 }
 
-func extractJSONLDEvents(htmlContent string) ([]jsonLDEvent, error) {
+func extractJSONLDEvents(ctx context.Context, htmlContent string) ([]jsonLDEvent, error) {
 	doc, err := html.Parse(strings.NewReader(htmlContent))
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse HTML: %w", err)
@@ -112,16 +122,19 @@ func extractJSONLDEvents(htmlContent string) ([]jsonLDEvent, error) {
 
 	var events []jsonLDEvent
 	var parseErrs []error
+	var blocks int
 	var traverse func(*html.Node)
 	traverse = func(n *html.Node) {
 		if n.Type == html.ElementNode && n.Data == "script" {
 			for _, attr := range n.Attr {
 				if attr.Key == "type" && attr.Val == "application/ld+json" {
+					blocks++
 					if n.FirstChild != nil {
 						var raw json.RawMessage
 						text := n.FirstChild.Data
 						if err := json.Unmarshal([]byte(text), &raw); err != nil {
-							slog.Error("failed to unmarshal JSON-LD", "err", err)
+							logging.FromContext(ctx).Error("failed to unmarshal JSON-LD", logging.KeyError, err)
+							diagnostics.FromContext(ctx).Warnf("invalid JSON-LD block: %v", err)
 							parseErrs = append(parseErrs, err)
 							break
 						}
@@ -153,6 +166,11 @@ func extractJSONLDEvents(htmlContent string) ([]jsonLDEvent, error) {
 	}
 	traverse(doc)
 
+	// The venue page always embeds JSON-LD, even when no events are listed.
+	if blocks == 0 {
+		return nil, apperrors.NewLayoutChangedError(string(event.VenueIDSkateCenter), "page has no JSON-LD")
+	}
+
 	if len(events) == 0 && len(parseErrs) > 0 {
 		return nil, fmt.Errorf("failed to parse %d JSON-LD block(s), no events extracted: %w", len(parseErrs), errors.Join(parseErrs...))
 	}
@@ -161,8 +179,8 @@ func extractJSONLDEvents(htmlContent string) ([]jsonLDEvent, error) {
 }
 
 func buildSkateCenterEvent(raw jsonLDEvent, today time.Time) event.Event {
-	jst := time.FixedZone("JST", 9*60*60)
-	date := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, jst)
+	loc := today.Location()
+	date := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, loc)
 
 	evt := event.Event{
 		Title: raw.Name,
@@ -171,7 +189,7 @@ func buildSkateCenterEvent(raw jsonLDEvent, today time.Time) event.Event {
 
 	t, err := time.Parse(time.RFC3339, raw.StartDate)
 	if err == nil {
-		startTime := t.In(jst)
+		startTime := t.In(loc)
 		schedule := event.Schedule{
 			StartTime: &startTime,
 		}
@@ -181,6 +199,14 @@ func buildSkateCenterEvent(raw jsonLDEvent, today time.Time) event.Event {
 	return evt
 }
 
+// Capabilities reports that only the first page of the venue's upcoming events on
+// ticketjam is read, which starts today.
+func (s *SkateCenterFetcher) Capabilities() ports.Capabilities {
+	now := s.clock.Now().In(event.JST)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return ports.Capabilities{Earliest: today, FirstPageOnly: true}
+}
+
 func (s *SkateCenterFetcher) VenueID() event.VenueID {
 	return event.VenueIDSkateCenter
 }
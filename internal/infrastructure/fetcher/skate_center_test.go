@@ -13,6 +13,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
 )
 
 func TestNewSkateCenterFetcher(t *testing.T) {
@@ -32,6 +34,16 @@ func TestSkateCenterFetcher_VenueID(t *testing.T) {
 	assert.Equal(t, event.VenueIDSkateCenter, vid)
 }
 
+func TestSkateCenterFetcher_Capabilities(t *testing.T) {
+	now := time.Date(2026, 10, 17, 20, 0, 0, 0, time.UTC)
+	scraper := NewSkateCenterFetcher(WithClock(ports.ClockFunc(func() time.Time { return now }))).(*SkateCenterFetcher)
+
+	caps := scraper.Capabilities()
+
+	assert.True(t, time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST).Equal(caps.Earliest), "got %s", caps.Earliest)
+	assert.True(t, caps.FirstPageOnly)
+}
+
 func TestSkateCenterFetcher_FetchEvents_SingleEvent(t *testing.T) {
 	jst := time.FixedZone("JST", 9*60*60)
 	today := time.Now().In(jst)
@@ -109,15 +121,17 @@ func TestSkateCenterFetcher_FetchEvents_NoEventsToday(t *testing.T) {
 	assert.Empty(t, events)
 }
 
-func TestSkateCenterFetcher_FetchEvents_EmptyPage(t *testing.T) {
+func TestSkateCenterFetcher_FetchEvents_EmptyPage_LayoutChanged(t *testing.T) {
 	server := createSkateCenterMockServer(`<html><body></body></html>`)
 	defer server.Close()
 
 	scraper := &SkateCenterFetcher{baseURL: server.URL}
 	events, err := scraper.FetchEvents(context.Background(), time.Now(), time.Now())
 
-	require.NoError(t, err)
-	assert.Empty(t, events)
+	require.Error(t, err)
+	assert.Nil(t, events)
+	assert.True(t, apperrors.IsLayoutChanged(err))
+	assert.Contains(t, err.Error(), "page has no JSON-LD")
 }
 
 func TestSkateCenterFetcher_FetchEvents_HTTPError(t *testing.T) {
@@ -132,6 +146,7 @@ func TestSkateCenterFetcher_FetchEvents_HTTPError(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, events)
 	assert.Contains(t, err.Error(), "unexpected status code: 500")
+	assert.ErrorIs(t, err, apperrors.ErrSourceUnavailable)
 }
 
 func TestSkateCenterFetcher_FetchEvents_ContextCancellation(t *testing.T) {
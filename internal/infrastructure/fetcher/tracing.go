@@ -0,0 +1,11 @@
+package fetcher
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer returns the package tracer from the current global provider.
+func tracer() trace.Tracer {
+	return otel.Tracer("github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/fetcher")
+}
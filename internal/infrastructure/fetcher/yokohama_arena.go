@@ -4,23 +4,28 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log/slog"
 	"net/http"
 	"strings"
 	"time"
 	"unicode/utf8"
 
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
 type YokohamaArenaFetcher struct {
-	baseURL string
+	transport http.RoundTripper
+	baseURL   string
 }
 
-func NewYokohamaArenaFetcher() ports.EventFetcher {
+func NewYokohamaArenaFetcher(opts ...Option) ports.EventFetcher {
+	o := newOptions(opts)
 	return &YokohamaArenaFetcher{
-		baseURL: "https://www.yokohama-arena.co.jp",
+		baseURL:   "https://www.yokohama-arena.co.jp",
+		transport: o.transport,
 	}
 }
 
@@ -33,13 +38,14 @@ type yokohamaArenaEvent struct {
 }
 
 func (s *YokohamaArenaFetcher) FetchEvents(ctx context.Context, from, to time.Time) ([]event.Event, error) {
-	jst := time.FixedZone("JST", 9*60*60)
-	from = from.In(jst)
-	to = to.In(jst)
+	loc := event.JST
+	from = from.In(loc)
+	to = to.In(loc)
 	fromStr := from.Format("2006-01-02")
 	toStr := to.Format("2006-01-02")
 
-	slog.Info("fetching yokohama arena events", "from", fromStr, "to", toStr)
+	logger := logging.FromContext(ctx)
+	logger.Info("fetching yokohama arena events", "from", fromStr, "to", toStr)
 
 	months := distinctYearMonths(from, to)
 	var allRaw []yokohamaArenaEvent
@@ -52,23 +58,26 @@ func (s *YokohamaArenaFetcher) FetchEvents(ctx context.Context, from, to time.Ti
 		allRaw = append(allRaw, rawEvents...)
 	}
 
+	report := diagnostics.FromContext(ctx)
 	var events []event.Event
 	for _, raw := range allRaw {
 		if raw.Path == "" {
+			report.Skip("no detail page", raw.Title)
 			continue
 		}
 		if raw.Date1 < fromStr || raw.Date1 > toStr {
 			continue
 		}
-		eventDate, err := time.ParseInLocation("2006-01-02", raw.Date1, jst)
+		eventDate, err := time.ParseInLocation("2006-01-02", raw.Date1, loc)
 		if err != nil {
-			slog.Error("failed to parse event date", "date", raw.Date1, "err", err)
+			logger.Error("failed to parse event date", "date", raw.Date1, logging.KeyError, err)
+			report.Skip("unparseable date", fmt.Sprintf("%s: %q", raw.Title, raw.Date1))
 			continue
 		}
-		events = append(events, s.buildEvent(raw, eventDate))
+		events = append(events, s.buildEvent(ctx, raw, eventDate))
 	}
 
-	slog.Info("fetched yokohama arena events", "count", len(events))
+	logger.Info("fetched yokohama arena events", "count", len(events))
 
 	return events, nil
 }
@@ -90,28 +99,46 @@ func (s *YokohamaArenaFetcher) fetchJSON(ctx context.Context, apiURL string) ([]
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	client := &http.Client{Timeout: 10 * time.Second}
+	client := &http.Client{Timeout: 10 * time.Second, Transport: newTransport(s.transport)}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, apperrors.NewSourceUnavailableError(string(event.VenueIDYokohamaArena), fmt.Errorf("failed to execute request: %w", err))
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return nil, statusError(event.VenueIDYokohamaArena, resp.StatusCode)
 	}
 
 	var rawEvents []yokohamaArenaEvent
 	if err := json.NewDecoder(resp.Body).Decode(&rawEvents); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		layoutErr := apperrors.NewLayoutChangedError(string(event.VenueIDYokohamaArena), "response is not the expected event list")
+		layoutErr.Err = err
+		return nil, layoutErr
+	}
+
+	if len(rawEvents) > 0 && !hasEventFields(rawEvents) {
+		return nil, apperrors.NewLayoutChangedError(string(event.VenueIDYokohamaArena), "events have no date1 or title fields")
 	}
 
 	return rawEvents, nil
 }
 
-func (s *YokohamaArenaFetcher) buildEvent(raw yokohamaArenaEvent, today time.Time) event.Event {
-	jst := time.FixedZone("JST", 9*60*60)
-	date := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, jst)
+// hasEventFields reports whether any event carries the fields the parser relies on.
+// An empty month is valid, but a list whose entries all lack them means the JSON changed.
+func hasEventFields(rawEvents []yokohamaArenaEvent) bool {
+	for _, raw := range rawEvents {
+		if raw.Date1 != "" && raw.Title != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *YokohamaArenaFetcher) buildEvent(ctx context.Context, raw yokohamaArenaEvent, today time.Time) event.Event {
+	logger := logging.FromContext(ctx)
+	report := diagnostics.FromContext(ctx)
+	date := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, today.Location())
 
 	n := len(raw.EvStart)
 	if len(raw.EvOpen) > n {
@@ -127,7 +154,8 @@ func (s *YokohamaArenaFetcher) buildEvent(raw yokohamaArenaEvent, today time.Tim
 			if t, err := parseArenaTime(raw.EvStart[i], date); err == nil {
 				slot.StartTime = &t
 			} else {
-				slog.Error("failed to parse start time", "time", raw.EvStart[i], "err", err)
+				logger.Error("failed to parse start time", "time", raw.EvStart[i], logging.KeyError, err)
+				report.Warnf("%s: unparseable start time %q", raw.Title, raw.EvStart[i])
 			}
 		}
 
@@ -135,7 +163,8 @@ func (s *YokohamaArenaFetcher) buildEvent(raw yokohamaArenaEvent, today time.Tim
 			if t, err := parseArenaTime(raw.EvOpen[i], date); err == nil {
 				slot.OpenTime = &t
 			} else {
-				slog.Error("failed to parse open time", "time", raw.EvOpen[i], "err", err)
+				logger.Error("failed to parse open time", "time", raw.EvOpen[i], logging.KeyError, err)
+				report.Warnf("%s: unparseable open time %q", raw.Title, raw.EvOpen[i])
 			}
 		}
 
@@ -150,15 +179,14 @@ func parseArenaTime(s string, baseDate time.Time) (time.Time, error) {
 	s = stripCircledNumberPrefix(s)
 	s = strings.ReplaceAll(s, "：", ":")
 
-	jst := time.FixedZone("JST", 9*60*60)
-	t, err := time.ParseInLocation("15:04", s, jst)
+	t, err := time.Parse("15:04", s)
 	if err != nil {
 		return time.Time{}, fmt.Errorf("failed to parse time '%s': %w", s, err)
 	}
 
 	return time.Date(
 		baseDate.Year(), baseDate.Month(), baseDate.Day(),
-		t.Hour(), t.Minute(), 0, 0, jst,
+		t.Hour(), t.Minute(), 0, 0, baseDate.Location(),
 	), nil
 }
 
@@ -173,6 +201,12 @@ func stripCircledNumberPrefix(s string) string {
 	return s
 }
 
+// Capabilities reports that the monthly event API lists door-opening times and any month,
+// past ones included.
+func (s *YokohamaArenaFetcher) Capabilities() ports.Capabilities {
+	return ports.Capabilities{OpenTime: true}
+}
+
 func (s *YokohamaArenaFetcher) VenueID() event.VenueID {
 	return event.VenueIDYokohamaArena
 }
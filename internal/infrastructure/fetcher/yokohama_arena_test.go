@@ -13,7 +13,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/diagnostics"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/infrastructure/httpcache"
+	apperrors "github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/errors"
 )
 
 func TestNewYokohamaArenaFetcher(t *testing.T) {
@@ -25,6 +28,33 @@ func TestNewYokohamaArenaFetcher(t *testing.T) {
 	assert.Equal(t, "https://www.yokohama-arena.co.jp", arenaScraper.baseURL)
 }
 
+func TestYokohamaArenaFetcher_WeeklyThenDailyHitsSiteOnce(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, jst)
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		//nolint:errcheck
+		io.WriteString(w, `[{"date1": "2026-10-19", "title": "テストイベント", "path": "/event/detail/test"}]`)
+	}))
+	defer server.Close()
+
+	transport := httpcache.NewTransport(httpcache.NewMemoryStore(), 30*time.Minute)
+	scraper := NewYokohamaArenaFetcher(WithTransport(transport)).(*YokohamaArenaFetcher)
+	scraper.baseURL = server.URL
+
+	weekly, err := scraper.FetchEvents(context.Background(), today, today.AddDate(0, 0, 6))
+	require.NoError(t, err)
+	daily, err := scraper.FetchEvents(context.Background(), today, today)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, requests)
+	assert.Len(t, weekly, 1)
+	assert.Equal(t, weekly, daily)
+}
+
 func TestYokohamaArenaFetcher_VenueID(t *testing.T) {
 	scraper := NewYokohamaArenaFetcher()
 
@@ -162,6 +192,33 @@ func TestYokohamaArenaFetcher_FetchEvents_EmptyResponse(t *testing.T) {
 	assert.Empty(t, events)
 }
 
+func TestYokohamaArenaFetcher_FetchEvents_LayoutChanged(t *testing.T) {
+	testCases := []struct {
+		name     string
+		response string
+		wantMsg  string
+	}{
+		{name: "not a list", response: `{"events": []}`, wantMsg: "response is not the expected event list"},
+		{name: "html instead of json", response: `<html></html>`, wantMsg: "response is not the expected event list"},
+		{name: "renamed fields", response: `[{"date": "2026-10-19", "name": "テスト"}]`, wantMsg: "events have no date1 or title fields"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := createYokohamaArenaMockServer(tc.response)
+			defer server.Close()
+
+			scraper := &YokohamaArenaFetcher{baseURL: server.URL}
+			events, err := scraper.FetchEvents(context.Background(), time.Now(), time.Now())
+
+			require.Error(t, err)
+			assert.Nil(t, events)
+			assert.True(t, apperrors.IsLayoutChanged(err))
+			assert.Contains(t, err.Error(), tc.wantMsg)
+		})
+	}
+}
+
 func TestYokohamaArenaFetcher_FetchEvents_HTTPError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
@@ -174,6 +231,40 @@ func TestYokohamaArenaFetcher_FetchEvents_HTTPError(t *testing.T) {
 	require.Error(t, err)
 	assert.Nil(t, events)
 	assert.Contains(t, err.Error(), "unexpected status code: 500")
+	assert.ErrorIs(t, err, apperrors.ErrSourceUnavailable)
+	assert.True(t, apperrors.IsRetryable(err))
+}
+
+func TestYokohamaArenaFetcher_FetchEvents_StatusClassification(t *testing.T) {
+	testCases := []struct {
+		want      error
+		name      string
+		status    int
+		retryable bool
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests, want: apperrors.ErrRateLimited, retryable: true},
+		{name: "not found", status: http.StatusNotFound, want: apperrors.ErrSourceUnavailable, retryable: false},
+		{name: "bad gateway", status: http.StatusBadGateway, want: apperrors.ErrSourceUnavailable, retryable: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+
+			scraper := &YokohamaArenaFetcher{baseURL: server.URL}
+			_, err := scraper.FetchEvents(context.Background(), time.Now(), time.Now())
+
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tc.want)
+			assert.Equal(t, tc.retryable, apperrors.IsRetryable(err))
+			domainErr, ok := apperrors.AsDomainError(err)
+			require.True(t, ok)
+			assert.Equal(t, string(event.VenueIDYokohamaArena), domainErr.Venue)
+		})
+	}
 }
 
 func TestYokohamaArenaFetcher_FetchEvents_ContextCancellation(t *testing.T) {
@@ -300,6 +391,33 @@ func TestYokohamaArenaFetcher_FetchEvents_FullwidthColon(t *testing.T) {
 	assert.Equal(t, 15, events[0].Schedules[0].OpenTime.Hour())
 }
 
+func TestYokohamaArenaFetcher_FetchEvents_Diagnostics(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	today := time.Now().In(jst)
+	todayStr := today.Format("2006-01-02")
+
+	jsonResp := fmt.Sprintf(`[
+		{"date1": "%[1]s", "title": "時刻未定イベント", "ev_open": ["未定"], "ev_start": ["18:00"], "path": "/event/detail/tbd"},
+		{"date1": "%[1]s", "title": "詳細なし", "path": ""}
+	]`, todayStr)
+
+	server := createYokohamaArenaMockServer(jsonResp)
+	defer server.Close()
+
+	scraper := &YokohamaArenaFetcher{baseURL: server.URL}
+	report := diagnostics.NewReport(event.VenueIDYokohamaArena)
+
+	events, err := scraper.FetchEvents(diagnostics.NewContext(context.Background(), report), today, today)
+
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Len(t, report.Requests, 1)
+	assert.Equal(t, http.StatusOK, report.Requests[0].Status)
+	assert.Equal(t, int64(len(jsonResp)), report.Requests[0].Bytes)
+	assert.Equal(t, []string{`時刻未定イベント: unparseable open time "未定"`}, report.Warnings)
+	assert.Equal(t, []diagnostics.Skip{{Reason: "no detail page", Detail: "詳細なし"}}, report.Skipped)
+}
+
 func TestYokohamaArenaFetcher_FetchEvents_DateRange_SameMonth(t *testing.T) {
 	jst := time.FixedZone("JST", 9*60*60)
 	from := time.Date(2026, 4, 20, 0, 0, 0, 0, jst)
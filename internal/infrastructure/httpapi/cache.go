@@ -0,0 +1,59 @@
+package httpapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// responseCache keeps rendered response bodies so repeated queries do not scrape the
+// venue sites again.
+type responseCache struct {
+	entries map[string]cachedResponse
+	ttl     time.Duration
+	mu      sync.Mutex
+}
+
+type cachedResponse struct {
+	expires time.Time
+	etag    string
+	body    []byte
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{entries: make(map[string]cachedResponse), ttl: ttl}
+}
+
+func (c *responseCache) get(key string, now time.Time) (cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return cachedResponse{}, false
+	}
+	return entry, true
+}
+
+func (c *responseCache) put(key string, body []byte, now time.Time) cachedResponse {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+
+	entry := cachedResponse{body: body, etag: etagFor(body), expires: now.Add(c.ttl)}
+	if c.ttl > 0 {
+		c.entries[key] = entry
+	}
+	return entry
+}
+
+func etagFor(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
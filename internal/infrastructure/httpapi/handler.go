@@ -0,0 +1,231 @@
+package httpapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+const (
+	// DefaultCacheTTL matches how often the venue sites realistically change.
+	DefaultCacheTTL = 15 * time.Minute
+
+	// maxRangeDays keeps a single request from scraping months of schedules.
+	maxRangeDays = 31
+)
+
+type Handler struct {
+	query *service.EventQueryService
+	clock ports.Clock
+	cache *responseCache
+	mux   *http.ServeMux
+}
+
+type Option func(*Handler)
+
+func WithClock(clock ports.Clock) Option {
+	return func(h *Handler) {
+		h.clock = clock
+	}
+}
+
+// WithCacheTTL sets how long responses are cached, both in memory and via Cache-Control.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(h *Handler) {
+		h.cache = newResponseCache(ttl)
+	}
+}
+
+func NewHandler(query *service.EventQueryService, opts ...Option) *Handler {
+	h := &Handler{
+		query: query,
+		clock: ports.ClockFunc(time.Now),
+		cache: newResponseCache(DefaultCacheTTL),
+		mux:   http.NewServeMux(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+
+	h.mux.HandleFunc("GET /v1/venues", h.cached(h.venues))
+	h.mux.HandleFunc("GET /v1/events", h.cached(h.events))
+	h.mux.HandleFunc("GET /v1/congestion", h.cached(h.congestion))
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// apiError is returned by endpoint functions to produce a JSON error response.
+type apiError struct {
+	code    string
+	message string
+	status  int
+}
+
+func (e *apiError) Error() string { return e.message }
+
+func badRequest(format string, args ...any) *apiError {
+	return &apiError{status: http.StatusBadRequest, code: "invalid_request", message: fmt.Sprintf(format, args...)}
+}
+
+type endpoint func(ctx context.Context, r *http.Request) (any, *apiError)
+
+// cached serves responses from the cache keyed by today's date, path and query, answering
+// If-None-Match with 304 when the ETag still matches. Omitted dates default to today, so a
+// response cached before midnight must not answer the same request after it.
+func (h *Handler) cached(fn endpoint) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		now := h.clock.Now()
+		key := h.today().Format(dateLayout) + " " + r.URL.Path + "?" + r.URL.Query().Encode()
+
+		entry, ok := h.cache.get(key, now)
+		if !ok {
+			resp, apiErr := fn(r.Context(), r)
+			if apiErr != nil {
+				writeError(r.Context(), w, apiErr)
+				return
+			}
+
+			body, err := json.Marshal(resp)
+			if err != nil {
+				writeError(r.Context(), w, &apiError{status: http.StatusInternalServerError, code: "internal", message: "failed to encode response"})
+				return
+			}
+			entry = h.cache.put(key, body, now)
+		}
+
+		w.Header().Set("ETag", entry.etag)
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(entry.expires.Sub(now).Seconds())))
+		if match := r.Header.Get("If-None-Match"); match != "" && match == entry.etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if _, err := w.Write(entry.body); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to write response", logging.KeyError, err)
+		}
+	}
+}
+
+func (h *Handler) venues(_ context.Context, _ *http.Request) (any, *apiError) {
+	caps := h.query.Capabilities()
+	resp := VenuesResponse{}
+	for _, v := range h.query.Venues() {
+		resp.Venues = append(resp.Venues, toVenueDetail(v, caps))
+	}
+	return resp, nil
+}
+
+func (h *Handler) events(ctx context.Context, r *http.Request) (any, *apiError) {
+	q := r.URL.Query()
+	today := h.today()
+
+	from, apiErr := parseDate(q.Get("from"), "from", today)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	to, apiErr := parseDate(q.Get("to"), "to", from)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if to.Before(from) {
+		return nil, badRequest("to must not be before from")
+	}
+	if to.Sub(from) >= maxRangeDays*24*time.Hour {
+		return nil, badRequest("date range must not exceed %d days", maxRangeDays)
+	}
+
+	venueIDs, apiErr := h.parseVenues(q.Get("venue"))
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	venues, err := h.query.Events(ctx, from, to, venueIDs...)
+	if err != nil {
+		return nil, upstreamError(ctx, err)
+	}
+
+	return EventsResponse{
+		From:   from.Format(dateLayout),
+		To:     to.Format(dateLayout),
+		Venues: toVenueEvents(venues),
+	}, nil
+}
+
+func (h *Handler) congestion(ctx context.Context, r *http.Request) (any, *apiError) {
+	today := h.today()
+	date, apiErr := parseDate(r.URL.Query().Get("date"), "date", today)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	c, err := h.query.Congestion(ctx, date)
+	if err != nil {
+		return nil, upstreamError(ctx, err)
+	}
+	return toCongestionResponse(c), nil
+}
+
+func (h *Handler) today() time.Time {
+	now := h.clock.Now().In(event.JST)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, event.JST)
+}
+
+func (h *Handler) parseVenues(v string) ([]event.VenueID, *apiError) {
+	if v == "" {
+		return nil, nil
+	}
+
+	var known []event.VenueID
+	for _, venue := range h.query.Venues() {
+		known = append(known, venue.ID)
+	}
+
+	var ids []event.VenueID
+	for _, id := range strings.Split(v, ",") {
+		venueID := event.VenueID(strings.TrimSpace(id))
+		if !slices.Contains(known, venueID) {
+			return nil, badRequest("unknown venue %q", id)
+		}
+		ids = append(ids, venueID)
+	}
+	return ids, nil
+}
+
+func parseDate(v, name string, def time.Time) (time.Time, *apiError) {
+	if v == "" {
+		return def, nil
+	}
+	t, err := time.ParseInLocation(dateLayout, v, event.JST)
+	if err != nil {
+		return time.Time{}, badRequest("%s must be a date in YYYY-MM-DD format", name)
+	}
+	return t, nil
+}
+
+func upstreamError(ctx context.Context, err error) *apiError {
+	logging.FromContext(ctx).Error("failed to fetch events", logging.KeyError, err)
+	return &apiError{status: http.StatusBadGateway, code: "upstream_unavailable", message: "failed to fetch events from venue sites"}
+}
+
+func writeError(ctx context.Context, w http.ResponseWriter, e *apiError) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(e.status)
+	if err := json.NewEncoder(w).Encode(ErrorResponse{Error: ErrorBody{Code: e.code, Message: e.message}}); err != nil {
+		logging.FromContext(ctx).Warn("failed to write error response", logging.KeyError, err)
+	}
+}
@@ -0,0 +1,261 @@
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+)
+
+var testNow = time.Date(2026, 10, 18, 9, 0, 0, 0, event.JST)
+
+// setupHandler serves the arena, which describes no capabilities, and the stadium, which
+// lists events from October to November.
+func setupHandler(t *testing.T) (*mock_ports.MockEventFetcher, *mock_ports.MockCapableEventFetcher, *Handler, *time.Time) {
+	t.Helper()
+	ctrl := gomock.NewController(t)
+	arena := mock_ports.NewMockEventFetcher(ctrl)
+	stadium := mock_ports.NewMockCapableEventFetcher(ctrl)
+	arena.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	stadium.EXPECT().VenueID().Return(event.VenueIDNissanStadium).AnyTimes()
+	stadium.EXPECT().Capabilities().Return(ports.Capabilities{
+		Earliest:              time.Date(2026, 10, 1, 0, 0, 0, 0, event.JST),
+		Horizon:               time.Date(2026, 11, 30, 0, 0, 0, 0, event.JST),
+		MaxConcurrentRequests: 5,
+	}).AnyTimes()
+
+	now := testNow
+	h := NewHandler(service.NewEventQueryService([]ports.EventFetcher{arena, stadium}),
+		WithClock(ports.ClockFunc(func() time.Time { return now })),
+	)
+	return arena, stadium, h, &now
+}
+
+func serve(h http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestHandler_Venues(t *testing.T) {
+	_, _, h, _ := setupHandler(t)
+
+	rec := serve(h, "/v1/venues", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"venues": [
+		{
+			"id": "yokohama_arena", "name": "横浜アリーナ", "emoji": "🏟️", "large": true,
+			"capabilities": {"max_concurrent_requests": 1, "open_time": false, "end_time": false, "first_page_only": false}
+		},
+		{
+			"id": "nissan_stadium", "name": "日産スタジアム", "emoji": "⚽", "large": true,
+			"capabilities": {"earliest": "2026-10-01", "horizon": "2026-11-30", "max_concurrent_requests": 5, "open_time": false, "end_time": false, "first_page_only": false}
+		},
+		{"id": "skate_center", "name": "KOSÉ新横浜スケートセンター", "emoji": "⛸️", "large": false}
+	]}`, rec.Body.String())
+}
+
+func TestHandler_Events(t *testing.T) {
+	_, stadium, h, _ := setupHandler(t)
+	from := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+	to := time.Date(2026, 10, 20, 0, 0, 0, 0, event.JST)
+	start := time.Date(2026, 10, 19, 19, 0, 0, 0, event.JST)
+
+	stadium.EXPECT().FetchEvents(gomock.Any(), from, to).Return([]event.Event{{
+		Date:      time.Date(2026, 10, 19, 0, 0, 0, 0, event.JST),
+		Title:     "Match",
+		Schedules: []event.Schedule{{StartTime: &start}},
+	}}, nil)
+
+	rec := serve(h, "/v1/events?from=2026-10-18&to=2026-10-20&venue=nissan_stadium", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"from": "2026-10-18",
+		"to": "2026-10-20",
+		"venues": [{
+			"id": "nissan_stadium", "name": "日産スタジアム", "emoji": "⚽", "large": true,
+			"events": [{
+				"date": "2026-10-19",
+				"title": "Match",
+				"schedules": [{"open_time": null, "start_time": "2026-10-19T19:00:00+09:00"}]
+			}]
+		}]
+	}`, rec.Body.String())
+}
+
+func TestHandler_Events_DefaultsToToday(t *testing.T) {
+	arena, stadium, h, _ := setupHandler(t)
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+
+	arena.EXPECT().FetchEvents(gomock.Any(), today, today).Return(nil, nil)
+	stadium.EXPECT().FetchEvents(gomock.Any(), today, today).Return(nil, nil)
+
+	rec := serve(h, "/v1/events", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp EventsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "2026-10-18", resp.From)
+	assert.Equal(t, "2026-10-18", resp.To)
+	assert.Len(t, resp.Venues, 3)
+}
+
+func TestHandler_Events_BadRequest(t *testing.T) {
+	testCases := []struct {
+		target  string
+		wantMsg string
+	}{
+		{target: "/v1/events?from=18-10-2026", wantMsg: "from must be a date in YYYY-MM-DD format"},
+		{target: "/v1/events?from=2026-10-18&to=2026-10-17", wantMsg: "to must not be before from"},
+		{target: "/v1/events?from=2026-10-01&to=2026-11-01", wantMsg: "date range must not exceed 31 days"},
+		{target: "/v1/events?venue=tokyo_dome", wantMsg: `unknown venue "tokyo_dome"`},
+		{target: "/v1/congestion?date=tomorrow", wantMsg: "date must be a date in YYYY-MM-DD format"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.target, func(t *testing.T) {
+			_, _, h, _ := setupHandler(t)
+
+			rec := serve(h, tc.target, nil)
+
+			require.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			var resp ErrorResponse
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Equal(t, "invalid_request", resp.Error.Code)
+			assert.Equal(t, tc.wantMsg, resp.Error.Message)
+		})
+	}
+}
+
+func TestHandler_Events_PastDatesTheVenueStillLists(t *testing.T) {
+	_, stadium, h, _ := setupHandler(t)
+	date := time.Date(2026, 10, 17, 0, 0, 0, 0, event.JST)
+
+	stadium.EXPECT().FetchEvents(gomock.Any(), date, date).Return(nil, nil)
+
+	rec := serve(h, "/v1/events?from=2026-10-17&venue=nissan_stadium", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "unlisted_before")
+}
+
+func TestHandler_Congestion_ReportsUnlistedVenue(t *testing.T) {
+	arena, _, h, _ := setupHandler(t)
+	date := time.Date(2026, 9, 30, 0, 0, 0, 0, event.JST)
+
+	arena.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{{Date: date, Title: "Live"}}, nil)
+
+	rec := serve(h, "/v1/congestion?date=2026-09-30", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp CongestionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, 1, resp.TotalEvents)
+	require.Len(t, resp.Venues, 3)
+	assert.Empty(t, resp.Venues[0].UnlistedBefore)
+	assert.Equal(t, "2026-10-01", resp.Venues[1].UnlistedBefore)
+}
+
+func TestHandler_Events_UpstreamError(t *testing.T) {
+	arena, stadium, h, _ := setupHandler(t)
+	arena.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, errors.New("timeout"))
+	stadium.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+
+	rec := serve(h, "/v1/events", nil)
+
+	require.Equal(t, http.StatusBadGateway, rec.Code)
+	assert.Contains(t, rec.Body.String(), "upstream_unavailable")
+}
+
+func TestHandler_Congestion(t *testing.T) {
+	arena, stadium, h, _ := setupHandler(t)
+	date := time.Date(2026, 11, 3, 0, 0, 0, 0, event.JST)
+
+	arena.EXPECT().FetchEvents(gomock.Any(), date, date).Return([]event.Event{{Date: date, Title: "Live"}}, nil)
+	stadium.EXPECT().FetchEvents(gomock.Any(), date, date).Return(nil, nil)
+
+	rec := serve(h, "/v1/congestion?date=2026-11-03", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp CongestionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "2026-11-03", resp.Date)
+	assert.Equal(t, "moderate", resp.Level)
+	assert.Equal(t, 1, resp.TotalEvents)
+	assert.Equal(t, 1, resp.VenuesWithEvents)
+	assert.True(t, resp.LargeVenueActive)
+}
+
+func TestHandler_CachesResponsesWithETag(t *testing.T) {
+	arena, stadium, h, now := setupHandler(t)
+
+	// Each fetcher is called once per cache fill; the second request must be served from cache.
+	arena.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+	stadium.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil).Times(2)
+
+	first := serve(h, "/v1/events", nil)
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "public, max-age=900", first.Header().Get("Cache-Control"))
+
+	*now = now.Add(5 * time.Minute)
+	second := serve(h, "/v1/events", http.Header{"If-None-Match": {etag}})
+	assert.Equal(t, http.StatusNotModified, second.Code)
+	assert.Empty(t, second.Body.String())
+	assert.Equal(t, "public, max-age=600", second.Header().Get("Cache-Control"))
+
+	*now = now.Add(15 * time.Minute)
+	third := serve(h, "/v1/events", nil)
+	assert.Equal(t, http.StatusOK, third.Code)
+	assert.Equal(t, etag, third.Header().Get("ETag"))
+}
+
+func TestHandler_CacheDoesNotOutliveTheDay(t *testing.T) {
+	arena, stadium, h, now := setupHandler(t)
+	*now = time.Date(2026, 10, 18, 23, 55, 0, 0, event.JST)
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, event.JST)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	arena.EXPECT().FetchEvents(gomock.Any(), today, today).Return(nil, nil)
+	stadium.EXPECT().FetchEvents(gomock.Any(), today, today).Return(nil, nil)
+	arena.EXPECT().FetchEvents(gomock.Any(), tomorrow, tomorrow).Return(nil, nil)
+	stadium.EXPECT().FetchEvents(gomock.Any(), tomorrow, tomorrow).Return(nil, nil)
+
+	require.Equal(t, http.StatusOK, serve(h, "/v1/events", nil).Code)
+
+	*now = now.Add(10 * time.Minute)
+	rec := serve(h, "/v1/events", nil)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp EventsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "2026-10-19", resp.From)
+}
+
+func TestHandler_UnknownRoute(t *testing.T) {
+	_, _, h, _ := setupHandler(t)
+
+	rec := serve(h, "/v2/events", nil)
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
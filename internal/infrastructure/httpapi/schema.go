@@ -0,0 +1,169 @@
+package httpapi
+
+import (
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// The types below are the public v1 schema. Fields may be added, but existing fields must
+// keep their names and meaning.
+
+const dateLayout = "2006-01-02"
+
+type Venue struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Emoji string `json:"emoji"`
+	Large bool   `json:"large"`
+}
+
+// VenueDetail is a venue as listed by /v1/venues. Capabilities is omitted for a venue
+// without a configured fetcher.
+type VenueDetail struct {
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+	Venue
+}
+
+// Capabilities tells clients what to expect from a venue's events.
+type Capabilities struct {
+	// Earliest is the first date the venue still lists events for, if it is limited.
+	Earliest string `json:"earliest,omitempty"`
+	// Horizon is the last date the venue has published events for, if it is limited.
+	Horizon               string `json:"horizon,omitempty"`
+	MaxConcurrentRequests int    `json:"max_concurrent_requests"`
+	OpenTime              bool   `json:"open_time"`
+	EndTime               bool   `json:"end_time"`
+	// FirstPageOnly means events further ahead than the site's first page may be missing.
+	FirstPageOnly bool `json:"first_page_only"`
+}
+
+type VenueEvents struct {
+	// UnlistedBefore is the first date the venue still lists events for, set when the
+	// requested range starts before it. Events before that date are unknown, not absent.
+	UnlistedBefore string `json:"unlisted_before,omitempty"`
+	// UnpublishedFrom is the date from which the venue has not published events yet, set
+	// when the requested range reaches it. Events from that date on are unknown, not absent.
+	UnpublishedFrom string  `json:"unpublished_from,omitempty"`
+	Events          []Event `json:"events"`
+	Venue
+}
+
+type Event struct {
+	Date      string     `json:"date"`
+	Title     string     `json:"title"`
+	Schedules []Schedule `json:"schedules"`
+}
+
+// Schedule times are RFC 3339 with the JST offset; either may be null when the site does not list it.
+type Schedule struct {
+	OpenTime  *string `json:"open_time"`
+	StartTime *string `json:"start_time"`
+}
+
+type VenuesResponse struct {
+	Venues []VenueDetail `json:"venues"`
+}
+
+type EventsResponse struct {
+	From   string        `json:"from"`
+	To     string        `json:"to"`
+	Venues []VenueEvents `json:"venues"`
+}
+
+type CongestionResponse struct {
+	Date             string        `json:"date"`
+	Level            string        `json:"level"`
+	Venues           []VenueEvents `json:"venues"`
+	TotalEvents      int           `json:"total_events"`
+	VenuesWithEvents int           `json:"venues_with_events"`
+	LargeVenueActive bool          `json:"large_venue_active"`
+}
+
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func toVenue(v *event.Venue) Venue {
+	return Venue{ID: string(v.ID), Name: v.DisplayName, Emoji: v.Emoji, Large: v.Large}
+}
+
+func toVenueDetail(v *event.Venue, caps map[event.VenueID]ports.Capabilities) VenueDetail {
+	detail := VenueDetail{Venue: toVenue(v)}
+	c, ok := caps[v.ID]
+	if !ok {
+		return detail
+	}
+	detail.Capabilities = &Capabilities{
+		MaxConcurrentRequests: max(c.MaxConcurrentRequests, 1),
+		OpenTime:              c.OpenTime,
+		EndTime:               c.EndTime,
+		FirstPageOnly:         c.FirstPageOnly,
+	}
+	if !c.Earliest.IsZero() {
+		detail.Capabilities.Earliest = c.Earliest.In(event.JST).Format(dateLayout)
+	}
+	if !c.Horizon.IsZero() {
+		detail.Capabilities.Horizon = c.Horizon.In(event.JST).Format(dateLayout)
+	}
+	return detail
+}
+
+func toVenueEvents(venues []*event.Venue) []VenueEvents {
+	out := make([]VenueEvents, 0, len(venues))
+	for _, v := range venues {
+		ve := VenueEvents{Venue: toVenue(v), Events: make([]Event, 0, len(v.Events))}
+		if !v.UnlistedBefore.IsZero() {
+			ve.UnlistedBefore = v.UnlistedBefore.In(event.JST).Format(dateLayout)
+		}
+		if !v.UnpublishedFrom.IsZero() {
+			ve.UnpublishedFrom = v.UnpublishedFrom.In(event.JST).Format(dateLayout)
+		}
+		for _, e := range v.Events {
+			ve.Events = append(ve.Events, toEvent(e))
+		}
+		out = append(out, ve)
+	}
+	return out
+}
+
+func toEvent(e event.Event) Event {
+	out := Event{
+		Date:      e.Date.In(event.JST).Format(dateLayout),
+		Title:     e.Title,
+		Schedules: make([]Schedule, 0, len(e.Schedules)),
+	}
+	for _, s := range e.Schedules {
+		out.Schedules = append(out.Schedules, Schedule{
+			OpenTime:  formatTime(s.OpenTime),
+			StartTime: formatTime(s.StartTime),
+		})
+	}
+	return out
+}
+
+func toCongestionResponse(c *service.Congestion) CongestionResponse {
+	return CongestionResponse{
+		Date:             c.Date.Format(dateLayout),
+		Level:            string(c.Level),
+		Venues:           toVenueEvents(c.Venues),
+		TotalEvents:      c.TotalEvents,
+		VenuesWithEvents: c.VenuesWithEvents,
+		LargeVenueActive: c.LargeVenueActive,
+	}
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.In(event.JST).Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,97 @@
+package httpcache
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeS3Client struct {
+	objects map[string][]byte
+}
+
+func (c *fakeS3Client) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	data, ok := c.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (c *fakeS3Client) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.objects[*params.Bucket+"/"+*params.Key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func testEntry() *Entry {
+	return &Entry{
+		StoredAt: time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC),
+		Header:   http.Header{"Etag": {`"v1"`}},
+		Body:     []byte("body"),
+	}
+}
+
+func TestStores_RoundTrip(t *testing.T) {
+	stores := map[string]Store{
+		"memory": NewMemoryStore(),
+		"disk":   NewDiskStore(filepath.Join(t.TempDir(), "cache")),
+		"s3":     NewS3Store(&fakeS3Client{objects: map[string][]byte{}}, "bucket", "http-cache/"),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, ok, err := store.Load(ctx, "https://example.com/a")
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			require.NoError(t, store.Save(ctx, "https://example.com/a", testEntry()))
+
+			entry, ok, err := store.Load(ctx, "https://example.com/a")
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.True(t, testEntry().StoredAt.Equal(entry.StoredAt))
+			assert.Equal(t, testEntry().Header, entry.Header)
+			assert.Equal(t, testEntry().Body, entry.Body)
+
+			_, ok, err = store.Load(ctx, "https://example.com/b")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestDiskStore_CorruptEntry(t *testing.T) {
+	dir := t.TempDir()
+	store := NewDiskStore(dir)
+	require.NoError(t, os.WriteFile(store.path("key"), []byte("{"), 0o600))
+
+	_, _, err := store.Load(context.Background(), "key")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to parse cache entry")
+}
+
+func TestS3Store_ObjectKey(t *testing.T) {
+	client := &fakeS3Client{objects: map[string][]byte{}}
+	store := NewS3Store(client, "bucket", "http-cache/")
+
+	require.NoError(t, store.Save(context.Background(), "key", testEntry()))
+
+	assert.Contains(t, client.objects, "bucket/http-cache/"+hashKey("key")+".json")
+}
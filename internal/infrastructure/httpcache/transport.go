@@ -0,0 +1,148 @@
+package httpcache
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// Transport caches successful GET responses. Within the TTL a cached response is served
+// without contacting the site; after that it is revalidated with If-None-Match /
+// If-Modified-Since, so an unchanged page costs only a 304. Entries stored in an earlier
+// month are not reused at all, since some pages, like Nissan Stadium's /calendar/, show
+// the current month without naming it in the URL.
+// Cache failures are logged and never fail the request.
+type Transport struct {
+	next  http.RoundTripper
+	store Store
+	clock ports.Clock
+	ttl   time.Duration
+}
+
+type Option func(*Transport)
+
+func WithClock(clock ports.Clock) Option {
+	return func(t *Transport) {
+		t.clock = clock
+	}
+}
+
+// WithNext sets the transport used for requests that are not served from the cache.
+func WithNext(next http.RoundTripper) Option {
+	return func(t *Transport) {
+		t.next = next
+	}
+}
+
+func NewTransport(store Store, ttl time.Duration, opts ...Option) *Transport {
+	t := &Transport{
+		next:  http.DefaultTransport,
+		store: store,
+		clock: ports.ClockFunc(time.Now),
+		ttl:   ttl,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	key := req.URL.String()
+
+	entry, cached, err := t.store.Load(ctx, key)
+	if err != nil {
+		logging.FromContext(req.Context()).Warn("failed to read http cache", "url", key, logging.KeyError, err)
+		cached = false
+	}
+
+	now := t.clock.Now()
+	if cached && !sameMonth(entry.StoredAt, now) {
+		logging.FromContext(req.Context()).Debug("http cache entry is from an earlier month", "url", key)
+		cached = false
+	}
+	if cached && now.Sub(entry.StoredAt) < t.ttl {
+		logging.FromContext(req.Context()).Debug("http cache hit", "url", key)
+		return entry.response(req), nil
+	}
+
+	outReq := req
+	if cached {
+		outReq = req.Clone(ctx)
+		if etag := entry.Header.Get("ETag"); etag != "" {
+			outReq.Header.Set("If-None-Match", etag)
+		}
+		if lastModified := entry.Header.Get("Last-Modified"); lastModified != "" {
+			outReq.Header.Set("If-Modified-Since", lastModified)
+		}
+	}
+
+	resp, err := t.next.RoundTrip(outReq)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case cached && resp.StatusCode == http.StatusNotModified:
+		logger := logging.FromContext(req.Context())
+		if _, err := io.Copy(io.Discard, resp.Body); err != nil {
+			// The connection is just not reused; the cached entry is still valid.
+			logger.Debug("failed to drain not-modified response", "url", key, logging.KeyError, err)
+		}
+		resp.Body.Close()
+
+		logger.Debug("http cache revalidated", "url", key)
+		entry.StoredAt = now
+		t.save(req, key, entry)
+		return entry.response(req), nil
+
+	case resp.StatusCode == http.StatusOK:
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		t.save(req, key, &Entry{StoredAt: now, Header: resp.Header.Clone(), Body: body})
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		return resp, nil
+	}
+
+	return resp, nil
+}
+
+// sameMonth reports whether a and b fall in the same month in JST, the venues' time zone.
+func sameMonth(a, b time.Time) bool {
+	a, b = a.In(event.JST), b.In(event.JST)
+	return a.Year() == b.Year() && a.Month() == b.Month()
+}
+
+func (t *Transport) save(req *http.Request, key string, entry *Entry) {
+	if err := t.store.Save(req.Context(), key, entry); err != nil {
+		logging.FromContext(req.Context()).Warn("failed to write http cache", "url", key, logging.KeyError, err)
+	}
+}
+
+func (e *Entry) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        e.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,201 @@
+package httpcache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+type failingStore struct{}
+
+func (failingStore) Load(context.Context, string) (*Entry, bool, error) {
+	return nil, false, errors.New("unavailable")
+}
+
+func (failingStore) Save(context.Context, string, *Entry) error {
+	return errors.New("unavailable")
+}
+
+type testSite struct {
+	server   *httptest.Server
+	etag     string
+	modified string
+	body     string
+	requests []*http.Request
+}
+
+func newTestSite(t *testing.T) *testSite {
+	t.Helper()
+	site := &testSite{body: "v1", etag: `"v1"`}
+	site.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		site.requests = append(site.requests, r)
+		if site.etag != "" {
+			w.Header().Set("ETag", site.etag)
+			if r.Header.Get("If-None-Match") == site.etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		if site.modified != "" {
+			w.Header().Set("Last-Modified", site.modified)
+			if r.Header.Get("If-Modified-Since") == site.modified {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		//nolint:errcheck
+		io.WriteString(w, site.body)
+	}))
+	t.Cleanup(site.server.Close)
+	return site
+}
+
+func newTestHTTPClient(store Store, ttl time.Duration, now *time.Time) *http.Client {
+	return &http.Client{Transport: NewTransport(store, ttl, WithClock(ports.ClockFunc(func() time.Time { return *now })))}
+}
+
+func get(t *testing.T, client *http.Client, url string) (*http.Response, string) {
+	t.Helper()
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, string(body)
+}
+
+func TestTransport_ServesFreshEntriesFromCache(t *testing.T) {
+	site := newTestSite(t)
+	now := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	client := newTestHTTPClient(NewMemoryStore(), 30*time.Minute, &now)
+
+	_, first := get(t, client, site.server.URL)
+	now = now.Add(10 * time.Minute)
+	resp, second := get(t, client, site.server.URL)
+
+	assert.Len(t, site.requests, 1)
+	assert.Equal(t, "v1", first)
+	assert.Equal(t, "v1", second)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/html; charset=utf-8", resp.Header.Get("Content-Type"))
+}
+
+func TestTransport_DoesNotReuseEntriesFromEarlierMonth(t *testing.T) {
+	site := newTestSite(t)
+	now := time.Date(2026, 10, 31, 23, 50, 0, 0, event.JST)
+	client := newTestHTTPClient(NewMemoryStore(), 30*time.Minute, &now)
+
+	get(t, client, site.server.URL)
+	now = now.Add(15 * time.Minute)
+	site.body, site.etag = "v2", `"v2"`
+	_, body := get(t, client, site.server.URL)
+
+	require.Len(t, site.requests, 2)
+	assert.Empty(t, site.requests[1].Header.Get("If-None-Match"))
+	assert.Equal(t, "v2", body)
+}
+
+func TestTransport_RevalidatesWithETag(t *testing.T) {
+	site := newTestSite(t)
+	now := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	client := newTestHTTPClient(store, 30*time.Minute, &now)
+
+	get(t, client, site.server.URL)
+	now = now.Add(time.Hour)
+	resp, body := get(t, client, site.server.URL)
+
+	require.Len(t, site.requests, 2)
+	assert.Equal(t, `"v1"`, site.requests[1].Header.Get("If-None-Match"))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "v1", body)
+
+	// A 304 renews the entry, so the next request within the TTL stays local.
+	now = now.Add(10 * time.Minute)
+	get(t, client, site.server.URL)
+	assert.Len(t, site.requests, 2)
+}
+
+func TestTransport_RevalidatesWithLastModified(t *testing.T) {
+	site := newTestSite(t)
+	site.etag = ""
+	site.modified = "Mon, 19 Oct 2026 05:00:00 GMT"
+	now := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	client := newTestHTTPClient(NewMemoryStore(), 0, &now)
+
+	get(t, client, site.server.URL)
+	_, body := get(t, client, site.server.URL)
+
+	require.Len(t, site.requests, 2)
+	assert.Equal(t, site.modified, site.requests[1].Header.Get("If-Modified-Since"))
+	assert.Equal(t, "v1", body)
+}
+
+func TestTransport_ReplacesChangedContent(t *testing.T) {
+	site := newTestSite(t)
+	now := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	client := newTestHTTPClient(NewMemoryStore(), time.Minute, &now)
+
+	get(t, client, site.server.URL)
+	site.body, site.etag = "v2", `"v2"`
+	now = now.Add(time.Hour)
+	_, body := get(t, client, site.server.URL)
+	_, cached := get(t, client, site.server.URL)
+
+	assert.Len(t, site.requests, 2)
+	assert.Equal(t, "v2", body)
+	assert.Equal(t, "v2", cached)
+}
+
+func TestTransport_DoesNotCacheErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	now := time.Now()
+	client := newTestHTTPClient(NewMemoryStore(), time.Hour, &now)
+
+	resp, _ := get(t, client, server.URL)
+	get(t, client, server.URL)
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, 2, requests)
+}
+
+func TestTransport_PassesThroughNonGET(t *testing.T) {
+	site := newTestSite(t)
+	now := time.Now()
+	client := newTestHTTPClient(NewMemoryStore(), time.Hour, &now)
+
+	for range 2 {
+		resp, err := client.Post(site.server.URL, "text/plain", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Len(t, site.requests, 2)
+}
+
+func TestTransport_StoreFailuresDoNotFailRequests(t *testing.T) {
+	site := newTestSite(t)
+	now := time.Now()
+	client := newTestHTTPClient(failingStore{}, time.Hour, &now)
+
+	resp, body := get(t, client, site.server.URL)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "v1", body)
+}
@@ -0,0 +1,26 @@
+package lambda
+
+import (
+	"context"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// Flusher exports buffered telemetry, such as *tracing.Provider.
+type Flusher interface {
+	ForceFlush(ctx context.Context) error
+}
+
+// FlushAfter runs flusher after every invocation of handler. Lambda may freeze the
+// environment as soon as the handler returns, so spans left in a batcher would otherwise
+// be delayed until the next invocation or lost. A failed flush is logged but does not fail
+// the invocation.
+func FlushAfter(handler func(context.Context) error, flusher Flusher) func(context.Context) error {
+	return func(ctx context.Context) error {
+		err := handler(ctx)
+		if flushErr := flusher.ForceFlush(ctx); flushErr != nil {
+			logging.FromContext(ctx).Warn("failed to flush telemetry", logging.KeyError, flushErr)
+		}
+		return err
+	}
+}
@@ -0,0 +1,35 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type flusherFunc func(ctx context.Context) error
+
+func (f flusherFunc) ForceFlush(ctx context.Context) error {
+	return f(ctx)
+}
+
+func TestFlushAfter(t *testing.T) {
+	var calls []string
+	handlerErr := errors.New("fetch error")
+	handler := FlushAfter(
+		func(context.Context) error {
+			calls = append(calls, "handler")
+			return handlerErr
+		},
+		flusherFunc(func(context.Context) error {
+			calls = append(calls, "flush")
+			return errors.New("collector unreachable")
+		}),
+	)
+
+	err := handler(context.Background())
+
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Equal(t, []string{"handler", "flush"}, calls)
+}
@@ -18,6 +18,7 @@ func NewDailyHandler(eventService *service.EventNotificationService) *DailyHandl
 }
 
 func (h *DailyHandler) HandleRequest(ctx context.Context) error {
+	ctx = withRequestLogger(ctx)
 	if err := h.eventService.NotifyTodayEvents(ctx); err != nil {
 		return fmt.Errorf("failed to notify today events: %w", err)
 	}
@@ -1,10 +1,13 @@
 package lambda
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"log/slog"
 	"testing"
 
+	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
@@ -13,6 +16,7 @@ import (
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
 	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports/mock_ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
 )
 
 func TestNewDailyHandler(t *testing.T) {
@@ -89,6 +93,28 @@ func TestDailyHandler_HandleRequest_ContextPropagation(t *testing.T) {
 	assert.Equal(t, "testValue", capturedCtx.Value(testKey))
 }
 
+func TestDailyHandler_HandleRequest_RequestLogger(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	svc := service.NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher})
+	handler := NewDailyHandler(svc)
+
+	var buf bytes.Buffer
+	ctx := logging.NewContext(context.Background(), slog.New(logging.NewHandler(&buf, logging.FormatText, slog.LevelInfo)))
+	ctx = lambdacontext.NewContext(ctx, &lambdacontext.LambdaContext{AwsRequestID: "req-123"})
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, from, to interface{}) ([]event.Event, error) {
+		logging.FromContext(ctx).Info("fetching")
+		return []event.Event{}, nil
+	})
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	require.NoError(t, handler.HandleRequest(ctx))
+	assert.Contains(t, buf.String(), "msg=fetching aws_request_id=req-123")
+}
+
 func TestNewWeeklyHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	mockSender := mock_ports.NewMockNotificationSender(ctrl)
@@ -162,3 +188,51 @@ func TestWeeklyHandler_HandleRequest_ContextPropagation(t *testing.T) {
 	require.NotNil(t, capturedCtx)
 	assert.Equal(t, "testValue", capturedCtx.Value(testKey))
 }
+
+func TestNewTomorrowHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	svc := service.NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher})
+	handler := NewTomorrowHandler(svc)
+
+	require.NotNil(t, handler)
+	assert.NotNil(t, handler.eventService)
+}
+
+func TestTomorrowHandler_HandleRequest_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	svc := service.NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher})
+	handler := NewTomorrowHandler(svc)
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return([]event.Event{}, nil)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := handler.HandleRequest(context.Background())
+
+	require.NoError(t, err)
+}
+
+func TestTomorrowHandler_HandleRequest_ServiceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	mockSender := mock_ports.NewMockNotificationSender(ctrl)
+	mockFetcher := mock_ports.NewMockEventFetcher(ctrl)
+	mockFetcher.EXPECT().VenueID().Return(event.VenueIDYokohamaArena).AnyTimes()
+	svc := service.NewEventNotificationService(mockSender, []ports.EventFetcher{mockFetcher})
+	handler := NewTomorrowHandler(svc)
+
+	expectedErr := errors.New("fetch error")
+
+	mockFetcher.EXPECT().FetchEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+	mockSender.EXPECT().Send(gomock.Any(), gomock.Any()).Return(nil)
+
+	err := handler.HandleRequest(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to notify tomorrow events")
+	assert.ErrorIs(t, err, expectedErr)
+}
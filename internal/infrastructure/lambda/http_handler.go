@@ -0,0 +1,83 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// HTTPHandler serves an http.Handler behind an API Gateway HTTP API (payload format 2.0).
+type HTTPHandler struct {
+	handler http.Handler
+}
+
+func NewHTTPHandler(handler http.Handler) *HTTPHandler {
+	return &HTTPHandler{handler: handler}
+}
+
+func (h *HTTPHandler) HandleRequest(ctx context.Context, req events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	body := []byte(req.Body)
+	if req.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(req.Body)
+		if err != nil {
+			return events.APIGatewayV2HTTPResponse{}, fmt.Errorf("failed to decode request body: %w", err)
+		}
+		body = decoded
+	}
+
+	target := req.RawPath
+	if req.RawQueryString != "" {
+		target += "?" + req.RawQueryString
+	}
+
+	httpReq, err := http.NewRequestWithContext(withRequestLogger(ctx), req.RequestContext.HTTP.Method, target, bytes.NewReader(body))
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, fmt.Errorf("failed to create request: %w", err)
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+	if len(req.Cookies) > 0 {
+		httpReq.Header.Set("Cookie", strings.Join(req.Cookies, "; "))
+	}
+
+	w := &responseWriter{header: make(http.Header), status: http.StatusOK}
+	h.handler.ServeHTTP(w, httpReq)
+
+	resp := events.APIGatewayV2HTTPResponse{
+		StatusCode: w.status,
+		Headers:    make(map[string]string, len(w.header)),
+		Body:       w.body.String(),
+	}
+	for k, v := range w.header {
+		resp.Headers[k] = strings.Join(v, ",")
+	}
+	return resp, nil
+}
+
+type responseWriter struct {
+	header      http.Header
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+func (w *responseWriter) Header() http.Header { return w.header }
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.status = status
+	w.wroteHeader = true
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
@@ -0,0 +1,73 @@
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPHandler_HandleRequest(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/v1/events", r.URL.Path)
+		assert.Equal(t, "2026-10-18", r.URL.Query().Get("from"))
+		assert.Equal(t, `"abc"`, r.Header.Get("If-None-Match"))
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "payload", string(body))
+
+		w.Header().Set("ETag", `"def"`)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	})
+
+	resp, err := NewHTTPHandler(inner).HandleRequest(context.Background(), events.APIGatewayV2HTTPRequest{
+		RawPath:         "/v1/events",
+		RawQueryString:  "from=2026-10-18",
+		Headers:         map[string]string{"if-none-match": `"abc"`},
+		Body:            base64.StdEncoding.EncodeToString([]byte("payload")),
+		IsBase64Encoded: true,
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{Method: http.MethodPost},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, `"def"`, resp.Headers["Etag"])
+	assert.Equal(t, `{"ok":true}`, resp.Body)
+}
+
+func TestHTTPHandler_HandleRequest_DefaultStatus(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	})
+
+	resp, err := NewHTTPHandler(inner).HandleRequest(context.Background(), events.APIGatewayV2HTTPRequest{
+		RawPath: "/",
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{Method: http.MethodGet},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "hello", resp.Body)
+}
+
+func TestHTTPHandler_HandleRequest_InvalidBase64(t *testing.T) {
+	_, err := NewHTTPHandler(http.NotFoundHandler()).HandleRequest(context.Background(), events.APIGatewayV2HTTPRequest{
+		RawPath:         "/",
+		Body:            "%%%",
+		IsBase64Encoded: true,
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decode request body")
+}
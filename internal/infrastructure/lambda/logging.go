@@ -0,0 +1,18 @@
+package lambda
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// withRequestLogger tags the logger of ctx with the Lambda request id, which matches the
+// START/END lines Lambda writes for the invocation.
+func withRequestLogger(ctx context.Context) context.Context {
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		return logging.With(ctx, logging.KeyRequestID, lc.AwsRequestID)
+	}
+	return ctx
+}
@@ -0,0 +1,32 @@
+package lambda
+
+import (
+	"net/url"
+	"os"
+	"strings"
+)
+
+// LogLinkFromEnv returns a function linking to the CloudWatch Logs console view of the
+// current log stream, filtered by run id. It returns nil outside Lambda, where there is no
+// log stream to link to.
+func LogLinkFromEnv() func(runID string) string {
+	region := os.Getenv("AWS_REGION")
+	group := os.Getenv("AWS_LAMBDA_LOG_GROUP_NAME")
+	stream := os.Getenv("AWS_LAMBDA_LOG_STREAM_NAME")
+	if region == "" || group == "" || stream == "" {
+		return nil
+	}
+
+	base := "https://" + region + ".console.aws.amazon.com/cloudwatch/home?region=" + region +
+		"#logsV2:log-groups/log-group/" + consoleEscape(group) +
+		"/log-events/" + consoleEscape(stream)
+	return func(runID string) string {
+		return base + "$3FfilterPattern$3D" + consoleEscape(`"`+runID+`"`)
+	}
+}
+
+// consoleEscape encodes s the way the CloudWatch console expects in its URL fragment:
+// escaped twice, with "$" in place of "%".
+func consoleEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(url.QueryEscape(s)), "%", "$")
+}
@@ -0,0 +1,30 @@
+package lambda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLinkFromEnv(t *testing.T) {
+	t.Setenv("AWS_REGION", "ap-northeast-1")
+	t.Setenv("AWS_LAMBDA_LOG_GROUP_NAME", "/aws/lambda/notifier-daily")
+	t.Setenv("AWS_LAMBDA_LOG_STREAM_NAME", "2026/10/18/[$LATEST]abc123")
+
+	link := LogLinkFromEnv()
+	require.NotNil(t, link)
+
+	assert.Equal(t,
+		"https://ap-northeast-1.console.aws.amazon.com/cloudwatch/home?region=ap-northeast-1"+
+			"#logsV2:log-groups/log-group/$252Faws$252Flambda$252Fnotifier-daily"+
+			"/log-events/2026$252F10$252F18$252F$255B$2524LATEST$255Dabc123"+
+			"$3FfilterPattern$3D$2522deadbeef$2522",
+		link("deadbeef"))
+}
+
+func TestLogLinkFromEnv_OutsideLambda(t *testing.T) {
+	t.Setenv("AWS_LAMBDA_LOG_GROUP_NAME", "")
+
+	assert.Nil(t, LogLinkFromEnv())
+}
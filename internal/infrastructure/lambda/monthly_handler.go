@@ -0,0 +1,27 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+)
+
+type MonthlyHandler struct {
+	eventService *service.EventNotificationService
+}
+
+func NewMonthlyHandler(eventService *service.EventNotificationService) *MonthlyHandler {
+	return &MonthlyHandler{
+		eventService: eventService,
+	}
+}
+
+func (h *MonthlyHandler) HandleRequest(ctx context.Context) error {
+	ctx = withRequestLogger(ctx)
+	if err := h.eventService.NotifyMonthlyEvents(ctx); err != nil {
+		return fmt.Errorf("failed to notify monthly events: %w", err)
+	}
+
+	return nil
+}
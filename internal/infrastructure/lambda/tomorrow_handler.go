@@ -0,0 +1,27 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/application/service"
+)
+
+type TomorrowHandler struct {
+	eventService *service.EventNotificationService
+}
+
+func NewTomorrowHandler(eventService *service.EventNotificationService) *TomorrowHandler {
+	return &TomorrowHandler{
+		eventService: eventService,
+	}
+}
+
+func (h *TomorrowHandler) HandleRequest(ctx context.Context) error {
+	ctx = withRequestLogger(ctx)
+	if err := h.eventService.NotifyTomorrowEvents(ctx); err != nil {
+		return fmt.Errorf("failed to notify tomorrow events: %w", err)
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ func NewWeeklyHandler(eventService *service.EventNotificationService) *WeeklyHan
 }
 
 func (h *WeeklyHandler) HandleRequest(ctx context.Context) error {
+	ctx = withRequestLogger(ctx)
 	if err := h.eventService.NotifyWeeklyEvents(ctx); err != nil {
 		return fmt.Errorf("failed to notify weekly events: %w", err)
 	}
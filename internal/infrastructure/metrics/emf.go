@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+// EMF writes metrics to stdout in CloudWatch Embedded Metric Format. In Lambda, CloudWatch
+// Logs extracts them into custom metrics without any API calls.
+// See https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch_Embedded_Metric_Format_Specification.html
+type EMF struct {
+	w         io.Writer
+	clock     ports.Clock
+	namespace string
+	mu        sync.Mutex
+}
+
+type EMFOption func(*EMF)
+
+func WithWriter(w io.Writer) EMFOption {
+	return func(e *EMF) {
+		e.w = w
+	}
+}
+
+func WithClock(clock ports.Clock) EMFOption {
+	return func(e *EMF) {
+		e.clock = clock
+	}
+}
+
+func NewEMF(namespace string, opts ...EMFOption) *EMF {
+	e := &EMF{
+		w:         os.Stdout,
+		clock:     ports.ClockFunc(time.Now),
+		namespace: namespace,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+type emfMetadata struct {
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+	Timestamp         int64          `json:"Timestamp"`
+}
+
+type emfDirective struct {
+	Namespace  string          `json:"Namespace"`
+	Dimensions [][]string      `json:"Dimensions"`
+	Metrics    []emfDefinition `json:"Metrics"`
+}
+
+type emfDefinition struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+// Record writes one log line per distinct set of dimensions. Repeated metrics with the
+// same dimensions are written as an array of values.
+func (e *EMF) Record(ctx context.Context, metrics ...ports.Metric) {
+	timestamp := e.clock.Now().UnixMilli()
+
+	var keys []string
+	groups := make(map[string][]ports.Metric)
+	for _, m := range metrics {
+		key := dimensionsKey(m.Dimensions)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], m)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, key := range keys {
+		line, err := json.Marshal(e.document(groups[key], timestamp))
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to encode metrics", logging.KeyError, err)
+			continue
+		}
+		if _, err := e.w.Write(append(line, '\n')); err != nil {
+			logging.FromContext(ctx).Warn("failed to write metrics", logging.KeyError, err)
+		}
+	}
+}
+
+func (e *EMF) document(metrics []ports.Metric, timestamp int64) map[string]any {
+	dims := metrics[0].Dimensions
+	dimNames := slices.Sorted(maps.Keys(dims))
+	if dimNames == nil {
+		dimNames = []string{}
+	}
+
+	doc := make(map[string]any, len(dims)+len(metrics)+1)
+	for k, v := range dims {
+		doc[k] = v
+	}
+
+	directive := emfDirective{Namespace: e.namespace, Dimensions: [][]string{dimNames}}
+	values := make(map[string][]float64)
+	for _, m := range metrics {
+		if _, ok := values[m.Name]; !ok {
+			directive.Metrics = append(directive.Metrics, emfDefinition{Name: m.Name, Unit: string(m.Unit)})
+		}
+		values[m.Name] = append(values[m.Name], m.Value)
+	}
+	for name, v := range values {
+		if len(v) == 1 {
+			doc[name] = v[0]
+		} else {
+			doc[name] = v
+		}
+	}
+
+	doc["_aws"] = emfMetadata{Timestamp: timestamp, CloudWatchMetrics: []emfDirective{directive}}
+	return doc
+}
+
+func dimensionsKey(dims map[string]string) string {
+	var sb strings.Builder
+	for _, k := range slices.Sorted(maps.Keys(dims)) {
+		sb.WriteString(k + "=" + dims[k] + "\x00")
+	}
+	return sb.String()
+}
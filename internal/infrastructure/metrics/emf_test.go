@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+func TestEMF_Record(t *testing.T) {
+	var buf bytes.Buffer
+	now := time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC)
+	emf := NewEMF("Test", WithWriter(&buf), WithClock(ports.ClockFunc(func() time.Time { return now })))
+
+	venue := map[string]string{"venue": "nissan_stadium", "mode": "daily"}
+	emf.Record(context.Background(),
+		ports.Metric{Name: "FetchLatency", Unit: ports.UnitMilliseconds, Value: 120, Dimensions: venue},
+		ports.Metric{Name: "EventCount", Unit: ports.UnitCount, Value: 2, Dimensions: venue},
+		ports.Metric{Name: "DiscordResponses", Unit: ports.UnitCount, Value: 1, Dimensions: map[string]string{"status_code": "204"}},
+		ports.Metric{Name: "DiscordResponses", Unit: ports.UnitCount, Value: 1, Dimensions: map[string]string{"status_code": "204"}},
+	)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{
+		"_aws": {
+			"Timestamp": 1792303200000,
+			"CloudWatchMetrics": [{
+				"Namespace": "Test",
+				"Dimensions": [["mode", "venue"]],
+				"Metrics": [{"Name": "FetchLatency", "Unit": "Milliseconds"}, {"Name": "EventCount", "Unit": "Count"}]
+			}]
+		},
+		"venue": "nissan_stadium",
+		"mode": "daily",
+		"FetchLatency": 120,
+		"EventCount": 2
+	}`, lines[0])
+	assert.JSONEq(t, `{
+		"_aws": {
+			"Timestamp": 1792303200000,
+			"CloudWatchMetrics": [{
+				"Namespace": "Test",
+				"Dimensions": [["status_code"]],
+				"Metrics": [{"Name": "DiscordResponses", "Unit": "Count"}]
+			}]
+		},
+		"status_code": "204",
+		"DiscordResponses": [1, 1]
+	}`, lines[1])
+}
+
+func TestEMF_Record_NoDimensions(t *testing.T) {
+	var buf bytes.Buffer
+	emf := NewEMF("Test", WithWriter(&buf))
+
+	emf.Record(context.Background(), ports.Metric{Name: "Runs", Unit: ports.UnitCount, Value: 1})
+
+	assert.Contains(t, buf.String(), `"Dimensions":[[]]`)
+	assert.Contains(t, buf.String(), `"Runs":1`)
+}
+
+func TestMemory_Find(t *testing.T) {
+	m := NewMemory()
+	m.Record(context.Background(),
+		ports.Metric{Name: "FetchSuccess", Value: 1, Dimensions: map[string]string{"venue": "a", "mode": "daily"}},
+		ports.Metric{Name: "FetchSuccess", Value: 0, Dimensions: map[string]string{"venue": "b", "mode": "daily"}},
+	)
+
+	assert.Equal(t, []float64{1}, m.Find("FetchSuccess", map[string]string{"venue": "a"}))
+	assert.Equal(t, []float64{1, 0}, m.Find("FetchSuccess", map[string]string{"mode": "daily"}))
+	assert.Empty(t, m.Find("FetchFailure", nil))
+	assert.Len(t, m.Metrics(), 2)
+}
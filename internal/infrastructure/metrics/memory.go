@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// Noop discards every metric.
+type Noop struct{}
+
+func (Noop) Record(context.Context, ...ports.Metric) {}
+
+// Memory keeps recorded metrics in memory, for tests.
+type Memory struct {
+	metrics []ports.Metric
+	mu      sync.Mutex
+}
+
+func NewMemory() *Memory {
+	return &Memory{}
+}
+
+func (m *Memory) Record(_ context.Context, metrics ...ports.Metric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.metrics = append(m.metrics, metrics...)
+}
+
+// Metrics returns every metric recorded so far.
+func (m *Memory) Metrics() []ports.Metric {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ports.Metric(nil), m.metrics...)
+}
+
+// Find returns the values recorded under name whose dimensions include dims.
+func (m *Memory) Find(name string, dims map[string]string) []float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var values []float64
+	for _, metric := range m.metrics {
+		if metric.Name != name {
+			continue
+		}
+		if includes(metric.Dimensions, dims) {
+			values = append(values, metric.Value)
+		}
+	}
+	return values
+}
+
+func includes(dims, subset map[string]string) bool {
+	for k, v := range subset {
+		if got, ok := dims[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Cron is a parsed Amazon EventBridge cron expression, e.g. "cron(0 6 * * ? *)".
+// Fields are minutes, hours, day-of-month, month, day-of-week (1=SUN..7=SAT) and year.
+// The L, W and # extensions are not supported.
+type Cron struct {
+	location *time.Location
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[time.Weekday]bool
+	years    map[int]bool
+	// anyDay records that day-of-month is unrestricted, in which case day-of-week decides.
+	anyDay bool
+}
+
+var monthNames = map[string]int{
+	"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+	"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+}
+
+var weekdayNames = map[string]int{
+	"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+}
+
+// ParseCron parses expr, evaluating it in loc.
+func ParseCron(expr string, loc *time.Location) (*Cron, error) {
+	body := strings.TrimSpace(expr)
+	if !strings.HasPrefix(body, "cron(") || !strings.HasSuffix(body, ")") {
+		return nil, fmt.Errorf("invalid cron expression %q: must be of the form cron(...)", expr)
+	}
+	fields := strings.Fields(body[len("cron(") : len(body)-1])
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 6 fields, got %d", expr, len(fields))
+	}
+	if fields[2] != "?" && fields[4] != "?" {
+		return nil, fmt.Errorf("invalid cron expression %q: either day-of-month or day-of-week must be ?", expr)
+	}
+
+	c := &Cron{
+		location: loc,
+		anyDay:   fields[2] == "*" || fields[2] == "?",
+	}
+
+	var err error
+	if c.minutes, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: minutes: %w", expr, err)
+	}
+	if c.hours, err = parseField(fields[1], 0, 23, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: hours: %w", expr, err)
+	}
+	if c.days, err = parseField(fields[2], 1, 31, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: day-of-month: %w", expr, err)
+	}
+	if c.months, err = parseField(fields[3], 1, 12, monthNames); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: month: %w", expr, err)
+	}
+	weekdays, err := parseField(fields[4], 1, 7, weekdayNames)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: day-of-week: %w", expr, err)
+	}
+	c.weekdays = make(map[time.Weekday]bool, len(weekdays))
+	for d := range weekdays {
+		c.weekdays[time.Weekday(d-1)] = true
+	}
+	if c.years, err = parseField(fields[5], 1970, 2199, nil); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: year: %w", expr, err)
+	}
+
+	return c, nil
+}
+
+func parseField(field string, lo, hi int, names map[string]int) (map[int]bool, error) {
+	values := make(map[int]bool)
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if base, s, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", s)
+			}
+			part, step = base, n
+		}
+
+		start, end := lo, hi
+		switch {
+		case part == "*" || part == "?":
+		case strings.Contains(part, "-"):
+			a, b, _ := strings.Cut(part, "-")
+			var err error
+			if start, err = parseValue(a, lo, hi, names); err != nil {
+				return nil, err
+			}
+			if end, err = parseValue(b, lo, hi, names); err != nil {
+				return nil, err
+			}
+			if start > end {
+				return nil, fmt.Errorf("invalid range %q", part)
+			}
+		default:
+			v, err := parseValue(part, lo, hi, names)
+			if err != nil {
+				return nil, err
+			}
+			start = v
+			if step == 1 {
+				end = v
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
+
+func parseValue(s string, lo, hi int, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToUpper(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < lo || v > hi {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, lo, hi)
+	}
+	return v, nil
+}
+
+// Next returns the first time strictly after t that matches the expression,
+// or the zero time if there is none.
+func (c *Cron) Next(t time.Time) time.Time {
+	t = t.In(c.location).Truncate(time.Minute).Add(time.Minute)
+
+	// Walking minute by minute would be simple but slow for sparse schedules, so skip
+	// whole days and hours that cannot match.
+	for t.Year() <= 2199 {
+		if !c.years[t.Year()] {
+			t = time.Date(t.Year()+1, 1, 1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if !c.months[int(t.Month())] || !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, c.location)
+			continue
+		}
+		if !c.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, c.location)
+			continue
+		}
+		if !c.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c *Cron) matchesDay(t time.Time) bool {
+	if c.anyDay {
+		return c.weekdays[t.Weekday()]
+	}
+	return c.days[t.Day()]
+}
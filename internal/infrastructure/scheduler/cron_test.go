@@ -0,0 +1,111 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var jst = time.FixedZone("JST", 9*60*60)
+
+func TestCron_Next(t *testing.T) {
+	testCases := []struct {
+		after time.Time
+		want  time.Time
+		name  string
+		expr  string
+	}{
+		{
+			name:  "daily later today",
+			expr:  "cron(0 6 * * ? *)",
+			after: time.Date(2026, 10, 18, 5, 59, 0, 0, jst),
+			want:  time.Date(2026, 10, 18, 6, 0, 0, 0, jst),
+		},
+		{
+			name:  "daily strictly after",
+			expr:  "cron(0 6 * * ? *)",
+			after: time.Date(2026, 10, 18, 6, 0, 0, 0, jst),
+			want:  time.Date(2026, 10, 19, 6, 0, 0, 0, jst),
+		},
+		{
+			name:  "evaluated in JST regardless of input zone",
+			expr:  "cron(0 21 * * ? *)",
+			after: time.Date(2026, 10, 18, 11, 0, 0, 0, time.UTC),
+			want:  time.Date(2026, 10, 18, 21, 0, 0, 0, jst),
+		},
+		{
+			name:  "weekday names",
+			expr:  "cron(30 7 ? * MON-FRI *)",
+			after: time.Date(2026, 10, 17, 12, 0, 0, 0, jst), // Saturday
+			want:  time.Date(2026, 10, 19, 7, 30, 0, 0, jst),
+		},
+		{
+			name:  "numeric day of week starts at Sunday",
+			expr:  "cron(0 9 ? * 1 *)",
+			after: time.Date(2026, 10, 12, 0, 0, 0, 0, jst),
+			want:  time.Date(2026, 10, 18, 9, 0, 0, 0, jst),
+		},
+		{
+			name:  "day of month and month",
+			expr:  "cron(0 0 1 JAN,JUL ? *)",
+			after: time.Date(2026, 10, 18, 0, 0, 0, 0, jst),
+			want:  time.Date(2027, 1, 1, 0, 0, 0, 0, jst),
+		},
+		{
+			name:  "steps",
+			expr:  "cron(0/15 * * * ? *)",
+			after: time.Date(2026, 10, 18, 8, 16, 30, 0, jst),
+			want:  time.Date(2026, 10, 18, 8, 30, 0, 0, jst),
+		},
+		{
+			name:  "year",
+			expr:  "cron(0 6 * * ? 2027)",
+			after: time.Date(2026, 10, 18, 0, 0, 0, 0, jst),
+			want:  time.Date(2027, 1, 1, 6, 0, 0, 0, jst),
+		},
+		{
+			name:  "never again",
+			expr:  "cron(0 6 * * ? 2025)",
+			after: time.Date(2026, 10, 18, 0, 0, 0, 0, jst),
+			want:  time.Time{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := ParseCron(tc.expr, jst)
+			require.NoError(t, err)
+
+			got := c.Next(tc.after)
+
+			assert.True(t, tc.want.Equal(got), "want %v, got %v", tc.want, got)
+		})
+	}
+}
+
+func TestParseCron_Errors(t *testing.T) {
+	testCases := []struct {
+		expr    string
+		wantErr string
+	}{
+		{expr: "0 6 * * ?", wantErr: "must be of the form cron(...)"},
+		{expr: "cron(0 6 * * ?)", wantErr: "expected 6 fields"},
+		{expr: "cron(0 6 * * MON *)", wantErr: "either day-of-month or day-of-week must be ?"},
+		{expr: "cron(60 6 * * ? *)", wantErr: "minutes: value 60 out of range"},
+		{expr: "cron(0 6 L * ? *)", wantErr: `day-of-month: invalid value "L"`},
+		{expr: "cron(0 6 ? * MON#1 *)", wantErr: "day-of-week"},
+		{expr: "cron(0 6 ? * FRI-MON *)", wantErr: "invalid range"},
+		{expr: "cron(0/0 6 * * ? *)", wantErr: "invalid step"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := ParseCron(tc.expr, jst)
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package scheduler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+type healthResponse struct {
+	Status string      `json:"status"`
+	Jobs   []JobStatus `json:"jobs"`
+}
+
+// HealthHandler reports the scheduler as healthy while its loop keeps ticking,
+// together with the status of every job. A failed job does not make it unhealthy;
+// restarting the process would not fix an unreachable venue site.
+func (s *Scheduler) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp := healthResponse{Status: "ok", Jobs: s.Status()}
+		code := http.StatusOK
+		if !s.alive() {
+			resp.Status = "stalled"
+			code = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		if err := json.NewEncoder(w).Encode(resp); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to write health response", logging.KeyError, err)
+		}
+	})
+}
+
+func (s *Scheduler) alive() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.lastTick.IsZero() && s.clock.Now().Sub(s.lastTick) <= 3*pollInterval
+}
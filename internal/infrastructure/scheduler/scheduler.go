@@ -0,0 +1,186 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/pkg/logging"
+)
+
+const (
+	// DefaultCatchUpWindow bounds how late a missed run may still be executed,
+	// e.g. after the host wakes from sleep.
+	DefaultCatchUpWindow = 6 * time.Hour
+
+	// pollInterval caps how long the scheduler sleeps at once. Timers do not advance
+	// while the host is suspended, so the wall clock is re-checked regularly.
+	pollInterval = time.Minute
+)
+
+// Job is a task run whenever its schedule fires.
+type Job struct {
+	Run      func(ctx context.Context) error
+	Schedule *Cron
+	Name     string
+}
+
+// JobStatus describes the most recent and upcoming run of a job.
+type JobStatus struct {
+	LastRun   time.Time `json:"last_run,omitzero"`
+	NextRun   time.Time `json:"next_run,omitzero"`
+	Name      string    `json:"name"`
+	LastError string    `json:"last_error,omitempty"`
+	Running   bool      `json:"running"`
+}
+
+type jobState struct {
+	job    Job
+	status JobStatus
+}
+
+type Scheduler struct {
+	lastTick      time.Time
+	clock         ports.Clock
+	after         func(time.Duration) <-chan time.Time
+	jobs          []*jobState
+	wg            sync.WaitGroup
+	catchUpWindow time.Duration
+	mu            sync.Mutex
+}
+
+type Option func(*Scheduler)
+
+func WithClock(clock ports.Clock) Option {
+	return func(s *Scheduler) {
+		s.clock = clock
+	}
+}
+
+// WithCatchUpWindow sets how late a missed run may still be executed. Zero disables catch-up.
+func WithCatchUpWindow(d time.Duration) Option {
+	return func(s *Scheduler) {
+		s.catchUpWindow = d
+	}
+}
+
+func New(jobs []Job, opts ...Option) *Scheduler {
+	s := &Scheduler{
+		clock:         ports.ClockFunc(time.Now),
+		after:         time.After,
+		catchUpWindow: DefaultCatchUpWindow,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	now := s.clock.Now()
+	for _, job := range jobs {
+		s.jobs = append(s.jobs, &jobState{
+			job:    job,
+			status: JobStatus{Name: job.Name, NextRun: job.Schedule.Next(now)},
+		})
+	}
+	return s
+}
+
+// Run executes jobs as they become due until ctx is cancelled, then waits for
+// running jobs to finish. Jobs receive a context that is not cancelled on shutdown,
+// so a notification that has started is delivered completely.
+func (s *Scheduler) Run(ctx context.Context) {
+	for {
+		s.tick(ctx)
+
+		select {
+		case <-ctx.Done():
+			s.wg.Wait()
+			return
+		case <-s.after(s.sleepDuration()):
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	logger := logging.FromContext(ctx)
+	now := s.clock.Now()
+	s.lastTick = now
+	for _, st := range s.jobs {
+		due := st.status.NextRun
+		if due.IsZero() || now.Before(due) {
+			continue
+		}
+		st.status.NextRun = st.job.Schedule.Next(now)
+
+		if late := now.Sub(due); late > pollInterval {
+			if late > s.catchUpWindow {
+				logger.Warn("skipping missed run", "job", st.job.Name, "scheduled", due)
+				continue
+			}
+			logger.Info("catching up missed run", "job", st.job.Name, "scheduled", due)
+		}
+
+		if st.status.Running {
+			logger.Warn("previous run still in progress, skipping", "job", st.job.Name)
+			continue
+		}
+
+		st.status.Running = true
+		s.wg.Add(1)
+		go s.execute(context.WithoutCancel(ctx), st)
+	}
+}
+
+func (s *Scheduler) execute(ctx context.Context, st *jobState) {
+	defer s.wg.Done()
+
+	// Everything the job logs carries the job name too.
+	ctx = logging.With(ctx, "job", st.job.Name)
+	logger := logging.FromContext(ctx)
+
+	logger.Info("job started")
+	err := st.job.Run(ctx)
+	if err != nil {
+		logger.Error("job failed", logging.KeyError, err)
+	} else {
+		logger.Info("job finished")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st.status.Running = false
+	st.status.LastRun = s.clock.Now()
+	st.status.LastError = ""
+	if err != nil {
+		st.status.LastError = err.Error()
+	}
+}
+
+func (s *Scheduler) sleepDuration() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	d := pollInterval
+	for _, st := range s.jobs {
+		if next := st.status.NextRun; !next.IsZero() && next.Sub(now) < d {
+			d = max(next.Sub(now), 0)
+		}
+	}
+	return d
+}
+
+// Status returns a snapshot of every job.
+func (s *Scheduler) Status() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, 0, len(s.jobs))
+	for _, st := range s.jobs {
+		statuses = append(statuses, st.status)
+	}
+	return statuses
+}
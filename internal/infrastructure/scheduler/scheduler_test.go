@@ -0,0 +1,196 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+type fakeClock struct {
+	now time.Time
+	mu  sync.Mutex
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = t
+}
+
+func newDailyJob(t *testing.T, run func(context.Context) error) Job {
+	t.Helper()
+	c, err := ParseCron("cron(0 6 * * ? *)", jst)
+	require.NoError(t, err)
+	return Job{Name: "daily", Schedule: c, Run: run}
+}
+
+func TestScheduler_RunsDueJob(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 5, 59, 0, 0, jst)}
+	var runs atomic.Int32
+	s := New([]Job{newDailyJob(t, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})}, WithClock(clock))
+
+	s.tick(context.Background())
+	s.wg.Wait()
+	assert.Zero(t, runs.Load())
+
+	clock.Set(time.Date(2026, 10, 18, 6, 0, 0, 0, jst))
+	s.tick(context.Background())
+	s.wg.Wait()
+	assert.Equal(t, int32(1), runs.Load())
+
+	status := s.Status()[0]
+	assert.True(t, time.Date(2026, 10, 19, 6, 0, 0, 0, jst).Equal(status.NextRun))
+	assert.True(t, clock.Now().Equal(status.LastRun))
+	assert.Empty(t, status.LastError)
+}
+
+func TestScheduler_RecordsJobError(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 5, 59, 0, 0, jst)}
+	s := New([]Job{newDailyJob(t, func(context.Context) error {
+		return errors.New("site unreachable")
+	})}, WithClock(clock))
+
+	clock.Set(time.Date(2026, 10, 18, 6, 0, 0, 0, jst))
+	s.tick(context.Background())
+	s.wg.Wait()
+
+	assert.Equal(t, "site unreachable", s.Status()[0].LastError)
+}
+
+func TestScheduler_SkipsOverlappingRun(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 5, 59, 0, 0, jst)}
+	release := make(chan struct{})
+	var runs atomic.Int32
+	s := New([]Job{newDailyJob(t, func(context.Context) error {
+		runs.Add(1)
+		<-release
+		return nil
+	})}, WithClock(clock))
+
+	clock.Set(time.Date(2026, 10, 18, 6, 0, 0, 0, jst))
+	s.tick(context.Background())
+
+	clock.Set(time.Date(2026, 10, 19, 6, 0, 0, 0, jst))
+	s.tick(context.Background())
+
+	close(release)
+	s.wg.Wait()
+	assert.Equal(t, int32(1), runs.Load())
+	assert.True(t, time.Date(2026, 10, 20, 6, 0, 0, 0, jst).Equal(s.Status()[0].NextRun))
+}
+
+func TestScheduler_CatchesUpMissedRun(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 5, 0, 0, 0, jst)}
+	var runs atomic.Int32
+	s := New([]Job{newDailyJob(t, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})}, WithClock(clock))
+
+	// The host slept through 06:00 and woke up at 08:30.
+	clock.Set(time.Date(2026, 10, 18, 8, 30, 0, 0, jst))
+	s.tick(context.Background())
+	s.wg.Wait()
+
+	assert.Equal(t, int32(1), runs.Load())
+}
+
+func TestScheduler_SkipsRunOutsideCatchUpWindow(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 5, 0, 0, 0, jst)}
+	var runs atomic.Int32
+	s := New([]Job{newDailyJob(t, func(context.Context) error {
+		runs.Add(1)
+		return nil
+	})}, WithClock(clock), WithCatchUpWindow(time.Hour))
+
+	clock.Set(time.Date(2026, 10, 18, 8, 30, 0, 0, jst))
+	s.tick(context.Background())
+	s.wg.Wait()
+
+	assert.Zero(t, runs.Load())
+	assert.True(t, time.Date(2026, 10, 19, 6, 0, 0, 0, jst).Equal(s.Status()[0].NextRun))
+}
+
+func TestScheduler_SleepDuration(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 5, 59, 30, 0, jst)}
+	s := New([]Job{newDailyJob(t, func(context.Context) error { return nil })}, WithClock(clock))
+
+	assert.Equal(t, 30*time.Second, s.sleepDuration())
+
+	clock.Set(time.Date(2026, 10, 18, 1, 0, 0, 0, jst))
+	assert.Equal(t, pollInterval, s.sleepDuration())
+}
+
+func TestScheduler_RunWaitsForJobsOnShutdown(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 5, 59, 0, 0, jst)}
+	started := make(chan struct{})
+	var finished atomic.Bool
+	var jobCtxErr error
+	s := New([]Job{newDailyJob(t, func(ctx context.Context) error {
+		close(started)
+		time.Sleep(20 * time.Millisecond)
+		jobCtxErr = ctx.Err()
+		finished.Store(true)
+		return nil
+	})}, WithClock(ports.Clock(clock)))
+	clock.Set(time.Date(2026, 10, 18, 6, 0, 0, 0, jst))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	<-started
+	cancel()
+	<-done
+
+	assert.True(t, finished.Load())
+	assert.NoError(t, jobCtxErr)
+}
+
+func TestScheduler_HealthHandler(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 5, 0, 0, 0, jst)}
+	s := New([]Job{newDailyJob(t, func(context.Context) error { return nil })}, WithClock(clock))
+
+	rec := httptest.NewRecorder()
+	s.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	s.tick(context.Background())
+	rec = httptest.NewRecorder()
+	s.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var body healthResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.Equal(t, "ok", body.Status)
+	require.Len(t, body.Jobs, 1)
+	assert.Equal(t, "daily", body.Jobs[0].Name)
+
+	clock.Set(clock.Now().Add(10 * time.Minute))
+	rec = httptest.NewRecorder()
+	s.HealthHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
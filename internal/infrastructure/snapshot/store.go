@@ -0,0 +1,111 @@
+// Package snapshot stores the last successful fetch result per venue and date, used when
+// a venue's site is down.
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+// MemoryStore keeps snapshots for the lifetime of the process, e.g. the scheduler daemon.
+type MemoryStore struct {
+	snapshots map[string]ports.Snapshot
+	mu        sync.Mutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{snapshots: make(map[string]ports.Snapshot)}
+}
+
+func (s *MemoryStore) Load(_ context.Context, venue event.VenueID, date string) (*ports.Snapshot, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshot, ok := s.snapshots[key(venue, date)]
+	if !ok {
+		return nil, false, nil
+	}
+	return &snapshot, true, nil
+}
+
+func (s *MemoryStore) Save(_ context.Context, venue event.VenueID, date string, snapshot *ports.Snapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.snapshots[key(venue, date)] = *snapshot
+	return nil
+}
+
+// DiskStore keeps one JSON file per venue and date in a directory.
+type DiskStore struct {
+	dir string
+}
+
+func NewDiskStore(dir string) *DiskStore {
+	return &DiskStore{dir: dir}
+}
+
+func (s *DiskStore) Load(_ context.Context, venue event.VenueID, date string) (*ports.Snapshot, bool, error) {
+	data, err := os.ReadFile(s.path(venue, date))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snapshot ports.Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, false, fmt.Errorf("failed to parse snapshot: %w", err)
+	}
+	return &snapshot, true, nil
+}
+
+func (s *DiskStore) Save(_ context.Context, venue event.VenueID, date string, snapshot *ports.Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	path := s.path(venue, date)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	// Write to a temporary file and rename, so a crash never leaves a truncated snapshot.
+	tmp, err := os.CreateTemp(filepath.Dir(path), "snapshot-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+func (s *DiskStore) path(venue event.VenueID, date string) string {
+	return filepath.Join(s.dir, key(venue, date))
+}
+
+// key is also the relative path and object key, e.g. "yokohama_arena/2026-10-18.json".
+func key(venue event.VenueID, date string) string {
+	return string(venue) + "/" + date + ".json"
+}
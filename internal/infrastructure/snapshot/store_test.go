@@ -0,0 +1,97 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/event"
+	"github.com/Eagle-Konbu/shin-yokohama-event-notifier/internal/domain/ports"
+)
+
+type fakeS3Client struct {
+	objects map[string][]byte
+}
+
+func (c *fakeS3Client) GetObject(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	data, ok := c.objects[*params.Bucket+"/"+*params.Key]
+	if !ok {
+		return nil, &types.NoSuchKey{}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(data))}, nil
+}
+
+func (c *fakeS3Client) PutObject(_ context.Context, params *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	data, err := io.ReadAll(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	c.objects[*params.Bucket+"/"+*params.Key] = data
+	return &s3.PutObjectOutput{}, nil
+}
+
+func testSnapshot() *ports.Snapshot {
+	start := time.Date(2026, 10, 19, 18, 0, 0, 0, event.JST)
+	return &ports.Snapshot{
+		FetchedAt: time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC),
+		Events: []event.Event{{
+			Date:      time.Date(2026, 10, 19, 0, 0, 0, 0, event.JST),
+			Title:     "Live",
+			Schedules: []event.Schedule{{StartTime: &start}},
+		}},
+	}
+}
+
+func TestStores_RoundTrip(t *testing.T) {
+	s3Client := &fakeS3Client{objects: map[string][]byte{}}
+	stores := map[string]ports.SnapshotStore{
+		"memory": NewMemoryStore(),
+		"disk":   NewDiskStore(filepath.Join(t.TempDir(), "snapshots")),
+		"s3":     NewS3Store(s3Client, "bucket", "last-known-good/"),
+	}
+
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			_, ok, err := store.Load(ctx, event.VenueIDYokohamaArena, "2026-10-19")
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			require.NoError(t, store.Save(ctx, event.VenueIDYokohamaArena, "2026-10-19", testSnapshot()))
+
+			snapshot, ok, err := store.Load(ctx, event.VenueIDYokohamaArena, "2026-10-19")
+			require.NoError(t, err)
+			require.True(t, ok)
+			assert.True(t, testSnapshot().FetchedAt.Equal(snapshot.FetchedAt))
+			require.Len(t, snapshot.Events, 1)
+			assert.Equal(t, "Live", snapshot.Events[0].Title)
+			assert.True(t, testSnapshot().Events[0].Schedules[0].StartTime.Equal(*snapshot.Events[0].Schedules[0].StartTime))
+
+			_, ok, err = store.Load(ctx, event.VenueIDNissanStadium, "2026-10-19")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		})
+	}
+	assert.Contains(t, s3Client.objects, "bucket/last-known-good/yokohama_arena/2026-10-19.json")
+}
+
+func TestDiskStore_CorruptSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	store := NewDiskStore(dir)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "yokohama_arena"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "yokohama_arena", "2026-10-19.json"), []byte("{"), 0o644))
+
+	_, _, err := store.Load(context.Background(), event.VenueIDYokohamaArena, "2026-10-19")
+
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+// Exporters accepted by Setup.
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+)
+
+// DefaultServiceName is reported unless OTEL_SERVICE_NAME overrides it.
+const DefaultServiceName = "shin-yokohama-event-notifier"
+
+// Provider flushes and shuts down the tracer provider installed by Setup. A nil Provider,
+// returned when tracing is off, does nothing.
+type Provider struct {
+	tp *sdktrace.TracerProvider
+}
+
+type options struct {
+	writer io.Writer
+}
+
+type Option func(*options)
+
+// WithWriter sets where the stdout exporter writes. Defaults to os.Stdout.
+func WithWriter(w io.Writer) Option {
+	return func(o *options) {
+		o.writer = w
+	}
+}
+
+// Setup installs a global tracer provider exporting to exporter. The OTLP exporter is
+// configured by the standard OTEL_EXPORTER_OTLP_* environment variables. No propagator is
+// installed, so trace context is never sent to the venue sites or Discord. Packages look
+// their tracer up from the global provider on every use, so spans follow whichever
+// provider is installed at the time rather than the first one set.
+func Setup(ctx context.Context, exporter string, opts ...Option) (*Provider, error) {
+	o := options{writer: os.Stdout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var exp sdktrace.SpanExporter
+	var err error
+	switch exporter {
+	case "", ExporterNone:
+		return nil, nil
+	case ExporterOTLP:
+		exp, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(o.writer))
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s trace exporter: %w", exporter, err)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(semconv.ServiceName(DefaultServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return &Provider{tp: tp}, nil
+}
+
+// ForceFlush exports buffered spans. Lambda handlers call it before returning, since the
+// environment may be frozen before the batcher runs again.
+func (p *Provider) ForceFlush(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	return p.tp.ForceFlush(ctx)
+}
+
+func (p *Provider) Shutdown(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	return p.tp.Shutdown(ctx)
+}
@@ -0,0 +1,47 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup_None(t *testing.T) {
+	for _, exporter := range []string{"", ExporterNone} {
+		p, err := Setup(context.Background(), exporter)
+
+		require.NoError(t, err)
+		assert.Nil(t, p)
+		assert.NoError(t, p.ForceFlush(context.Background()))
+		assert.NoError(t, p.Shutdown(context.Background()))
+	}
+}
+
+func TestSetup_Stdout(t *testing.T) {
+	original := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(original) })
+	t.Setenv("OTEL_SERVICE_NAME", "")
+
+	var buf bytes.Buffer
+	p, err := Setup(context.Background(), ExporterStdout, WithWriter(&buf))
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "fetch")
+	span.End()
+	require.NoError(t, p.ForceFlush(context.Background()))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	assert.Contains(t, buf.String(), `"Name":"fetch"`)
+	assert.Contains(t, buf.String(), DefaultServiceName)
+}
+
+func TestSetup_Unsupported(t *testing.T) {
+	_, err := Setup(context.Background(), "zipkin")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "zipkin")
+}
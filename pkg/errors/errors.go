@@ -1,11 +1,52 @@
 package errors
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	CodeInfrastructure = "INFRASTRUCTURE_ERROR"
+	CodeValidation     = "VALIDATION_ERROR"
+	// CodeSourceUnavailable means a venue site could not be reached or answered with an error.
+	CodeSourceUnavailable = "SOURCE_UNAVAILABLE"
+	// CodeLayoutChanged means a scraped page no longer has the structure its parser expects.
+	CodeLayoutChanged = "LAYOUT_CHANGED"
+	// CodeParseFailure means a single value on a page, such as a date, could not be parsed.
+	CodeParseFailure = "PARSE_FAILURE"
+	// CodeRateLimited means a venue site or a destination asked us to slow down.
+	CodeRateLimited = "RATE_LIMITED"
+	// CodeDestinationRejected means a destination refused the notification itself.
+	CodeDestinationRejected = "DESTINATION_REJECTED"
+	// CodeDestinationUnauthorized means a destination no longer accepts our credentials,
+	// for example because the webhook was deleted.
+	CodeDestinationUnauthorized = "DESTINATION_UNAUTHORIZED"
+	// CodeConfigInvalid means the configuration could not be loaded as given.
+	CodeConfigInvalid = "CONFIG_INVALID"
+)
+
+// Sentinels for errors.Is. A DomainError matches the sentinel with the same code.
+var (
+	ErrValidation              = &DomainError{Code: CodeValidation}
+	ErrSourceUnavailable       = &DomainError{Code: CodeSourceUnavailable}
+	ErrLayoutChanged           = &DomainError{Code: CodeLayoutChanged}
+	ErrParseFailure            = &DomainError{Code: CodeParseFailure}
+	ErrRateLimited             = &DomainError{Code: CodeRateLimited}
+	ErrDestinationRejected     = &DomainError{Code: CodeDestinationRejected}
+	ErrDestinationUnauthorized = &DomainError{Code: CodeDestinationUnauthorized}
+	ErrConfigInvalid           = &DomainError{Code: CodeConfigInvalid}
+)
 
 type DomainError struct {
 	Err     error
 	Code    string
 	Message string
+	// Venue is the ID of the venue whose site failed, for source errors.
+	Venue string
+	// Destination names where a notification could not be delivered, for destination errors.
+	Destination string
+	// Retryable reports whether the same request may succeed later without any change.
+	Retryable bool
 }
 
 func (e *DomainError) Error() string {
@@ -19,9 +60,16 @@ func (e *DomainError) Unwrap() error {
 	return e.Err
 }
 
+// Is makes errors.Is match any DomainError with the same code as target, so callers can
+// compare against the sentinels above.
+func (e *DomainError) Is(target error) bool {
+	t, ok := target.(*DomainError)
+	return ok && t.Code == e.Code
+}
+
 func NewInfrastructureError(message string, err error) *DomainError {
 	return &DomainError{
-		Code:    "INFRASTRUCTURE_ERROR",
+		Code:    CodeInfrastructure,
 		Message: message,
 		Err:     err,
 	}
@@ -29,7 +77,111 @@ func NewInfrastructureError(message string, err error) *DomainError {
 
 func NewValidationError(message string) *DomainError {
 	return &DomainError{
-		Code:    "VALIDATION_ERROR",
+		Code:    CodeValidation,
 		Message: message,
 	}
 }
+
+// NewSourceUnavailableError reports that the site of venue could not be fetched. It is
+// retryable, since venue sites are usually down only briefly.
+func NewSourceUnavailableError(venue string, err error) *DomainError {
+	return &DomainError{
+		Code:      CodeSourceUnavailable,
+		Message:   venue + ": site is unavailable",
+		Venue:     venue,
+		Err:       err,
+		Retryable: true,
+	}
+}
+
+// NewLayoutChangedError reports that the page of source could not be parsed, which must not
+// be mistaken for the page listing no events.
+func NewLayoutChangedError(source, message string) *DomainError {
+	return &DomainError{
+		Code:    CodeLayoutChanged,
+		Message: source + ": " + message,
+		Venue:   source,
+	}
+}
+
+// NewParseError reports that a value on the page of venue could not be parsed.
+func NewParseError(venue, message string, err error) *DomainError {
+	return &DomainError{
+		Code:    CodeParseFailure,
+		Message: venue + ": " + message,
+		Venue:   venue,
+		Err:     err,
+	}
+}
+
+// NewSourceRateLimitedError reports that the site of venue refused us with 429 Too Many Requests.
+func NewSourceRateLimitedError(venue string, err error) *DomainError {
+	return &DomainError{
+		Code:      CodeRateLimited,
+		Message:   venue + ": rate limited",
+		Venue:     venue,
+		Err:       err,
+		Retryable: true,
+	}
+}
+
+// NewDestinationRateLimitedError reports that destination refused a notification with 429
+// Too Many Requests.
+func NewDestinationRateLimitedError(destination string, err error) *DomainError {
+	return &DomainError{
+		Code:        CodeRateLimited,
+		Message:     destination + ": rate limited",
+		Destination: destination,
+		Err:         err,
+		Retryable:   true,
+	}
+}
+
+// NewDestinationRejectedError reports that destination refused the notification, which
+// will fail the same way until the notification changes.
+func NewDestinationRejectedError(destination string, err error) *DomainError {
+	return &DomainError{
+		Code:        CodeDestinationRejected,
+		Message:     destination + ": notification rejected",
+		Destination: destination,
+		Err:         err,
+	}
+}
+
+// NewDestinationUnauthorizedError reports that destination no longer accepts our
+// credentials, which needs the configuration to be fixed.
+func NewDestinationUnauthorizedError(destination string, err error) *DomainError {
+	return &DomainError{
+		Code:        CodeDestinationUnauthorized,
+		Message:     destination + ": unauthorized",
+		Destination: destination,
+		Err:         err,
+	}
+}
+
+// NewConfigInvalidError reports a configuration that cannot be used as given.
+func NewConfigInvalidError(message string, err error) *DomainError {
+	return &DomainError{
+		Code:    CodeConfigInvalid,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// IsLayoutChanged reports whether err wraps an error created by NewLayoutChangedError.
+func IsLayoutChanged(err error) bool {
+	return errors.Is(err, ErrLayoutChanged)
+}
+
+// IsRetryable reports whether err wraps a DomainError that may succeed when retried.
+func IsRetryable(err error) bool {
+	var domainErr *DomainError
+	return errors.As(err, &domainErr) && domainErr.Retryable
+}
+
+// AsDomainError returns the first DomainError wrapped by err.
+func AsDomainError(err error) (*DomainError, bool) {
+	var domainErr *DomainError
+	ok := errors.As(err, &domainErr)
+	return domainErr, ok
+}
@@ -2,6 +2,7 @@ package errors
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -123,3 +124,70 @@ func TestDomainError_ErrorChaining(t *testing.T) {
 	assert.Contains(t, validationErr.Error(), "VALIDATION_ERROR")
 	assert.Contains(t, validationErr.Error(), "layer 2")
 }
+
+func TestNewLayoutChangedError(t *testing.T) {
+	domainErr := NewLayoutChangedError("nissan_stadium", "calendar page has no dated rows")
+
+	require.NotNil(t, domainErr)
+	assert.Equal(t, "LAYOUT_CHANGED", domainErr.Code)
+	assert.Equal(t, "nissan_stadium: calendar page has no dated rows", domainErr.Message)
+	assert.Nil(t, domainErr.Err)
+}
+
+func TestIsLayoutChanged(t *testing.T) {
+	layoutErr := NewLayoutChangedError("skate_center", "page has no JSON-LD")
+
+	assert.True(t, IsLayoutChanged(layoutErr))
+	assert.True(t, IsLayoutChanged(fmt.Errorf("failed to fetch: %w", layoutErr)))
+	assert.False(t, IsLayoutChanged(NewInfrastructureError("timeout", nil)))
+	assert.False(t, IsLayoutChanged(errors.New("plain")))
+	assert.False(t, IsLayoutChanged(nil))
+}
+
+func TestConstructors(t *testing.T) {
+	cause := errors.New("cause")
+	testCases := []struct {
+		name        string
+		err         *DomainError
+		sentinel    error
+		venue       string
+		destination string
+		retryable   bool
+	}{
+		{name: "validation", err: NewValidationError("date must not be in the past"), sentinel: ErrValidation},
+		{name: "source unavailable", err: NewSourceUnavailableError("yokohama_arena", cause), sentinel: ErrSourceUnavailable, venue: "yokohama_arena", retryable: true},
+		{name: "layout changed", err: NewLayoutChangedError("skate_center", "page has no JSON-LD"), sentinel: ErrLayoutChanged, venue: "skate_center"},
+		{name: "parse failure", err: NewParseError("nissan_stadium", "unparseable date", cause), sentinel: ErrParseFailure, venue: "nissan_stadium"},
+		{name: "source rate limited", err: NewSourceRateLimitedError("yokohama_arena", cause), sentinel: ErrRateLimited, venue: "yokohama_arena", retryable: true},
+		{name: "destination rate limited", err: NewDestinationRateLimitedError("discord", cause), sentinel: ErrRateLimited, destination: "discord", retryable: true},
+		{name: "destination rejected", err: NewDestinationRejectedError("discord", cause), sentinel: ErrDestinationRejected, destination: "discord"},
+		{name: "destination unauthorized", err: NewDestinationUnauthorizedError("discord", cause), sentinel: ErrDestinationUnauthorized, destination: "discord"},
+		{name: "config invalid", err: NewConfigInvalidError("invalid RUN_REPORTS", cause), sentinel: ErrConfigInvalid},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			wrapped := fmt.Errorf("failed to notify: %w", tc.err)
+
+			assert.ErrorIs(t, wrapped, tc.sentinel)
+			assert.Equal(t, tc.venue, tc.err.Venue)
+			assert.Equal(t, tc.destination, tc.err.Destination)
+			assert.Equal(t, tc.retryable, IsRetryable(wrapped))
+
+			domainErr, ok := AsDomainError(wrapped)
+			require.True(t, ok)
+			assert.Same(t, tc.err, domainErr)
+		})
+	}
+}
+
+func TestDomainError_Is_OtherCode(t *testing.T) {
+	err := NewSourceUnavailableError("yokohama_arena", nil)
+
+	assert.NotErrorIs(t, err, ErrLayoutChanged)
+	assert.NotErrorIs(t, errors.New("plain"), ErrSourceUnavailable)
+	assert.False(t, IsRetryable(errors.New("plain")))
+
+	_, ok := AsDomainError(errors.New("plain"))
+	assert.False(t, ok)
+}
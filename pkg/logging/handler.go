@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+// Output formats accepted by NewHandler.
+const (
+	FormatJSON = "json"
+	FormatText = "text"
+)
+
+const redacted = "[REDACTED]"
+
+// webhookPattern matches the secret token of a Discord webhook URL, which is all anyone
+// needs to post to the channel.
+var webhookPattern = regexp.MustCompile(`(https://(?:[\w-]+\.)?discord(?:app)?\.com/api(?:/v\d+)?/webhooks/\d+/)[\w.-]+`)
+
+// sensitiveKeys are attribute names whose values are never logged.
+var sensitiveKeys = []string{"token", "secret", "password", "authorization", "webhook_url"}
+
+// NewHandler returns a handler writing to w in format, which redacts secrets from every
+// attribute. Unknown formats fall back to text.
+func NewHandler(w io.Writer, format string, level slog.Leveler) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level, ReplaceAttr: redactAttr}
+	if format == FormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// Redact masks the tokens of Discord webhook URLs in s.
+func Redact(s string) string {
+	return webhookPattern.ReplaceAllString(s, "${1}"+redacted)
+}
+
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	key := strings.ToLower(a.Key)
+	for _, k := range sensitiveKeys {
+		if strings.Contains(key, k) {
+			return slog.String(a.Key, redacted)
+		}
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, Redact(a.Value.String()))
+	case slog.KindAny:
+		// Errors from net/http quote the request URL.
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, Redact(err.Error()))
+		}
+	}
+	return a
+}
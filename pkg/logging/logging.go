@@ -0,0 +1,86 @@
+// Package logging carries a correlated slog.Logger in the context, so every line logged
+// during a run can be traced back to the run, the Lambda invocation and the venue.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"log/slog"
+	"time"
+)
+
+// Attribute keys shared by every log line. Use these instead of ad hoc names, so lines
+// from different packages can be queried together.
+const (
+	KeyRunID     = "run_id"
+	KeyRequestID = "aws_request_id"
+	KeyMode      = "mode"
+	KeyVenue     = "venue"
+	KeyError     = "error"
+)
+
+type loggerKey struct{}
+
+type runIDKey struct{}
+
+type modeKey struct{}
+
+// NewContext returns a copy of ctx carrying logger.
+func NewContext(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or the default logger.
+func FromContext(ctx context.Context) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+	return slog.Default()
+}
+
+// With returns a copy of ctx whose logger adds args to every line.
+func With(ctx context.Context, args ...any) context.Context {
+	return NewContext(ctx, FromContext(ctx).With(args...))
+}
+
+// WithRunID tags the logger of ctx with id and makes it available to RunIDFromContext.
+func WithRunID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, runIDKey{}, id)
+	return With(ctx, KeyRunID, id)
+}
+
+// RunIDFromContext returns the run id set by WithRunID, or "".
+func RunIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(runIDKey{}).(string)
+	return id
+}
+
+// WithMode tags the logger of ctx with the run mode and makes it available to
+// ModeFromContext, so code outside the service can label its metrics by it.
+func WithMode(ctx context.Context, mode string) context.Context {
+	ctx = context.WithValue(ctx, modeKey{}, mode)
+	return With(ctx, KeyMode, mode)
+}
+
+// ModeFromContext returns the run mode set by WithMode, or "".
+func ModeFromContext(ctx context.Context) string {
+	mode, _ := ctx.Value(modeKey{}).(string)
+	return mode
+}
+
+// randRead is replaced in tests to simulate a failing random source.
+var randRead = rand.Read
+
+// NewRunID returns a random id, short enough to paste into a log search. If no random
+// bytes are available, it falls back to the current time, which is still distinct
+// between runs.
+func NewRunID() string {
+	b := make([]byte, 8)
+	if _, err := randRead(b); err != nil {
+		slog.Warn("failed to read random run id, using the time instead", KeyError, err)
+		binary.BigEndian.PutUint64(b, uint64(time.Now().UnixNano()))
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,103 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const webhookURL = "https://discord.com/api/webhooks/123456789/abcDEF-ghi_jkl"
+
+func TestFromContext_Default(t *testing.T) {
+	assert.Same(t, slog.Default(), FromContext(context.Background()))
+}
+
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := NewContext(context.Background(), slog.New(NewHandler(&buf, FormatJSON, slog.LevelInfo)))
+
+	ctx = WithRunID(ctx, "run-1")
+	ctx = WithMode(ctx, "daily")
+	ctx = With(ctx, KeyVenue, "yokohama_arena")
+	FromContext(ctx).Info("fetched")
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "run-1", line[KeyRunID])
+	assert.Equal(t, "daily", line[KeyMode])
+	assert.Equal(t, "yokohama_arena", line[KeyVenue])
+	assert.Equal(t, "run-1", RunIDFromContext(ctx))
+	assert.Empty(t, RunIDFromContext(context.Background()))
+	assert.Equal(t, "daily", ModeFromContext(ctx))
+	assert.Empty(t, ModeFromContext(context.Background()))
+}
+
+func TestNewRunID(t *testing.T) {
+	id := NewRunID()
+
+	assert.Len(t, id, 16)
+	assert.NotEqual(t, id, NewRunID())
+}
+
+func TestNewRunID_FallsBackToTime(t *testing.T) {
+	randRead = func([]byte) (int, error) { return 0, errors.New("no entropy") }
+	t.Cleanup(func() { randRead = rand.Read })
+
+	id := NewRunID()
+
+	assert.Len(t, id, 16)
+	assert.NotEqual(t, "0000000000000000", id)
+}
+
+func TestNewHandler_Redacts(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, FormatText, slog.LevelInfo))
+
+	logger.Info("posting to "+webhookURL,
+		KeyError, fmt.Errorf("Post %q: dial tcp: timeout", webhookURL),
+		"url", webhookURL,
+		"bot_token", "secret-value",
+		"MAINTAINER_WEBHOOK_URL", "https://example.com/hook",
+	)
+
+	out := buf.String()
+	assert.NotContains(t, out, "abcDEF-ghi_jkl")
+	assert.NotContains(t, out, "secret-value")
+	assert.NotContains(t, out, "example.com/hook")
+	assert.Contains(t, out, "https://discord.com/api/webhooks/123456789/[REDACTED]")
+	assert.Contains(t, out, "dial tcp: timeout")
+}
+
+func TestNewHandler_Level(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(NewHandler(&buf, FormatJSON, slog.LevelWarn))
+
+	logger.Info("ignored")
+	logger.Warn("kept", KeyError, errors.New("boom"))
+
+	assert.NotContains(t, buf.String(), "ignored")
+	assert.Contains(t, buf.String(), `"error":"boom"`)
+}
+
+func TestRedact(t *testing.T) {
+	testCases := []struct {
+		in   string
+		want string
+	}{
+		{in: webhookURL, want: "https://discord.com/api/webhooks/123456789/[REDACTED]"},
+		{in: "https://canary.discordapp.com/api/v10/webhooks/1/tok?wait=true", want: "https://canary.discordapp.com/api/v10/webhooks/1/[REDACTED]?wait=true"},
+		{in: "https://www.yokohama-arena.co.jp/event", want: "https://www.yokohama-arena.co.jp/event"},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.want, Redact(tc.in))
+	}
+}
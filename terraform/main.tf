@@ -1,8 +1,54 @@
 locals {
-  function_name_daily  = "${var.project_name}-lambda-daily"
-  function_name_weekly = "${var.project_name}-lambda-weekly"
-  state_machine_name   = "${var.project_name}-notification"
-  bucket_name          = "${var.project_name}-artifacts"
+  function_name_daily    = "${var.project_name}-lambda-daily"
+  function_name_weekly   = "${var.project_name}-lambda-weekly"
+  function_name_tomorrow = "${var.project_name}-lambda-tomorrow"
+  function_name_monthly  = "${var.project_name}-lambda-monthly"
+  state_machine_name     = "${var.project_name}-notification"
+  bucket_name            = "${var.project_name}-artifacts"
+  fetch_cache_prefix     = "http-cache/"
+  fallback_prefix        = "last-known-good/"
+  message_store_prefix   = "discord-messages/"
+  archive_table_name     = "${var.project_name}-event-archive"
+
+  fetch_cache_environment = {
+    FETCH_CACHE        = "s3"
+    FETCH_CACHE_BUCKET = local.bucket_name
+    FETCH_CACHE_PREFIX = local.fetch_cache_prefix
+    FETCH_CACHE_TTL    = var.fetch_cache_ttl
+  }
+
+  # Last successful fetch per venue and date, shown when a venue's site is down.
+  fallback_environment = {
+    FALLBACK_STORE   = "s3"
+    FALLBACK_BUCKET  = local.bucket_name
+    FALLBACK_PREFIX  = local.fallback_prefix
+    FALLBACK_MAX_AGE = var.fallback_max_age
+  }
+
+  # IDs of posted Discord messages, so a rerun for the same date edits its message.
+  message_store_environment = {
+    DISCORD_MESSAGE_STORE_BUCKET = local.bucket_name
+    DISCORD_MESSAGE_STORE_PREFIX = local.message_store_prefix
+  }
+
+  # Every fetched event, kept for the stats command.
+  archive_environment = {
+    ARCHIVE_STORE = "dynamodb"
+    ARCHIVE_TABLE = local.archive_table_name
+  }
+
+  # Custom metrics in CloudWatch Embedded Metric Format, extracted from the Lambda logs.
+  metrics_environment = {
+    METRICS           = "emf"
+    METRICS_NAMESPACE = var.metrics_namespace
+  }
+
+  # Traces are exported over OTLP/HTTP only when a collector endpoint is configured.
+  tracing_environment = var.otlp_endpoint == "" ? {} : {
+    OTEL_TRACES_EXPORTER        = "otlp"
+    OTEL_EXPORTER_OTLP_ENDPOINT = var.otlp_endpoint
+    OTEL_EXPORTER_OTLP_HEADERS  = var.otlp_headers
+  }
 
   common_tags = merge(
     {
@@ -41,6 +87,58 @@ resource "aws_s3_bucket_lifecycle_configuration" "lambda_artifacts" {
       noncurrent_days = 30
     }
   }
+
+  rule {
+    id     = "expire-fetch-cache"
+    status = "Enabled"
+
+    filter {
+      prefix = local.fetch_cache_prefix
+    }
+
+    expiration {
+      days = 1
+    }
+
+    noncurrent_version_expiration {
+      noncurrent_days = 1
+    }
+  }
+
+  rule {
+    id     = "expire-fallback-snapshots"
+    status = "Enabled"
+
+    filter {
+      prefix = local.fallback_prefix
+    }
+
+    expiration {
+      days = 14
+    }
+
+    noncurrent_version_expiration {
+      noncurrent_days = 1
+    }
+  }
+
+  # Messages are edited at most until the end of the month they cover.
+  rule {
+    id     = "expire-discord-messages"
+    status = "Enabled"
+
+    filter {
+      prefix = local.message_store_prefix
+    }
+
+    expiration {
+      days = 40
+    }
+
+    noncurrent_version_expiration {
+      noncurrent_days = 1
+    }
+  }
 }
 
 resource "aws_s3_bucket_public_access_block" "lambda_artifacts" {
@@ -101,6 +199,80 @@ resource "aws_iam_role_policy" "lambda_secrets_manager" {
   })
 }
 
+# Lets the notification functions share downloaded venue pages, the last-known-good
+# snapshots and the IDs of posted Discord messages.
+resource "aws_iam_role_policy" "lambda_fetch_cache" {
+  name = "${var.project_name}-fetch-cache-access"
+  role = aws_iam_role.lambda_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "s3:GetObject",
+          "s3:PutObject"
+        ]
+        Resource = [
+          "${aws_s3_bucket.lambda_artifacts.arn}/${local.fetch_cache_prefix}*",
+          "${aws_s3_bucket.lambda_artifacts.arn}/${local.fallback_prefix}*",
+          "${aws_s3_bucket.lambda_artifacts.arn}/${local.message_store_prefix}*"
+        ]
+      },
+      {
+        # Without ListBucket a missing entry is reported as AccessDenied instead of NoSuchKey.
+        Effect   = "Allow"
+        Action   = "s3:ListBucket"
+        Resource = aws_s3_bucket.lambda_artifacts.arn
+        Condition = {
+          StringLike = {
+            "s3:prefix" = ["${local.fetch_cache_prefix}*", "${local.fallback_prefix}*", "${local.message_store_prefix}*"]
+          }
+        }
+      }
+    ]
+  })
+}
+
+resource "aws_dynamodb_table" "event_archive" {
+  name         = local.archive_table_name
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "venue"
+  range_key    = "event_key"
+
+  attribute {
+    name = "venue"
+    type = "S"
+  }
+
+  attribute {
+    name = "event_key"
+    type = "S"
+  }
+
+  tags = local.common_tags
+}
+
+resource "aws_iam_role_policy" "lambda_event_archive" {
+  name = "${var.project_name}-event-archive-access"
+  role = aws_iam_role.lambda_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect = "Allow"
+        Action = [
+          "dynamodb:UpdateItem",
+          "dynamodb:Query"
+        ]
+        Resource = aws_dynamodb_table.event_archive.arn
+      }
+    ]
+  })
+}
+
 resource "aws_cloudwatch_log_group" "lambda_daily" {
   name              = "/aws/lambda/${local.function_name_daily}"
   retention_in_days = var.log_retention_days
@@ -115,6 +287,20 @@ resource "aws_cloudwatch_log_group" "lambda_weekly" {
   tags = local.common_tags
 }
 
+resource "aws_cloudwatch_log_group" "lambda_tomorrow" {
+  name              = "/aws/lambda/${local.function_name_tomorrow}"
+  retention_in_days = var.log_retention_days
+
+  tags = local.common_tags
+}
+
+resource "aws_cloudwatch_log_group" "lambda_monthly" {
+  name              = "/aws/lambda/${local.function_name_monthly}"
+  retention_in_days = var.log_retention_days
+
+  tags = local.common_tags
+}
+
 resource "aws_s3_object" "lambda_daily_package" {
   bucket = aws_s3_bucket.lambda_artifacts.id
   key    = "lambda-daily.zip"
@@ -133,6 +319,24 @@ resource "aws_s3_object" "lambda_weekly_package" {
   tags = local.common_tags
 }
 
+resource "aws_s3_object" "lambda_tomorrow_package" {
+  bucket = aws_s3_bucket.lambda_artifacts.id
+  key    = "lambda-tomorrow.zip"
+  source = "../lambda-tomorrow.zip"
+  etag   = filemd5("../lambda-tomorrow.zip")
+
+  tags = local.common_tags
+}
+
+resource "aws_s3_object" "lambda_monthly_package" {
+  bucket = aws_s3_bucket.lambda_artifacts.id
+  key    = "lambda-monthly.zip"
+  source = "../lambda-monthly.zip"
+  etag   = filemd5("../lambda-monthly.zip")
+
+  tags = local.common_tags
+}
+
 resource "aws_lambda_function" "notification_daily" {
   function_name = local.function_name_daily
   role          = aws_iam_role.lambda_execution.arn
@@ -148,15 +352,16 @@ resource "aws_lambda_function" "notification_daily" {
   timeout     = var.lambda_timeout
 
   environment {
-    variables = {
+    variables = merge(local.fetch_cache_environment, local.fallback_environment, local.message_store_environment, local.archive_environment, local.metrics_environment, local.tracing_environment, {
       SECRET_ARN = aws_secretsmanager_secret.discord_webhook.arn
-    }
+    })
   }
 
   depends_on = [
     aws_cloudwatch_log_group.lambda_daily,
     aws_iam_role_policy_attachment.lambda_basic_execution,
-    aws_iam_role_policy.lambda_secrets_manager
+    aws_iam_role_policy.lambda_secrets_manager,
+    aws_iam_role_policy.lambda_fetch_cache
   ]
 
   tags = local.common_tags
@@ -177,15 +382,77 @@ resource "aws_lambda_function" "notification_weekly" {
   timeout     = var.lambda_weekly_timeout
 
   environment {
-    variables = {
+    variables = merge(local.fetch_cache_environment, local.fallback_environment, local.message_store_environment, local.archive_environment, local.metrics_environment, local.tracing_environment, {
       SECRET_ARN = aws_secretsmanager_secret.discord_webhook.arn
-    }
+    })
   }
 
   depends_on = [
     aws_cloudwatch_log_group.lambda_weekly,
     aws_iam_role_policy_attachment.lambda_basic_execution,
-    aws_iam_role_policy.lambda_secrets_manager
+    aws_iam_role_policy.lambda_secrets_manager,
+    aws_iam_role_policy.lambda_fetch_cache
+  ]
+
+  tags = local.common_tags
+}
+
+resource "aws_lambda_function" "notification_tomorrow" {
+  function_name = local.function_name_tomorrow
+  role          = aws_iam_role.lambda_execution.arn
+  handler       = "bootstrap"
+  runtime       = "provided.al2023"
+  architectures = ["arm64"]
+
+  s3_bucket        = aws_s3_bucket.lambda_artifacts.id
+  s3_key           = aws_s3_object.lambda_tomorrow_package.key
+  source_code_hash = filebase64sha256("../lambda-tomorrow.zip")
+
+  memory_size = var.lambda_memory_size
+  timeout     = var.lambda_timeout
+
+  environment {
+    variables = merge(local.fetch_cache_environment, local.fallback_environment, local.message_store_environment, local.archive_environment, local.metrics_environment, local.tracing_environment, {
+      SECRET_ARN          = aws_secretsmanager_secret.discord_webhook.arn
+      SKIP_EMPTY_TOMORROW = tostring(var.skip_empty_tomorrow)
+    })
+  }
+
+  depends_on = [
+    aws_cloudwatch_log_group.lambda_tomorrow,
+    aws_iam_role_policy_attachment.lambda_basic_execution,
+    aws_iam_role_policy.lambda_secrets_manager,
+    aws_iam_role_policy.lambda_fetch_cache
+  ]
+
+  tags = local.common_tags
+}
+
+resource "aws_lambda_function" "notification_monthly" {
+  function_name = local.function_name_monthly
+  role          = aws_iam_role.lambda_execution.arn
+  handler       = "bootstrap"
+  runtime       = "provided.al2023"
+  architectures = ["arm64"]
+
+  s3_bucket        = aws_s3_bucket.lambda_artifacts.id
+  s3_key           = aws_s3_object.lambda_monthly_package.key
+  source_code_hash = filebase64sha256("../lambda-monthly.zip")
+
+  memory_size = var.lambda_memory_size
+  timeout     = var.lambda_monthly_timeout
+
+  environment {
+    variables = merge(local.fetch_cache_environment, local.fallback_environment, local.message_store_environment, local.archive_environment, local.metrics_environment, local.tracing_environment, {
+      SECRET_ARN = aws_secretsmanager_secret.discord_webhook.arn
+    })
+  }
+
+  depends_on = [
+    aws_cloudwatch_log_group.lambda_monthly,
+    aws_iam_role_policy_attachment.lambda_basic_execution,
+    aws_iam_role_policy.lambda_secrets_manager,
+    aws_iam_role_policy.lambda_fetch_cache
   ]
 
   tags = local.common_tags
@@ -234,6 +501,7 @@ resource "aws_iam_role_policy" "sfn_lambda_invoke" {
         Resource = [
           aws_lambda_function.notification_daily.arn,
           aws_lambda_function.notification_weekly.arn,
+          aws_lambda_function.notification_monthly.arn,
         ]
       }
     ]
@@ -272,16 +540,41 @@ resource "aws_sfn_state_machine" "notification" {
   role_arn = aws_iam_role.sfn_execution.arn
 
   definition = jsonencode({
-    Comment       = "Shin-Yokohama event notification workflow. Runs weekly (Monday only) then daily."
+    Comment       = "Shin-Yokohama event notification workflow. Runs monthly (1st only), weekly (Monday only) then daily."
     QueryLanguage = "JSONata"
     StartAt       = "CheckDayOfWeek"
     States = {
       CheckDayOfWeek = {
         Type    = "Pass"
-        Comment = "Calculates day-of-week in JST from current UTC time. 32400000 = UTC+9 (JST) offset in milliseconds, 86400000 = milliseconds per day. Result mapping is Sunday=0, Monday=1, ... Saturday=6; IsMonday checks for 1."
+        Comment = "Calculates day-of-week and day-of-month in JST from current UTC time. 32400000 = UTC+9 (JST) offset in milliseconds, 86400000 = milliseconds per day. Result mapping is Sunday=0, Monday=1, ... Saturday=6; IsMonday checks for 1."
         Assign = {
-          dayOfWeek = "{% ($floor(($toMillis($now()) + 32400000) / 86400000) + 4) % 7 %}"
+          dayOfWeek  = "{% ($floor(($toMillis($now()) + 32400000) / 86400000) + 4) % 7 %}"
+          dayOfMonth = "{% $number($fromMillis($toMillis($now()), '[D]', '+0900')) %}"
+        }
+        Next = "IsFirstOfMonth"
+      }
+      IsFirstOfMonth = {
+        Type = "Choice"
+        Choices = [
+          {
+            Condition = "{% $dayOfMonth = 1 %}"
+            Next      = "RunMonthly"
+          }
+        ]
+        Default = "IsMonday"
+      }
+      RunMonthly = {
+        Type     = "Task"
+        Resource = "arn:aws:states:::lambda:invoke"
+        Arguments = {
+          FunctionName = aws_lambda_function.notification_monthly.arn
         }
+        Catch = [
+          {
+            ErrorEquals = ["States.ALL"]
+            Next        = "IsMonday"
+          }
+        ]
         Next = "IsMonday"
       }
       IsMonday = {
@@ -383,3 +676,36 @@ resource "aws_scheduler_schedule" "notification" {
     role_arn = aws_iam_role.scheduler_execution.arn
   }
 }
+
+resource "aws_iam_role_policy" "scheduler_lambda_invoke" {
+  name = "${var.project_name}-scheduler-lambda-invoke"
+  role = aws_iam_role.scheduler_execution.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["lambda:InvokeFunction"]
+        Resource = aws_lambda_function.notification_tomorrow.arn
+      }
+    ]
+  })
+}
+
+resource "aws_scheduler_schedule" "tomorrow" {
+  name        = "${var.project_name}-tomorrow-schedule"
+  description = "Trigger the evening preview of tomorrow's events"
+
+  flexible_time_window {
+    mode = "OFF"
+  }
+
+  schedule_expression          = var.tomorrow_schedule_expression
+  schedule_expression_timezone = "Asia/Tokyo"
+
+  target {
+    arn      = aws_lambda_function.notification_tomorrow.arn
+    role_arn = aws_iam_role.scheduler_execution.arn
+  }
+}
@@ -18,6 +18,26 @@ output "lambda_weekly_function_arn" {
   value       = aws_lambda_function.notification_weekly.arn
 }
 
+output "lambda_tomorrow_function_name" {
+  description = "Name of the tomorrow preview Lambda function"
+  value       = aws_lambda_function.notification_tomorrow.function_name
+}
+
+output "lambda_tomorrow_function_arn" {
+  description = "ARN of the tomorrow preview Lambda function"
+  value       = aws_lambda_function.notification_tomorrow.arn
+}
+
+output "lambda_monthly_function_name" {
+  description = "Name of the monthly outlook Lambda function"
+  value       = aws_lambda_function.notification_monthly.function_name
+}
+
+output "lambda_monthly_function_arn" {
+  description = "ARN of the monthly outlook Lambda function"
+  value       = aws_lambda_function.notification_monthly.arn
+}
+
 output "eventbridge_schedule_name" {
   description = "Name of the EventBridge Scheduler schedule"
   value       = aws_scheduler_schedule.notification.name
@@ -38,6 +58,11 @@ output "s3_bucket_name" {
   value       = aws_s3_bucket.lambda_artifacts.id
 }
 
+output "event_archive_table_name" {
+  description = "Name of the DynamoDB table archiving fetched events"
+  value       = aws_dynamodb_table.event_archive.name
+}
+
 output "cloudwatch_log_group_daily" {
   description = "CloudWatch log group name for the daily Lambda"
   value       = aws_cloudwatch_log_group.lambda_daily.name
@@ -28,12 +28,61 @@ variable "lambda_weekly_timeout" {
   default     = 120
 }
 
+variable "lambda_monthly_timeout" {
+  description = "Timeout for monthly outlook Lambda function in seconds"
+  type        = number
+  default     = 180
+}
+
 variable "schedule_expression" {
   description = "Amazon EventBridge Scheduler cron expression for triggering the notification workflow (Asia/Tokyo timezone)"
   type        = string
   default     = "cron(0 6 * * ? *)" # Daily at 6AM JST
 }
 
+variable "tomorrow_schedule_expression" {
+  description = "Amazon EventBridge Scheduler cron expression for the evening preview of tomorrow's events (Asia/Tokyo timezone)"
+  type        = string
+  default     = "cron(0 21 * * ? *)" # Daily at 9PM JST
+}
+
+variable "skip_empty_tomorrow" {
+  description = "Skip the evening preview when no events are scheduled tomorrow"
+  type        = bool
+  default     = false
+}
+
+variable "fetch_cache_ttl" {
+  description = "How long downloaded venue pages are reused before being revalidated (Go duration, e.g. 30m)"
+  type        = string
+  default     = "30m"
+}
+
+variable "fallback_max_age" {
+  description = "Maximum age of the previously fetched events shown when a venue's site is down (Go duration, e.g. 48h)"
+  type        = string
+  default     = "48h"
+}
+
+variable "metrics_namespace" {
+  description = "CloudWatch namespace of the custom metrics emitted by the Lambda functions"
+  type        = string
+  default     = "ShinYokohamaEventNotifier"
+}
+
+variable "otlp_endpoint" {
+  description = "OTLP/HTTP endpoint the Lambda functions export traces to (e.g. the Grafana Cloud OTLP gateway). Tracing is off when empty"
+  type        = string
+  default     = ""
+}
+
+variable "otlp_headers" {
+  description = "Headers sent with exported traces, in OTEL_EXPORTER_OTLP_HEADERS format (e.g. Authorization=Basic ...)"
+  type        = string
+  default     = ""
+  sensitive   = true
+}
+
 variable "log_retention_days" {
   description = "CloudWatch Logs retention period in days"
   type        = number